@@ -14,10 +14,13 @@ import (
 	"github.com/fission/fission-workflows/pkg/api/projectors"
 	"github.com/fission/fission-workflows/pkg/api/store"
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/cluster"
 	"github.com/fission/fission-workflows/pkg/controller"
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
 	"github.com/fission/fission-workflows/pkg/controller/executor"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
 	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/bolt"
 	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
 	"github.com/fission/fission-workflows/pkg/fes/cache"
@@ -26,7 +29,9 @@ import (
 	"github.com/fission/fission-workflows/pkg/fnenv/native"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/fnenv/workflows"
+	"github.com/fission/fission-workflows/pkg/retention"
 	"github.com/fission/fission-workflows/pkg/scheduler"
+	"github.com/fission/fission-workflows/pkg/triggers"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/util"
 	"github.com/fission/fission-workflows/pkg/util/labels"
@@ -54,11 +59,16 @@ const (
 	jaegerTracerServiceName      = "fission.workflows"
 	WorkflowsCacheSize           = 10000
 	InvocationsCacheSize         = 100000
+	TriggersCacheSize            = 10000
+	ReplicasCacheSize            = 1000
+	TaskResultsCacheSize         = 10000
 	executorMaxParallelism       = 1000
 	executorMaxTaskQueueSize     = 100000
 	workflowStorePollInterval    = time.Minute
 	invocationStorePollInterval  = time.Second
 	workflowSubscriptionBuffer   = 50
+	triggerSubscriptionBuffer    = 50
+	replicaSubscriptionBuffer    = 50
 	invocationSubscriptionBuffer = 1000
 )
 
@@ -94,6 +104,11 @@ func (app *App) Close() error {
 
 type Options struct {
 	NATS                 *nats.Config
+	Bolt                 *bolt.Config
+	SnapshotInterval     int
+	Retention            *retention.Config
+	Triggers             *triggers.Config
+	Cluster              *cluster.Config
 	Scheduler            scheduler.Policy
 	Fission              *FissionOptions
 	FissionProxy         *FissionProxyConfig
@@ -104,8 +119,23 @@ type Options struct {
 	WorkflowAPI          bool
 	HTTPGateway          bool
 	InvocationAPI        bool
+	TriggerAPI           bool
+	SchedulerAPI         bool
 	Metrics              bool
 	Debug                bool
+
+	// ControllerWorkers is the number of invocations and workflows that the controllers evaluate in parallel. If 0 or
+	// less, ctrl.DefaultWorkers is used.
+	ControllerWorkers int
+
+	// MaxConcurrencyPerReplica is the default maximum number of invocations of a workflow that are in progress at the
+	// same time in this replica, for the workflows that do not specify a limit of their own. If 0 or less, those
+	// workflows are not limited.
+	MaxConcurrencyPerReplica int
+
+	// DrainTimeout is the maximum time to wait for the running tasks to finish when the bundle is stopped. If 0, the
+	// bundle is stopped without draining.
+	DrainTimeout time.Duration
 }
 
 type FissionOptions struct {
@@ -195,6 +225,13 @@ func Run(ctx context.Context, opts *Options) error {
 		es = natsBackend
 		esPub = natsBackend
 		eventStore = natsBackend
+	} else if opts.Bolt != nil {
+		log.WithField("path", opts.Bolt.Path).Info("Using event store: BoltDB")
+		boltBackend := setupBoltEventStore(*opts.Bolt)
+		app.RegisterCloser("event-store", boltBackend)
+		es = boltBackend
+		esPub = boltBackend
+		eventStore = boltBackend
 	} else {
 		log.Info("Using the in-memory event store")
 		memBackend := mem.NewBackend()
@@ -204,8 +241,10 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 
 	// Caches
-	invocationStore := getInvocationStore(app, esPub, eventStore)
+	invocationCache := setupWorkflowInvocationCache(app, esPub, eventStore)
+	invocationStore := store.NewInvocationStore(invocationCache)
 	workflowStore := getWorkflowStore(app, esPub, eventStore)
+	triggerStore := store.NewTriggerStore(setupTriggerCache(app, esPub, eventStore))
 
 	//
 	// Function Runtimes
@@ -222,7 +261,7 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 	if opts.InternalRuntime {
 		log.Infof("Using function runtime: Internal")
-		internalRuntime := setupInternalFunctionRuntime()
+		internalRuntime := setupInternalFunctionRuntime(invocationStore)
 		runtimes["internal"] = internalRuntime
 		resolvers["internal"] = internalRuntime
 		log.Infof("Internal runtime functions: %v", internalRuntime.Installed())
@@ -243,12 +282,34 @@ func Run(ctx context.Context, opts *Options) error {
 	//
 	sched := SetupScheduler(opts.Scheduler)
 
+	//
+	// Cluster
+	//
+	// Without a cluster config, this replica processes all entities.
+	drainer := &Drainer{}
+	var partition ctrl.Partition
+	if opts.Cluster != nil {
+		if err := cluster.ValidateBackend(eventStore); err != nil {
+			log.Fatalf("Cannot divide the invocations among replicas: %v", err)
+		}
+		membership := cluster.NewMembership(eventStore, *opts.Cluster,
+			store.NewReplicaStore(setupReplicaCache(app, esPub, eventStore)), api.NewReplicaAPI(es))
+		ps.Register(membership)
+		partition = membership
+		drainer.membership = membership
+	}
+
 	//
 	// Controllers
 	//
+	ctrlConfig := ctrl.Config{
+		Workers:   opts.ControllerWorkers,
+		Partition: partition,
+	}
 	if opts.WorkflowController {
 		log.Info("Running workflow controller")
-		workflowCtrl := setupWorkflowController(workflowStore, es, resolvers)
+		workflowCtrl := setupWorkflowController(workflowStore, es, resolvers, ctrlConfig)
+		drainer.workflowCtrl = workflowCtrl
 		go workflowCtrl.Run()
 		defer func() {
 			if err := workflowCtrl.Close(); err != nil {
@@ -260,7 +321,9 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 	if opts.InvocationController {
 		log.Info("Running invocation controller")
-		invocationCtrl := setupInvocationController(invocationStore, es, runtimes, resolvers, sched)
+		invocationCtrl := setupInvocationController(invocationStore, es, runtimes, resolvers, sched,
+			opts.MaxConcurrencyPerReplica, ctrlConfig)
+		drainer.invocationCtrl = invocationCtrl
 		go invocationCtrl.Run()
 		defer func() {
 			if err := invocationCtrl.Close(); err != nil {
@@ -271,6 +334,28 @@ func Run(ctx context.Context, opts *Options) error {
 		}()
 	}
 
+	//
+	// Retention
+	//
+	if opts.Retention != nil {
+		collector, err := retention.NewCollector(eventStore, *opts.Retention, invocationCache)
+		if err != nil {
+			log.Warnf("Retention is disabled: %v", err)
+		} else {
+			ps.Register(collector)
+		}
+	}
+
+	//
+	// Triggers
+	//
+	if opts.Triggers != nil {
+		triggerScheduler := triggers.NewScheduler(eventStore, *opts.Triggers, triggerStore, workflowStore,
+			invocationStore, api.NewTriggerAPI(es), invocationAPI, partition)
+		ps.Register(triggerScheduler)
+		drainer.triggers = triggerScheduler
+	}
+
 	//
 	// Fission integration
 	//
@@ -280,7 +365,7 @@ func Run(ctx context.Context, opts *Options) error {
 	// gRPC API
 	//
 	if opts.AdminAPI {
-		serveAdminAPI(grpcServer)
+		serveAdminAPI(grpcServer, es, drainer)
 	}
 
 	if opts.WorkflowAPI {
@@ -288,10 +373,18 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 
 	if opts.InvocationAPI {
-		serveInvocationAPI(grpcServer, es, invocationStore, workflowStore)
+		serveInvocationAPI(grpcServer, es, invocationStore, workflowStore, drainer)
 	}
 
-	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI {
+	if opts.TriggerAPI {
+		serveTriggerAPI(grpcServer, es, triggerStore)
+	}
+
+	if opts.SchedulerAPI {
+		serveSchedulerAPI(grpcServer, sched)
+	}
+
+	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI || opts.TriggerAPI || opts.SchedulerAPI {
 		if opts.Metrics {
 			log.Debug("Instrumenting gRPC server with Prometheus metrics")
 			grpc_prometheus.Register(grpcServer)
@@ -314,12 +407,13 @@ func Run(ctx context.Context, opts *Options) error {
 	// HTTP API
 	//
 	if opts.HTTPGateway || opts.Metrics {
-		grpcMux := grpcruntime.NewServeMux()
+		grpcMux := grpcruntime.NewServeMux(
+			grpcruntime.WithMarshalerOption(apiserver.MIMEEventStream, apiserver.NewEventStreamMarshaler()))
 		httpMux := http.NewServeMux()
 
 		if opts.HTTPGateway {
 
-			var admin, wf, wfi string
+			var admin, wf, wfi, tr string
 			if opts.AdminAPI {
 				admin = gRPCAddress
 			}
@@ -329,7 +423,10 @@ func Run(ctx context.Context, opts *Options) error {
 			if opts.InvocationAPI {
 				wfi = gRPCAddress
 			}
-			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi)
+			if opts.TriggerAPI {
+				tr = gRPCAddress
+			}
+			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi, tr)
 		}
 
 		if opts.Metrics {
@@ -356,6 +453,11 @@ func Run(ctx context.Context, opts *Options) error {
 	log.Info("Setup completed.")
 	<-ctx.Done()
 	log.WithField("reason", ctx.Err()).Info("Shutting down...")
+	if opts.DrainTimeout > 0 {
+		drainCtx, cancel := context.WithTimeout(context.Background(), opts.DrainTimeout)
+		drainer.Drain(drainCtx)
+		cancel()
+	}
 	logIfErr(ps.Close())
 	util.LogIfError(app.Close())
 	time.Sleep(5 * time.Second) // Hack: wait a bit to ensure all goroutines are shutdown.
@@ -367,13 +469,14 @@ func getWorkflowStore(app *App, eventPub pubsub.Publisher, backend fes.Backend)
 	return store.NewWorkflowsStore(c)
 }
 
-func getInvocationStore(app *App, eventPub pubsub.Publisher, backend fes.Backend) *store.Invocations {
-	c := setupWorkflowInvocationCache(app, eventPub, backend)
-	return store.NewInvocationStore(c)
-}
-
-func setupInternalFunctionRuntime() *native.FunctionEnv {
-	return native.NewFunctionEnv(builtin.DefaultBuiltinFunctions)
+func setupInternalFunctionRuntime(invocations *store.Invocations) *native.FunctionEnv {
+	fns := map[string]native.InternalFunction{}
+	for name, fn := range builtin.DefaultBuiltinFunctions {
+		fns[name] = fn
+	}
+	// The await function depends on the invocation store to receive the signals sent to the invocation.
+	fns[builtin.Await] = builtin.NewFunctionAwait(invocations)
+	return native.NewFunctionEnv(fns)
 }
 
 func setupFissionFunctionRuntime(fissionOpts *FissionOptions) *fission.FunctionEnv {
@@ -398,9 +501,36 @@ func setupNatsEventStoreClient(config nats.Config) *nats.EventStore {
 	if err != nil {
 		panic(err)
 	}
+	err = es.Watch(fes.Aggregate{Type: types.TypeTrigger})
+	if err != nil {
+		panic(err)
+	}
+	err = es.Watch(fes.Aggregate{Type: types.TypeReplica})
+	if err != nil {
+		panic(err)
+	}
 	return es
 }
 
+func setupBoltEventStore(config bolt.Config) *bolt.Backend {
+	es, err := bolt.Open(config)
+	if err != nil {
+		panic(err)
+	}
+	return es
+}
+
+// setupLoadingCache creates a loading cache, which stores snapshots of the entities if the backend supports it.
+func setupLoadingCache(app *App, c fes.CacheReaderWriter, backend fes.Backend,
+	projector fes.Projector) *cache.LoadingCache {
+	snapshots, ok := backend.(fes.SnapshotStore)
+	if !ok || app.SnapshotInterval <= 0 {
+		return cache.NewLoadingCache(c, backend, projector)
+	}
+	return cache.NewLoadingCacheWithSnapshots(c, backend, projector, snapshots,
+		cache.EveryNEvents(app.SnapshotInterval))
+}
+
 func setupWorkflowInvocationCache(app *App, invocationEventPub pubsub.Publisher, backend fes.Backend) *cache.SubscribedCache {
 	sub := invocationEventPub.Subscribe(pubsub.SubscriptionOptions{
 		Buffer: invocationSubscriptionBuffer,
@@ -411,10 +541,7 @@ func setupWorkflowInvocationCache(app *App, invocationEventPub pubsub.Publisher,
 	name := types.TypeInvocation
 	projector := projectors.NewWorkflowInvocation()
 	c := cache.NewSubscribedCache(
-		cache.NewLoadingCache(
-			cache.NewLRUCache(InvocationsCacheSize),
-			backend,
-			projector),
+		setupLoadingCache(app, cache.NewLRUCache(InvocationsCacheSize), backend, projector),
 		projector,
 		sub)
 	app.RegisterCloser("cache-"+name, c)
@@ -429,19 +556,45 @@ func setupWorkflowCache(app *App, workflowEventPub pubsub.Publisher, backend fes
 	name := types.TypeWorkflow
 	projector := projectors.NewWorkflow()
 	c := cache.NewSubscribedCache(
-		cache.NewLoadingCache(
-			cache.NewLRUCache(WorkflowsCacheSize),
-			backend,
-			projector,
-		),
+		setupLoadingCache(app, cache.NewLRUCache(WorkflowsCacheSize), backend, projector),
 		projector,
 		sub)
 	app.RegisterCloser("cache-"+name, c)
 	return c
 }
 
-func serveAdminAPI(s *grpc.Server) {
-	adminServer := &apiserver.Admin{}
+func setupTriggerCache(app *App, triggerEventPub pubsub.Publisher, backend fes.Backend) *cache.SubscribedCache {
+	sub := triggerEventPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       triggerSubscriptionBuffer,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, types.TypeTrigger),
+	})
+	name := types.TypeTrigger
+	projector := projectors.NewTrigger()
+	c := cache.NewSubscribedCache(
+		setupLoadingCache(app, cache.NewLRUCache(TriggersCacheSize), backend, projector),
+		projector,
+		sub)
+	app.RegisterCloser("cache-"+name, c)
+	return c
+}
+
+func setupReplicaCache(app *App, replicaEventPub pubsub.Publisher, backend fes.Backend) *cache.SubscribedCache {
+	sub := replicaEventPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       replicaSubscriptionBuffer,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, types.TypeReplica),
+	})
+	name := types.TypeReplica
+	projector := projectors.NewReplica()
+	c := cache.NewSubscribedCache(
+		setupLoadingCache(app, cache.NewLRUCache(ReplicasCacheSize), backend, projector),
+		projector,
+		sub)
+	app.RegisterCloser("cache-"+name, c)
+	return c
+}
+
+func serveAdminAPI(s *grpc.Server, es fes.Backend, drainer apiserver.Drainer) {
+	adminServer := apiserver.NewAdmin(es, drainer)
 	apiserver.RegisterAdminAPIServer(s, adminServer)
 	log.Infof("Serving admin gRPC API at %s.", gRPCAddress)
 }
@@ -455,15 +608,28 @@ func serveWorkflowAPI(s *grpc.Server, es fes.Backend, resolvers map[string]fnenv
 	log.Infof("Serving workflow gRPC API at %s.", gRPCAddress)
 }
 
-func serveInvocationAPI(s *grpc.Server, es fes.Backend, invocations *store.Invocations, workflows *store.Workflows) {
+func serveInvocationAPI(s *grpc.Server, es fes.Backend, invocations *store.Invocations, workflows *store.Workflows,
+	drainer apiserver.Drainer) {
 	invocationAPI := api.NewInvocationAPI(es)
-	invocationServer := apiserver.NewInvocation(invocationAPI, invocations, workflows, es)
+	invocationServer := apiserver.NewInvocation(invocationAPI, invocations, workflows, es, drainer)
 	apiserver.RegisterWorkflowInvocationAPIServer(s, invocationServer)
 	log.Infof("Serving workflow invocation gRPC API at %s.", gRPCAddress)
 }
 
+func serveTriggerAPI(s *grpc.Server, es fes.Backend, triggers *store.Triggers) {
+	triggerAPI := api.NewTriggerAPI(es)
+	triggerServer := apiserver.NewTrigger(triggerAPI, triggers, es)
+	apiserver.RegisterTriggerAPIServer(s, triggerServer)
+	log.Infof("Serving trigger gRPC API at %s.", gRPCAddress)
+}
+
+func serveSchedulerAPI(s *grpc.Server, sched *scheduler.InvocationScheduler) {
+	scheduler.RegisterSchedulerServer(s, scheduler.NewServer(sched))
+	log.Infof("Serving scheduler gRPC API at %s.", gRPCAddress)
+}
+
 func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAddr string, workflowAPIAddr string,
-	invocationAPIAddr string) {
+	invocationAPIAddr string, triggerAPIAddr string) {
 	tracer := opentracing.GlobalTracer()
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -494,11 +660,19 @@ func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAd
 		}
 		log.Info("Registered Workflow WorkflowInvocation API HTTP Endpoint")
 	}
+
+	if triggerAPIAddr != "" {
+		err := apiserver.RegisterTriggerAPIHandlerFromEndpoint(ctx, mux, triggerAPIAddr, opts)
+		if err != nil {
+			panic(err)
+		}
+		log.Info("Registered Trigger API HTTP Endpoint")
+	}
 }
 
 func setupInvocationController(invocations *store.Invocations, es fes.Backend,
 	fnRuntimes map[string]fnenv.Runtime, fnResolvers map[string]fnenv.RuntimeResolver,
-	s *scheduler.InvocationScheduler) *controller.InvocationMetaController {
+	s *scheduler.InvocationScheduler, maxConcurrency int, cfg ctrl.Config) *controller.InvocationMetaController {
 
 	workflowAPI := api.NewWorkflowAPI(es, fnenv.NewMetaResolver(fnResolvers))
 	invocationAPI := api.NewInvocationAPI(es)
@@ -506,14 +680,20 @@ func setupInvocationController(invocations *store.Invocations, es fes.Backend,
 	taskAPI := api.NewTaskAPI(fnRuntimes, es, dynamicAPI)
 	stateStore := expr.NewStore()
 	localExec := executor.NewLocalExecutor(executorMaxParallelism, executorMaxTaskQueueSize)
-	return controller.NewInvocationMetaController(localExec, invocations, invocationAPI, taskAPI, s, stateStore, invocationStorePollInterval)
+	limiter := controller.NewConcurrencyLimiter(maxConcurrency)
+	if err := limiter.Recover(es, invocations); err != nil {
+		log.Errorf("Failed to recover the concurrency limits of running invocations: %v", err)
+	}
+	results := controller.NewResultCache(TaskResultsCacheSize)
+	return controller.NewInvocationMetaController(localExec, invocations, invocationAPI, taskAPI, s, stateStore,
+		limiter, results, invocationStorePollInterval, cfg)
 }
 
 func setupWorkflowController(store *store.Workflows, es fes.Backend,
-	fnResolvers map[string]fnenv.RuntimeResolver) *controller.WorkflowMetaController {
+	fnResolvers map[string]fnenv.RuntimeResolver, cfg ctrl.Config) *controller.WorkflowMetaController {
 	wfAPI := api.NewWorkflowAPI(es, fnenv.NewMetaResolver(fnResolvers))
 	exec := executor.NewLocalExecutor(10, 1000)
-	return controller.NewWorkflowMetaController(wfAPI, store, exec, workflowStorePollInterval)
+	return controller.NewWorkflowMetaController(wfAPI, store, exec, workflowStorePollInterval, cfg)
 }
 
 func setupMetricsEndpoint(apiMux *http.ServeMux) {
@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/controller"
+	log "github.com/sirupsen/logrus"
+)
+
+// Drainer drains the bundle, so that it can be stopped without abandoning the tasks that it is running. Draining stops
+// the bundle from accepting new invocations, from firing triggers, and from scheduling new tasks. Once the running
+// tasks have finished, or the deadline has passed, the bundle leaves the cluster to hand over its invocations to the
+// other replicas.
+//
+// It implements apiserver.Drainer. Components that are not enabled in the bundle are left nil.
+type Drainer struct {
+	invocationCtrl *controller.InvocationMetaController
+	workflowCtrl   *controller.WorkflowMetaController
+	triggers       io.Closer
+	membership     io.Closer
+
+	draining  int32
+	stopOnce  sync.Once
+	leaveOnce sync.Once
+}
+
+func (d *Drainer) Draining() bool {
+	return atomic.LoadInt32(&d.draining) != 0
+}
+
+// Drain drains the bundle until the context is done. Draining is terminal: the bundle cannot resume accepting
+// invocations afterwards. It can be called multiple times; subsequent calls wait for the remaining tasks again.
+func (d *Drainer) Drain(ctx context.Context) *apiserver.DrainStatus {
+	atomic.StoreInt32(&d.draining, 1)
+	d.stopOnce.Do(func() {
+		log.Info("Draining: no longer accepting new invocations")
+		if d.triggers != nil {
+			logIfErr(d.triggers.Close())
+		}
+	})
+
+	// The invocations are drained first, because the nested invocations that are still progressed while draining may
+	// need the workflow controller to parse their workflows.
+	drained := true
+	status := &apiserver.DrainStatus{}
+	if d.invocationCtrl != nil {
+		if err := d.invocationCtrl.Drain(ctx); err != nil {
+			drained = false
+		}
+		pending, invocations := d.invocationCtrl.Pending()
+		status.PendingTasks = int64(pending)
+		status.Invocations = invocations
+	}
+	if d.workflowCtrl != nil {
+		if err := d.workflowCtrl.Drain(ctx); err != nil {
+			drained = false
+		}
+	}
+	status.Drained = drained
+
+	d.leaveOnce.Do(func() {
+		if d.membership != nil {
+			logIfErr(d.membership.Close())
+		}
+	})
+	if drained {
+		log.Info("Drained: all tasks have finished")
+	} else {
+		log.Warnf("Draining incomplete: %d task(s) of invocations %v have not finished", status.PendingTasks,
+			status.Invocations)
+	}
+	return status
+}
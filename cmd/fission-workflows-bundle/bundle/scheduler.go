@@ -13,6 +13,7 @@ type SchedulerPolicy string
 const (
 	FlagSchedulerPolicy            = "scheduler.policy"
 	FlagSchedulerColdStartDuration = "scheduler.coldstart"
+	FlagSchedulerRemoteAddress     = "scheduler.remote"
 )
 
 var schedulerPolicies = map[string]func(time.Duration) scheduler.Policy{
@@ -23,10 +24,20 @@ var schedulerPolicies = map[string]func(time.Duration) scheduler.Policy{
 		return scheduler.Policy(scheduler.NewPrewarmHorizonPolicy(coldStartModel))
 	},
 	"horizon": func(_ time.Duration) scheduler.Policy { return scheduler.Policy(scheduler.NewHorizonPolicy()) },
+	"adaptive": func(coldStartModel time.Duration) scheduler.Policy {
+		return scheduler.Policy(scheduler.NewAdaptivePolicy(coldStartModel))
+	},
 }
 
 func ParseSchedulerConfig(c *cli.Context) (scheduler.Policy, error) {
 	policyName := c.String(FlagSchedulerPolicy)
+	if policyName == "remote" {
+		addr := c.String(FlagSchedulerRemoteAddress)
+		if len(addr) == 0 {
+			return nil, fmt.Errorf("remote scheduler policy requires --%s", FlagSchedulerRemoteAddress)
+		}
+		return scheduler.ConnectRemotePolicy(addr)
+	}
 	policy, ok := schedulerPolicies[policyName]
 	if !ok {
 		return nil, fmt.Errorf("unknown scheduler policy '%s'", policyName)
@@ -5,17 +5,35 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/fission/fission-workflows/pkg/cluster"
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
+	"github.com/fission/fission-workflows/pkg/fes/backend/bolt"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
+	"github.com/fission/fission-workflows/pkg/retention"
+	"github.com/fission/fission-workflows/pkg/triggers"
 	"github.com/fission/fission-workflows/pkg/util"
 	natsio "github.com/nats-io/go-nats"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
 
+const (
+	storeMem        = "mem://"
+	storeFilePrefix = "file://"
+
+	// shutdownGracePeriod is the time that the bundle gets to shut down after draining, before it is forced to stop.
+	shutdownGracePeriod = 10 * time.Second
+)
+
+// drainTimeout is the configured maximum time to drain the bundle, stored as nanoseconds for the signal handler.
+var drainTimeout int64
+
 func main() {
 	ctx, cancelFn := context.WithCancel(context.Background())
 	c := make(chan os.Signal, 1)
@@ -24,7 +42,7 @@ func main() {
 		for sig := range c {
 			fmt.Println("Received signal: ", sig)
 			go func() {
-				time.Sleep(30 * time.Second)
+				time.Sleep(time.Duration(atomic.LoadInt64(&drainTimeout)) + shutdownGracePeriod)
 				fmt.Println("Deadline exceeded; forcing shutdown.")
 				os.Exit(0)
 			}()
@@ -45,21 +63,32 @@ func main() {
 		if err != nil {
 			logrus.Fatal("Error while parsing Fission Proxy: ", err)
 		}
+		atomic.StoreInt64(&drainTimeout, int64(c.Duration("drain-timeout")))
 
 		return bundle.Run(ctx, &bundle.Options{
-			NATS:                 parseNatsOptions(c),
-			Fission:              parseFissionOptions(c),
-			Scheduler:            policy,
-			InternalRuntime:      c.Bool("internal"),
-			InvocationController: c.Bool("controller") || c.Bool("invocation-controller"),
-			WorkflowController:   c.Bool("controller") || c.Bool("workflow-controller"),
-			AdminAPI:             c.Bool("api") || c.Bool("api-admin"),
-			WorkflowAPI:          c.Bool("api") || c.Bool("api-workflow"),
-			InvocationAPI:        c.Bool("api") || c.Bool("api-workflow-invocation"),
-			HTTPGateway:          c.Bool("api") || c.Bool("api-http"),
-			Metrics:              c.Bool("metrics"),
-			Debug:                c.Bool("debug"),
-			FissionProxy:         proxyConfig,
+			NATS:                     parseNatsOptions(c),
+			Bolt:                     parseStoreOptions(c),
+			SnapshotInterval:         c.Int("snapshot-interval"),
+			Retention:                parseRetentionOptions(c),
+			Triggers:                 parseTriggerOptions(c),
+			Cluster:                  parseClusterOptions(c),
+			Fission:                  parseFissionOptions(c),
+			Scheduler:                policy,
+			InternalRuntime:          c.Bool("internal"),
+			InvocationController:     c.Bool("controller") || c.Bool("invocation-controller"),
+			WorkflowController:       c.Bool("controller") || c.Bool("workflow-controller"),
+			AdminAPI:                 c.Bool("api") || c.Bool("api-admin"),
+			WorkflowAPI:              c.Bool("api") || c.Bool("api-workflow"),
+			InvocationAPI:            c.Bool("api") || c.Bool("api-workflow-invocation"),
+			TriggerAPI:               c.Bool("api") || c.Bool("api-trigger"),
+			SchedulerAPI:             c.Bool("api-scheduler"),
+			HTTPGateway:              c.Bool("api") || c.Bool("api-http"),
+			Metrics:                  c.Bool("metrics"),
+			Debug:                    c.Bool("debug"),
+			FissionProxy:             proxyConfig,
+			ControllerWorkers:        c.Int("controller.workers"),
+			MaxConcurrencyPerReplica: c.Int("controller.max-concurrency"),
+			DrainTimeout:             c.Duration("drain-timeout"),
 		})
 	}
 	cliApp.Run(os.Args)
@@ -103,6 +132,67 @@ func parseNatsOptions(c *cli.Context) *nats.Config {
 	}
 }
 
+// parseRetentionOptions parses the options of the retention subsystem, which is only enabled if the flag is set.
+func parseRetentionOptions(c *cli.Context) *retention.Config {
+	if !c.IsSet("retention") {
+		return nil
+	}
+
+	cfg := &retention.Config{
+		TTL:      c.Duration("retention"),
+		Interval: c.Duration("retention.interval"),
+	}
+	if dir := c.String("retention.archive"); len(dir) > 0 {
+		cfg.Archiver = retention.NewDirArchiver(dir)
+	}
+	return cfg
+}
+
+// parseTriggerOptions parses the options of the trigger scheduler, which is only enabled as part of the controller.
+func parseTriggerOptions(c *cli.Context) *triggers.Config {
+	if !c.Bool("controller") && !c.Bool("trigger-controller") {
+		return nil
+	}
+
+	return &triggers.Config{
+		Interval:        c.Duration("trigger-controller.interval"),
+		CatchUpInterval: c.Duration("trigger-controller.catch-up-interval"),
+	}
+}
+
+// parseClusterOptions parses the membership options of this replica, which are only used if the flag is set.
+func parseClusterOptions(c *cli.Context) *cluster.Config {
+	if !c.Bool("cluster") {
+		return nil
+	}
+
+	return &cluster.Config{
+		ID:            c.String("cluster.id"),
+		LeaseDuration: c.Duration("cluster.lease"),
+	}
+}
+
+// parseStoreOptions parses the URL of the embedded event store, which is used if NATS is not enabled.
+func parseStoreOptions(c *cli.Context) *bolt.Config {
+	store := c.String("store")
+	switch {
+	case store == "" || store == storeMem:
+		return nil
+	case strings.HasPrefix(store, storeFilePrefix):
+		path := strings.TrimPrefix(store, storeFilePrefix)
+		if len(path) == 0 {
+			logrus.Fatalf("No path provided in event store URL: %s", store)
+		}
+		return &bolt.Config{
+			Path: path,
+		}
+	default:
+		logrus.Fatalf("Unknown event store URL: %s (expected '%s' or '%s<path>')", store, storeMem,
+			storeFilePrefix)
+	}
+	return nil
+}
+
 func createCli() *cli.App {
 
 	cliApp := cli.NewApp()
@@ -137,6 +227,39 @@ func createCli() *cli.App {
 			Usage: "Use NATS as the event store",
 		},
 
+		// Embedded event store
+		cli.StringFlag{
+			Name: "store",
+			Usage: "Event store to use if NATS is not enabled; either 'mem://' or 'file://<path>' for a " +
+				"file-based store.",
+			Value:  storeMem,
+			EnvVar: "ES_STORE",
+		},
+		cli.IntFlag{
+			Name: "snapshot-interval",
+			Usage: "Number of replayed events after which a snapshot of the entity is stored " +
+				"(0 disables snapshots). Only supported by the 'mem://' and 'file://' event stores.",
+			Value:  100,
+			EnvVar: "ES_SNAPSHOT_INTERVAL",
+		},
+
+		// Retention
+		cli.DurationFlag{
+			Name: "retention",
+			Usage: "Enable the retention of finished invocations, purging them from the event store after this " +
+				"duration (0 only purges invocations of workflows that specify a retention).",
+			EnvVar: "WORKFLOW_RETENTION",
+		},
+		cli.DurationFlag{
+			Name:  "retention.interval",
+			Usage: "Interval at which the event store is checked for expired invocations.",
+			Value: retention.DefaultInterval,
+		},
+		cli.StringFlag{
+			Name:  "retention.archive",
+			Usage: "Directory to archive the events of expired invocations to before they are purged.",
+		},
+
 		// Fission Environment Proxy
 		cli.BoolFlag{
 			Name:  "fission.proxy, fission-proxy",
@@ -194,6 +317,54 @@ func createCli() *cli.App {
 			Name:  "invocation-controller",
 			Usage: "Run the invocation controller",
 		},
+		cli.IntFlag{
+			Name: "controller.workers",
+			Usage: "Number of invocations and workflows that the controllers evaluate in parallel " +
+				"(for example, the number of CPUs)",
+			Value: ctrl.DefaultWorkers,
+		},
+		cli.IntFlag{
+			Name: "controller.max-concurrency",
+			Usage: "Default maximum number of invocations of a workflow that are in progress at the same time in " +
+				"each replica, for workflows that do not specify a maxConcurrency (0 is unlimited)",
+		},
+		cli.BoolFlag{
+			Name:  "trigger-controller",
+			Usage: "Run the trigger scheduler, which invokes workflows on the schedule of their triggers",
+		},
+		cli.DurationFlag{
+			Name:  "trigger-controller.interval",
+			Usage: "Time between two evaluations of the triggers",
+			Value: triggers.DefaultInterval,
+		},
+		cli.DurationFlag{
+			Name:  "trigger-controller.catch-up-interval",
+			Usage: "Time between two scans for finished invocations whose completion triggers did not fire",
+			Value: triggers.DefaultCatchUpInterval,
+		},
+		cli.DurationFlag{
+			Name: "drain-timeout",
+			Usage: "Maximum time to wait for running tasks to finish on shutdown, during which no new invocations " +
+				"are accepted (0 disables draining)",
+			Value: 20 * time.Second,
+		},
+
+		// Cluster
+		cli.BoolFlag{
+			Name: "cluster",
+			Usage: "Divide the invocations among the replicas that share the event store, instead of processing all " +
+				"invocations in every replica (not supported by the NATS event store)",
+		},
+		cli.StringFlag{
+			Name:   "cluster.id",
+			Usage:  "Unique id of this replica in the cluster, which it keeps across restarts (default: hostname)",
+			EnvVar: "WORKFLOW_REPLICA_ID",
+		},
+		cli.DurationFlag{
+			Name:  "cluster.lease",
+			Usage: "Time after which the invocations of a replica that stopped responding are taken over",
+			Value: cluster.DefaultLeaseDuration,
+		},
 		cli.BoolFlag{
 			Name:  "api-http",
 			Usage: "Serve the http apis of the apis",
@@ -206,6 +377,14 @@ func createCli() *cli.App {
 			Name:  "api-workflow",
 			Usage: "Serve the workflow gRPC api",
 		},
+		cli.BoolFlag{
+			Name:  "api-trigger",
+			Usage: "Serve the trigger gRPC api",
+		},
+		cli.BoolFlag{
+			Name:  "api-scheduler",
+			Usage: "Serve the scheduler gRPC api, which evaluates invocations of remote engines (not included in --api)",
+		},
 		cli.BoolFlag{
 			Name:  "api-admin",
 			Usage: "Serve the admin gRPC api",
@@ -222,12 +401,17 @@ func createCli() *cli.App {
 		// Scheduler
 		cli.StringFlag{
 			Name:  bundle.FlagSchedulerPolicy,
-			Usage: "Policy to use for the scheduler (prewarm-all, prewarm-horizon, horizon)",
+			Usage: "Policy to use for the scheduler (prewarm-all, prewarm-horizon, horizon, adaptive, remote)",
 			Value: "horizon",
 		},
+		cli.StringFlag{
+			Name:  bundle.FlagSchedulerRemoteAddress,
+			Usage: "Address of the scheduler gRPC api to delegate the scheduling to when using the remote scheduler",
+		},
 		cli.DurationFlag{
-			Name:  bundle.FlagSchedulerColdStartDuration,
-			Usage: "The static cold start duration to assume when using prewarm schedulers",
+			Name: bundle.FlagSchedulerColdStartDuration,
+			Usage: "The static cold start duration to assume when using prewarm schedulers, or until the adaptive " +
+				"scheduler has learned the cold start of a function",
 			Value: 1 * time.Second,
 		},
 	})
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backup"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var formatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: "Format of the events file: 'proto' (length-delimited protobuf) or 'jsonl' (JSON lines)",
+	Value: string(backup.FormatProto),
+}
+
+var cmdAdmin = cli.Command{
+	Name:  "admin",
+	Usage: "Administrative commands",
+	Subcommands: []cli.Command{
+		{
+			Name:  "export",
+			Usage: "export [--output <file>] [--type <aggregate-type>] [--since <time>] [--until <time>]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "output, o",
+					Usage: "File to write the events to ('-' for stdout)",
+					Value: "-",
+				},
+				formatFlag,
+				cli.StringSliceFlag{
+					Name:  "type",
+					Usage: "Only export aggregates of this type (e.g. 'workflow' or 'invocation'); can be repeated",
+				},
+				cli.StringFlag{
+					Name:  "since",
+					Usage: "Only export events that occurred at or after this time (RFC3339)",
+				},
+				cli.StringFlag{
+					Name:  "until",
+					Usage: "Only export events that occurred before this time (RFC3339)",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				format, err := backup.ParseFormat(ctx.String("format"))
+				if err != nil {
+					logrus.Fatal(err)
+				}
+				req := &apiserver.ExportRequest{
+					Types: ctx.StringSlice("type"),
+				}
+				if since := ctx.String("since"); len(since) > 0 {
+					req.Since = parseTimestampFlag("since", since)
+				}
+				if until := ctx.String("until"); len(until) > 0 {
+					req.Until = parseTimestampFlag("until", until)
+				}
+
+				out := os.Stdout
+				if path := ctx.String("output"); path != "-" {
+					out, err = os.Create(path)
+					if err != nil {
+						logrus.Fatalf("Failed to create %s: %v", path, err)
+					}
+					defer out.Close()
+				}
+				enc, err := backup.NewEncoder(out, format)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+
+				client := getClient(ctx)
+				var count int
+				err = client.Admin.Export(ctx, req, func(event *fes.Event) error {
+					count++
+					return enc.Encode(event)
+				})
+				if flushErr := enc.Flush(); err == nil {
+					err = flushErr
+				}
+				if err != nil {
+					logrus.Fatalf("Failed to export events: %v", err)
+				}
+				logrus.Infof("Exported %d event(s).", count)
+				return nil
+			}),
+		},
+		{
+			Name:  "import",
+			Usage: "import <file>",
+			Flags: []cli.Flag{
+				formatFlag,
+			},
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows admin import <file> ('-' for stdin)")
+				}
+				format, err := backup.ParseFormat(ctx.String("format"))
+				if err != nil {
+					logrus.Fatal(err)
+				}
+
+				var in io.Reader = os.Stdin
+				if path := ctx.Args().First(); path != "-" {
+					f, err := os.Open(path)
+					if err != nil {
+						logrus.Fatalf("Failed to open %s: %v", path, err)
+					}
+					defer f.Close()
+					in = f
+				}
+				dec, err := backup.NewDecoder(in, format)
+				if err != nil {
+					logrus.Fatal(err)
+				}
+
+				client := getClient(ctx)
+				summary, err := client.Admin.Import(ctx, dec.Decode)
+				if err != nil {
+					logrus.Fatalf("Failed to import events: %v", err)
+				}
+				logrus.Infof("Imported %d event(s).", summary.GetEvents())
+				return nil
+			}),
+		},
+		{
+			Name:  "drain",
+			Usage: "drain [--timeout <duration>]",
+			Description: "Stop the workflow engine from accepting new invocations and scheduling new tasks, and wait " +
+				"for the running tasks to finish. Draining cannot be undone; stop the engine afterwards.",
+			Flags: []cli.Flag{
+				cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "Maximum time to wait for the running tasks to finish",
+					Value: time.Minute,
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+				status, err := client.Admin.Drain(ctx, &apiserver.DrainRequest{
+					Timeout: ptypes.DurationProto(ctx.Duration("timeout")),
+				})
+				if err != nil {
+					logrus.Fatalf("Failed to drain: %v", err)
+				}
+				if !status.GetDrained() {
+					logrus.Fatalf("Drain timed out: %d task(s) of %d invocation(s) have not finished: %v",
+						status.GetPendingTasks(), len(status.GetInvocations()), status.GetInvocations())
+				}
+				logrus.Info("Drained: all tasks have finished.")
+				return nil
+			}),
+		},
+	},
+}
+
+func parseTimestampFlag(name string, value string) *timestamp.Timestamp {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logrus.Fatalf("Invalid --%s: %v", name, err)
+	}
+	ts, err := ptypes.TimestampProto(t)
+	if err != nil {
+		logrus.Fatalf("Invalid --%s: %v", name, err)
+	}
+	return ts
+}
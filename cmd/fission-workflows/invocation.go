@@ -2,16 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/apiserver/httpclient"
 	"github.com/fission/fission-workflows/pkg/parse/yaml"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
@@ -32,6 +36,11 @@ var cmdInvocation = cli.Command{
 					Usage: "Amount history (non-active invocations) to show.",
 					Value: time.Duration(1) * time.Hour,
 				},
+				cli.StringFlag{
+					Name: "at",
+					Usage: "Show the invocation as it was at this point in its history: either the (zero-based) " +
+						"index of an event or a time (RFC3339)",
+				},
 			},
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
@@ -42,9 +51,9 @@ var cmdInvocation = cli.Command{
 				case 1:
 					// Get Workflow Invocation
 					wfiID := ctx.Args().Get(0)
-					wfi, err := client.Invocation.Get(ctx, wfiID)
+					wfi, err := getInvocation(ctx, client.Invocation, wfiID, ctx.String("at"))
 					if err != nil {
-						panic(err)
+						logrus.Fatalf("Failed to retrieve invocation %s: %v", wfiID, err)
 					}
 					b, err := yaml.Marshal(wfi)
 					if err != nil {
@@ -56,9 +65,9 @@ var cmdInvocation = cli.Command{
 				default:
 					wfiID := ctx.Args().Get(0)
 					taskID := ctx.Args().Get(1)
-					wfi, err := client.Invocation.Get(ctx, wfiID)
+					wfi, err := getInvocation(ctx, client.Invocation, wfiID, ctx.String("at"))
 					if err != nil {
-						panic(err)
+						logrus.Fatalf("Failed to retrieve invocation %s: %v", wfiID, err)
 					}
 					ti, ok := wfi.Status.Tasks[taskID]
 					if !ok {
@@ -88,6 +97,88 @@ var cmdInvocation = cli.Command{
 				return nil
 			}),
 		},
+		{
+			Name:  "pause",
+			Usage: "pause <invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows invocation pause <invocation-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().First()
+				err := client.Invocation.Pause(ctx, wfiID)
+				if err != nil {
+					logrus.Fatalf("Failed to pause invocation %s: %v", wfiID, err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "resume",
+			Usage: "resume <invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows invocation resume <invocation-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().First()
+				err := client.Invocation.Resume(ctx, wfiID)
+				if err != nil {
+					logrus.Fatalf("Failed to resume invocation %s: %v", wfiID, err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "signal",
+			Usage: "signal <invocation-id> <name> [payload]",
+			Description: "Send a signal to an invocation, which completes the tasks awaiting the signal. The optional " +
+				"payload is parsed as JSON, or is used as a string if it is not valid JSON.",
+			Action: commandContext(func(ctx Context) error {
+				if ctx.NArg() < 2 {
+					logrus.Fatal("Usage: fission-workflows invocation signal <invocation-id> <name> [payload]")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().Get(0)
+				name := ctx.Args().Get(1)
+				var payload *typedvalues.TypedValue
+				if ctx.NArg() > 2 {
+					var val interface{}
+					if err := json.Unmarshal([]byte(ctx.Args().Get(2)), &val); err != nil {
+						val = ctx.Args().Get(2)
+					}
+					payload = typedvalues.MustWrap(val)
+				}
+				err := client.Invocation.Signal(ctx, wfiID, name, payload)
+				if err != nil {
+					logrus.Fatalf("Failed to signal invocation %s: %v", wfiID, err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "rerun",
+			Usage: "rerun <invocation-id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "workflow",
+					Usage: "Rerun the invocation against this revision of the workflow of the invocation, which has the same name.",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows invocation rerun <invocation-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().First()
+				md, err := client.Invocation.Rerun(ctx, wfiID, ctx.String("workflow"))
+				if err != nil {
+					logrus.Fatalf("Failed to rerun invocation %s: %v", wfiID, err)
+				}
+				fmt.Println(md.GetId())
+				return nil
+			}),
+		},
 		{
 			Name:  "events",
 			Usage: "events <invocation-id>",
@@ -162,6 +253,23 @@ var cmdInvocation = cli.Command{
 	},
 }
 
+// getInvocation fetches the invocation, or, if at is not empty, the invocation as it was at that event index or time.
+func getInvocation(ctx context.Context, client *httpclient.InvocationAPI, wfiID string,
+	at string) (*types.WorkflowInvocation, error) {
+	if len(at) == 0 {
+		return client.Get(ctx, wfiID)
+	}
+	req := &apiserver.GetAtRequest{
+		Id: wfiID,
+	}
+	if index, err := strconv.ParseInt(at, 10, 64); err == nil {
+		req.At = &apiserver.GetAtRequest_Index{Index: index}
+	} else {
+		req.At = &apiserver.GetAtRequest_Timestamp{Timestamp: parseTimestampFlag("at", at)}
+	}
+	return client.GetAt(ctx, req)
+}
+
 func invocationsList(out io.Writer, wfiAPI *httpclient.InvocationAPI, since time.Time) {
 	// List workflows invocations
 	ctx := context.TODO()
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -56,8 +57,13 @@ var cmdInvoke = cli.Command{
 			Name:  "inputs",
 			Usage: "Sets the inputs to provided value. Expects a JSON object.",
 		},
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Adds a label (key=value) to the invocation, which completion triggers can match on.",
+		},
 		cli.DurationFlag{
 			Name:  "poll",
+			Usage: "Interval at which to poll the invocation, if the server does not support watching it.",
 			Value: 10 * time.Millisecond,
 		},
 		cli.DurationFlag{
@@ -95,6 +101,7 @@ var cmdInvoke = cli.Command{
 		spec := &types.WorkflowInvocationSpec{
 			WorkflowId: workflowID,
 			Inputs:     inputs,
+			Labels:     parseLabels(ctx.StringSlice("label")),
 		}
 		types.NewWorkflowInvocationSpec(workflowID, time.Now().Add(timeout))
 		md, err := client.Invocation.Invoke(ctx, spec)
@@ -102,41 +109,33 @@ var cmdInvoke = cli.Command{
 			logrus.Fatalf("Error occurred while invoking workflow: %v", err)
 		}
 
-		// Poll for updates on the progress. In the future we can replace this with a streaming API.
+		// Follow the progress of the invocation until it has finished.
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+		var offset int
+		onUpdate := func() {
+			if !listenToEvents {
+				return
+			}
+			offset, err = fetchAndPrintEvents(ctx, client, md.GetId(), offset, w)
+			if err != nil {
+				logrus.Error(err)
+				logrus.Warn("Halting event streaming")
+				listenToEvents = false
+			}
+			w.Flush()
+		}
 		outputChan := make(chan *types.WorkflowInvocation)
 		go func() {
-			var offset int
-			ticker := time.Tick(ctx.Duration("poll"))
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker:
-					pollStatus := !listenToEvents
-					if listenToEvents {
-						pollStatus, err = fetchAndPrintEvents(ctx, client, md.GetId(), offset, w)
-						if err != nil {
-							logrus.Error(err)
-							logrus.Warn("Halting event streaming; falling back to polling status instead")
-							listenToEvents = false
-						}
-						w.Flush()
-					}
-
-					if pollStatus {
-						wi, err := client.Invocation.Get(ctx, md.GetId())
-						if err != nil {
-							logrus.Fatal("Failed to fetch invocation: ", err)
-						}
-						if wi.GetStatus().Finished() {
-							outputChan <- wi
-							close(outputChan)
-							return
-						}
-					}
-				}
+			defer close(outputChan)
+			wi, err := watchInvocation(ctx, client, md.GetId(), onUpdate)
+			if err != nil {
+				logrus.Warnf("Failed to watch invocation; falling back to polling: %v", err)
+				wi, err = pollInvocation(ctx, client, md.GetId(), ctx.Duration("poll"), onUpdate)
+			}
+			if err != nil {
+				logrus.Fatal("Failed to fetch invocation: ", err)
 			}
+			outputChan <- wi
 		}()
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt)
@@ -165,33 +164,71 @@ var cmdInvoke = cli.Command{
 	}),
 }
 
+// watchInvocation follows the invocation using the Watch API until it has finished, calling onUpdate on every update.
+func watchInvocation(ctx context.Context, client client, invocationID string,
+	onUpdate func()) (*types.WorkflowInvocation, error) {
+	var latest *types.WorkflowInvocation
+	err := client.Invocation.Watch(ctx, invocationID, func(wi *types.WorkflowInvocation) error {
+		latest = wi
+		onUpdate()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !latest.GetStatus().Finished() {
+		return nil, errors.New("watch ended before the invocation finished")
+	}
+	return latest, nil
+}
+
+// pollInvocation polls the invocation at the interval until it has finished, calling onUpdate on every poll. It is
+// used for servers that do not support the Watch API.
+func pollInvocation(ctx context.Context, client client, invocationID string, interval time.Duration,
+	onUpdate func()) (*types.WorkflowInvocation, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			onUpdate()
+			wi, err := client.Invocation.Get(ctx, invocationID)
+			if err != nil {
+				return nil, err
+			}
+			if wi.GetStatus().Finished() {
+				return wi, nil
+			}
+		}
+	}
+}
+
+// fetchAndPrintEvents prints the events of the invocation after the offset, and returns the offset of the next event.
 func fetchAndPrintEvents(ctx context.Context, client client, invocationID string, offset int,
-	w io.Writer) (finished bool,
-	err error) {
+	w io.Writer) (int, error) {
 	// Future: support and use offset in invocationEvents API requests
 	invocationEvents, err := client.Invocation.Events(ctx, invocationID)
 	if err != nil {
-		return finished, fmt.Errorf("failed to fetch events: %v", err)
+		return offset, fmt.Errorf("failed to fetch events: %v", err)
 	}
 
 	// Traverse all new invocationEvents
 	for _, event := range invocationEvents.GetEvents()[offset:] {
 		invocationEvent, err := parseInvocationEvent(event)
 		if err != nil {
-			return finished, fmt.Errorf("failed to parse events: %v", err)
+			return offset, fmt.Errorf("failed to parse events: %v", err)
 		}
 
 		if e, ok := invocationEvent.data.(events.Event); ok {
 			switch e.Type() {
 			case events.EventInvocationFailed:
 				invocationEvent.subjectType = subjectTypeError
-				finished = true
 			case events.EventInvocationCanceled:
 				invocationEvent.subjectType = subjectTypeError
-				finished = true
 			case events.EventInvocationCompleted:
 				invocationEvent.subjectType = subjectTypeSuccess
-				finished = true
 			case events.EventTaskSucceeded:
 				invocationEvent.subjectType = subjectTypeSuccess
 				invocationEvent.target = event.GetId() // TODO can we get task name
@@ -201,7 +238,7 @@ func fetchAndPrintEvents(ctx context.Context, client client, invocationID string
 		w.Write([]byte(eventToTabString(invocationEvent)))
 		offset++
 	}
-	return finished, nil
+	return offset, nil
 }
 
 type subjectType int
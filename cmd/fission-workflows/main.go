@@ -57,8 +57,10 @@ func main() {
 		cmdParse,
 		cmdWorkflow,
 		cmdInvocation,
+		cmdTrigger,
 		cmdValidate,
 		cmdVersion,
+		cmdAdmin,
 	}
 	app.Action = func(ctx *cli.Context) error {
 		if ctx.GlobalBool("plugin") {
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/fission/fission-workflows/pkg/parse/yaml"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var triggerSpecFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "workflow",
+		Usage: "ID of the workflow to invoke",
+	},
+	cli.StringFlag{
+		Name:  "cron",
+		Usage: "Cron expression (e.g. '0 * * * *' or '@hourly') of the schedule, evaluated in UTC.",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "Fixed interval (e.g. '5m') of the schedule, as an alternative to --cron.",
+	},
+	cli.StringFlag{
+		Name:  "on-completion",
+		Usage: "ID of the workflow of which finished invocations fire the trigger, as an alternative to --cron.",
+	},
+	cli.StringSliceFlag{
+		Name:  "on-status",
+		Usage: "Status (succeeded, failed or aborted) of the invocations that fire the trigger. (default: succeeded)",
+	},
+	cli.StringSliceFlag{
+		Name:  "on-label",
+		Usage: "Label (key=value) that the invocations that fire the trigger should have.",
+	},
+	cli.StringFlag{
+		Name: "inputs",
+		Usage: "Sets the inputs of every invocation to provided value. Expects a JSON object. For completion " +
+			"triggers, expressions such as '{$.Invocation.Output}' are resolved against the finished invocation.",
+	},
+	cli.StringFlag{
+		Name:  "overlap",
+		Value: strings.ToLower(types.TriggerSpec_ALLOW.String()),
+		Usage: "What to do if the previous invocation is still running: allow, skip or replace.",
+	},
+	cli.StringFlag{
+		Name:  "name",
+		Usage: "Name of the trigger",
+	},
+}
+
+var cmdTrigger = cli.Command{
+	Name:    "trigger",
+	Aliases: []string{"tr", "triggers"},
+	Usage:   "Trigger-related commands",
+	Subcommands: []cli.Command{
+		{
+			Name: "create",
+			Usage: "Create a trigger that invokes a workflow on a cron schedule, at a fixed interval, or when " +
+				"another workflow finishes.",
+			Flags: triggerSpecFlags,
+			Action: commandContext(func(ctx Context) error {
+				ensureServerVersionAtLeast(ctx, semver.MustParse("0.7.0"), true)
+				client := getClient(ctx)
+				md, err := client.Trigger.Create(ctx, parseTriggerSpec(ctx))
+				if err != nil {
+					logrus.Fatalf("Failed to create trigger: %v", err)
+				}
+				fmt.Println(md.GetId())
+				return nil
+			}),
+		},
+		{
+			Name:  "update",
+			Usage: "update <trigger-id>",
+			Description: "Replace the spec of a trigger. The schedule continues from the last time that the trigger " +
+				"fired.",
+			Flags: triggerSpecFlags,
+			Action: commandContext(func(ctx Context) error {
+				ensureServerVersionAtLeast(ctx, semver.MustParse("0.7.0"), true)
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows trigger update <trigger-id>")
+				}
+				client := getClient(ctx)
+				triggerID := ctx.Args().First()
+				if err := client.Trigger.Update(ctx, triggerID, parseTriggerSpec(ctx)); err != nil {
+					logrus.Fatalf("Failed to update trigger %s: %v", triggerID, err)
+				}
+				fmt.Println(triggerID)
+				return nil
+			}),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete <trigger-id...>",
+			Action: commandContext(func(ctx Context) error {
+				ensureServerVersionAtLeast(ctx, semver.MustParse("0.7.0"), true)
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows trigger delete <trigger-id...>")
+				}
+				client := getClient(ctx)
+				for _, triggerID := range ctx.Args() {
+					if err := client.Trigger.Delete(ctx, triggerID); err != nil {
+						logrus.Fatalf("Failed to delete %s: %v", triggerID, err)
+					}
+					fmt.Println(triggerID)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "get",
+			Usage: "get <trigger-id>",
+			Action: commandContext(func(ctx Context) error {
+				ensureServerVersionAtLeast(ctx, semver.MustParse("0.7.0"), true)
+				client := getClient(ctx)
+
+				if ctx.Args().Present() {
+					trigger, err := client.Trigger.Get(ctx, ctx.Args().First())
+					if err != nil {
+						logrus.Fatalf("Failed to get trigger: %v", err)
+					}
+					b, err := yaml.Marshal(trigger)
+					if err != nil {
+						panic(err)
+					}
+					fmt.Printf("%v\n", string(b))
+					return nil
+				}
+
+				// List triggers
+				resp, err := client.Trigger.List(ctx)
+				if err != nil {
+					logrus.Fatalf("Failed to list triggers: %v", err)
+				}
+				triggerIDs := resp.GetTriggers()
+				sort.Strings(triggerIDs)
+				var rows [][]string
+				for _, triggerID := range triggerIDs {
+					trigger, err := client.Trigger.Get(ctx, triggerID)
+					if err != nil {
+						logrus.Fatalf("Failed to get trigger %s: %v", triggerID, err)
+					}
+					schedule := trigger.GetSpec().GetCron()
+					if interval, err := ptypes.Duration(trigger.GetSpec().GetInterval()); err == nil {
+						schedule = "@every " + interval.String()
+					}
+					if completion := trigger.GetSpec().GetCompletion(); completion != nil {
+						schedule = "@completion " + completion.GetWorkflowId()
+					}
+					var lastScheduled string
+					if ts, err := ptypes.Timestamp(trigger.GetStatus().GetLastScheduledAt()); err == nil {
+						lastScheduled = ts.String()
+					}
+					rows = append(rows, []string{triggerID, trigger.GetSpec().GetName(),
+						trigger.GetSpec().GetWorkflowId(), schedule, trigger.GetStatus().GetStatus().String(),
+						fmt.Sprintf("%d", trigger.GetStatus().GetFired()), lastScheduled})
+				}
+				table(os.Stdout, []string{"ID", "NAME", "WORKFLOW", "SCHEDULE", "STATUS", "FIRED", "LAST SCHEDULED"},
+					rows)
+				return nil
+			}),
+		},
+	},
+}
+
+func parseTriggerSpec(ctx Context) *types.TriggerSpec {
+	spec := &types.TriggerSpec{
+		WorkflowId: ctx.String("workflow"),
+		Cron:       ctx.String("cron"),
+		Name:       ctx.String("name"),
+	}
+	if interval := ctx.Duration("interval"); interval > 0 {
+		spec.Interval = ptypes.DurationProto(interval)
+	}
+	if jsonInputs := ctx.String("inputs"); len(jsonInputs) > 0 {
+		inputMap := map[string]interface{}{}
+		err := json.Unmarshal([]byte(jsonInputs), &inputMap)
+		if err != nil {
+			logrus.Fatalf("Failed to parse provided inputs to JSON object: %v", err)
+		}
+		spec.Inputs = typedvalues.MustWrapMapTypedValue(inputMap)
+	}
+	if workflowID := ctx.String("on-completion"); len(workflowID) > 0 {
+		spec.Completion = &types.CompletionTrigger{
+			WorkflowId: workflowID,
+			Labels:     parseLabels(ctx.StringSlice("on-label")),
+		}
+		for _, s := range ctx.StringSlice("on-status") {
+			status, ok := types.WorkflowInvocationStatus_Status_value[strings.ToUpper(s)]
+			if !ok {
+				logrus.Fatalf("Unknown invocation status: %s", s)
+			}
+			spec.Completion.Statuses = append(spec.Completion.Statuses, types.WorkflowInvocationStatus_Status(status))
+		}
+	}
+	policy, ok := types.TriggerSpec_OverlapPolicy_value[strings.ToUpper(ctx.String("overlap"))]
+	if !ok {
+		logrus.Fatalf("Unknown overlap policy: %s", ctx.String("overlap"))
+	}
+	spec.OverlapPolicy = types.TriggerSpec_OverlapPolicy(policy)
+	return spec
+}
+
+// parseLabels parses labels of the format key=value.
+func parseLabels(args []string) map[string]string {
+	if len(args) == 0 {
+		return nil
+	}
+	labels := map[string]string{}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			logrus.Fatalf("Invalid label '%s', expected key=value", arg)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels
+}
@@ -73,7 +73,7 @@ require (
 	github.com/pkg/errors v0.8.1
 	github.com/prometheus/client_golang v0.9.2
 	github.com/robertkrimen/otto v0.0.0-20180305042045-6c383dd335ef
-	github.com/robfig/cron v1.2.0 // indirect
+	github.com/robfig/cron v1.2.0
 	github.com/satori/go.uuid v1.2.0
 	github.com/sirupsen/logrus v1.1.0
 	github.com/spf13/pflag v1.0.1 // indirect
@@ -83,12 +83,13 @@ require (
 	github.com/uber/jaeger-lib v1.5.0
 	github.com/ulikunitz/xz v0.0.0-20180703112113-636d36a76670 // indirect
 	github.com/urfave/cli v1.19.1
-	go.etcd.io/bbolt v1.3.3 // indirect
+	go.etcd.io/bbolt v1.3.5
 	go.uber.org/atomic v1.3.2
 	golang.org/x/exp v0.0.0-20190627132806-fd42eb6b336f // indirect
 	golang.org/x/net v0.0.0-20190311183353-d8887717615a
 	golang.org/x/oauth2 v0.0.0-20170412232759-a6bd8cefa181 // indirect
 	golang.org/x/sync v0.0.0-20181108010431-42b317875d0f
+	golang.org/x/sys v0.14.0 // indirect
 	golang.org/x/time v0.0.0-20161028155119-f51c12702a4d // indirect
 	gonum.org/v1/gonum v0.0.0-20180205154402-996b88e8f894
 	google.golang.org/appengine v0.0.0-20171031194329-9d8544a6b2c7 // indirect
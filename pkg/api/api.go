@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes/timestamp"
 )
 
 type CallConfig struct {
-	ctx             context.Context
-	postTransformer func(i interface{}) error
-	awaitWorkflow   time.Duration
+	ctx                context.Context
+	postTransformer    func(i interface{}) error
+	awaitWorkflow      time.Duration
+	expectedGeneration int64
+	startedAt          *timestamp.Timestamp
+	sourceInvocationID string
 }
 
 type CallOption func(op *CallConfig)
@@ -51,3 +55,27 @@ func AwaitWorklow(timeout time.Duration) CallOption {
 		config.awaitWorkflow = timeout
 	}
 }
+
+// WithExpectedGeneration makes the events appended by the call conditional on the generation of the invocation. If the
+// invocation has been modified concurrently, the call checks whether it is still valid for the updated invocation and
+// if so retries with the updated generation; otherwise it fails with a fes.ErrConcurrentModification.
+func WithExpectedGeneration(generation int64) CallOption {
+	return func(config *CallConfig) {
+		config.expectedGeneration = generation
+	}
+}
+
+// WithStartedAt records the time at which the function of the task started running in the result of the task.
+func WithStartedAt(startedAt *timestamp.Timestamp) CallOption {
+	return func(config *CallConfig) {
+		config.startedAt = startedAt
+	}
+}
+
+// WithSourceInvocation records that a completion trigger was fired or skipped for the finished invocation, which
+// prevents the trigger from being fired again for the same invocation.
+func WithSourceInvocation(invocationID string) CallOption {
+	return func(config *CallConfig) {
+		config.sourceInvocationID = invocationID
+	}
+}
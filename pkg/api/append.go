@@ -0,0 +1,222 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util/backoff"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxConflictRetries is the maximum number of times that an append without a precondition is retried after a
+	// concurrent modification.
+	maxConflictRetries = 5
+
+	// maxPreconditionRetries is the maximum number of times that an append with a precondition is retried after a
+	// concurrent modification, as long as the precondition holds. It is much higher than maxConflictRetries, because
+	// the appends of sibling tasks conflict with each other without being in conflict.
+	maxPreconditionRetries = 100
+)
+
+var (
+	// conflictBackoff is the base duration to wait before retrying an append after a concurrent modification, which is
+	// doubled for every subsequent attempt up to maxConflictBackoff.
+	conflictBackoff    = 5 * time.Millisecond
+	maxConflictBackoff = 500 * time.Millisecond
+)
+
+// invocationPrecondition checks whether an event can still be appended to the (updated) invocation.
+type invocationPrecondition func(invocation *types.WorkflowInvocation) error
+
+// appendToInvocation appends the event, which belongs to the event stream of the invocation, to the event store.
+//
+// If an expected generation is provided, the event is appended conditionally. In case of a concurrent modification,
+// the invocation is reloaded from the event store after a (jittered) backoff. If the precondition still holds for the
+// updated invocation, the append is retried with the updated generation, until it succeeds or the precondition no
+// longer holds. Otherwise it returns a fes.ErrConcurrentModification.
+//
+// Many appends can conflict with each other without being in conflict; for example, the results of the tasks of a
+// foreach, which are all evaluated against the same generation of the invocation. Because of this, appends with a
+// precondition are retried up to maxPreconditionRetries times, rather than maxConflictRetries times. Once the retries
+// are exhausted, the fes.ErrConcurrentModification is returned, so that a persistent conflict surfaces as an error.
+func appendToInvocation(es fes.Backend, invocationID string, event *fes.Event, expectedGeneration int64,
+	precondition invocationPrecondition) error {
+	event.ExpectedGeneration = expectedGeneration
+	for attempt := 0; ; attempt++ {
+		err := es.Append(event)
+		if err == nil || !fes.ErrConcurrentModification.Is(err) {
+			return err
+		}
+		maxRetries := maxConflictRetries
+		if precondition != nil {
+			maxRetries = maxPreconditionRetries
+		}
+		if attempt >= maxRetries {
+			return err
+		}
+		logrus.WithField("wi", invocationID).
+			WithField("event", event.Type).
+			Debugf("Concurrent modification of invocation (attempt %d): %v", attempt, err)
+		time.Sleep(conflictRetryBackoff(attempt))
+
+		invocation, err := loadInvocation(es, invocationID)
+		if err != nil {
+			return err
+		}
+		if precondition != nil {
+			if err := precondition(invocation); err != nil {
+				return fes.ErrConcurrentModification.WithEvent(event).WithError(err)
+			}
+		}
+		event.ExpectedGeneration = invocation.GetMetadata().GetGeneration()
+	}
+}
+
+// conflictRetryBackoff returns the duration to wait before the given retry of an append, which is randomized to
+// spread out the retries of the appends that conflicted with each other.
+func conflictRetryBackoff(attempt int) time.Duration {
+	if attempt > 10 {
+		attempt = 10
+	}
+	wait := backoff.ExponentialBackoff(attempt, conflictBackoff)
+	if wait > maxConflictBackoff {
+		wait = maxConflictBackoff
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// loadInvocation projects the current state of the invocation directly from the event store.
+func loadInvocation(es fes.Backend, invocationID string) (*types.WorkflowInvocation, error) {
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	events, err := es.Get(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	projector := projectors.NewWorkflowInvocation()
+	base, err := projector.NewProjection(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := projector.Project(base, events...)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.WorkflowInvocation), nil
+}
+
+// invocationNotFinished requires the invocation to not have finished yet.
+func invocationNotFinished(invocation *types.WorkflowInvocation) error {
+	if invocation.GetStatus().Finished() {
+		return fmt.Errorf("invocation has already finished with status %v", invocation.GetStatus().GetStatus())
+	}
+	return nil
+}
+
+// invocationRunning requires the invocation to not have finished, nor to be paused.
+func invocationRunning(invocation *types.WorkflowInvocation) error {
+	if err := invocationNotFinished(invocation); err != nil {
+		return err
+	}
+	if invocation.GetStatus().Paused() {
+		return errors.New("invocation is paused")
+	}
+	return nil
+}
+
+// invocationScheduled requires the invocation to be scheduled, but not started yet.
+func invocationScheduled(invocation *types.WorkflowInvocation) error {
+	if status := invocation.GetStatus().GetStatus(); status != types.WorkflowInvocationStatus_SCHEDULED {
+		return fmt.Errorf("invocation is not scheduled (%v)", status)
+	}
+	return nil
+}
+
+// invocationPaused requires the invocation to be paused.
+func invocationPaused(invocation *types.WorkflowInvocation) error {
+	if !invocation.GetStatus().Paused() {
+		return fmt.Errorf("invocation is not paused (%v)", invocation.GetStatus().GetStatus())
+	}
+	return nil
+}
+
+// signalNotReceived requires the invocation to not have finished yet, and to not have received the signal yet.
+func signalNotReceived(name string) invocationPrecondition {
+	return func(invocation *types.WorkflowInvocation) error {
+		if err := invocationNotFinished(invocation); err != nil {
+			return err
+		}
+		if _, ok := invocation.GetStatus().GetSignals()[name]; ok {
+			return fmt.Errorf("invocation has already received signal %s", name)
+		}
+		return nil
+	}
+}
+
+// taskNotFinished requires both the invocation and the task to not have finished yet.
+func taskNotFinished(taskID string) invocationPrecondition {
+	return func(invocation *types.WorkflowInvocation) error {
+		if err := invocationNotFinished(invocation); err != nil {
+			return err
+		}
+		if task, ok := invocation.TaskInvocation(taskID); ok && task.GetStatus() != nil && task.GetStatus().Finished() {
+			return fmt.Errorf("task %s has already finished with status %v", taskID, task.GetStatus().GetStatus())
+		}
+		return nil
+	}
+}
+
+// taskFailed requires the invocation to not have finished yet, and the task to have failed in the given attempt.
+func taskFailed(taskID string, attempt int) invocationPrecondition {
+	return func(invocation *types.WorkflowInvocation) error {
+		if err := invocationNotFinished(invocation); err != nil {
+			return err
+		}
+		task, ok := invocation.TaskInvocation(taskID)
+		if !ok || task.GetStatus().GetStatus() != types.TaskInvocationStatus_FAILED {
+			return fmt.Errorf("task %s has not failed", taskID)
+		}
+		if task.GetStatus().Attempt() != attempt-1 {
+			return fmt.Errorf("task %s is at attempt %d instead of %d", taskID, task.GetStatus().Attempt(), attempt-1)
+		}
+		return nil
+	}
+}
+
+// compensationInProgress requires the compensation of the invocation to have started, but not finished yet.
+func compensationInProgress(invocation *types.WorkflowInvocation) error {
+	if status := invocation.GetStatus().GetCompensation().GetStatus(); status != types.CompensationStatus_IN_PROGRESS {
+		return fmt.Errorf("compensation of the invocation is not in progress (%v)", status)
+	}
+	return nil
+}
+
+// compensationNotStarted requires the invocation to have failed, without the compensation having started.
+func compensationNotStarted(invocation *types.WorkflowInvocation) error {
+	if invocation.GetStatus().GetStatus() != types.WorkflowInvocationStatus_FAILED {
+		return fmt.Errorf("invocation has not failed (%v)", invocation.GetStatus().GetStatus())
+	}
+	if status := invocation.GetStatus().GetCompensation().GetStatus(); status != types.CompensationStatus_UNKNOWN {
+		return fmt.Errorf("compensation of the invocation has already started (%v)", status)
+	}
+	return nil
+}
+
+// taskNotCompensated requires the compensation of the invocation to be in progress, and the task to not have been
+// compensated yet.
+func taskNotCompensated(taskID string) invocationPrecondition {
+	return func(invocation *types.WorkflowInvocation) error {
+		if err := compensationInProgress(invocation); err != nil {
+			return err
+		}
+		if _, ok := invocation.GetStatus().GetCompensation().GetTasks()[taskID]; ok {
+			return fmt.Errorf("task %s has already been compensated", taskID)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,447 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupInvocation(t *testing.T) (*Invocation, *mem.Backend, string) {
+	backend := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(backend)
+	spec := types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Hour))
+	invocationID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	return invocationAPI, backend, invocationID
+}
+
+func TestInvocation_AddTaskRetriesOnConflict(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+
+	// Both calls observed generation 1, so the second one conflicts and should be retried.
+	err := invocationAPI.AddTask(invocationID, types.NewTask("task-1", "noop"), WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = invocationAPI.AddTask(invocationID, types.NewTask("task-2", "noop"), WithExpectedGeneration(1))
+	assert.NoError(t, err)
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, invocation.GetMetadata().GetGeneration())
+	assert.Len(t, invocation.GetStatus().GetDynamicTasks(), 2)
+}
+
+func TestInvocation_CompleteConflictsWithTerminalEvent(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+
+	// Both calls observed generation 1, but the invocation can only be finished once.
+	err := invocationAPI.Cancel(invocationID, WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = invocationAPI.Complete(invocationID, typedvalues.MustWrap("output"), nil, WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_ABORTED, invocation.GetStatus().GetStatus())
+}
+
+func TestTask_FailConflictsWithFinishedTask(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+
+	err := taskAPI.Fail(invocationID, "task-1", "first failure", WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = taskAPI.Fail(invocationID, "task-1", "second failure", WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+}
+
+// siblingBackend simulates the results of sibling tasks, such as those of a foreach, by appending the result of a
+// sibling to the invocation right before each conditional append, which therefore conflicts.
+type siblingBackend struct {
+	*mem.Backend
+	siblings int
+}
+
+func (b *siblingBackend) Append(event *fes.Event) error {
+	if event.GetExpectedGeneration() > 0 && b.siblings > 0 {
+		b.siblings--
+		sibling, err := fes.NewEvent(projectors.NewTaskRunAggregate(fmt.Sprintf("sibling-%d", b.siblings)),
+			&events.TaskSkipped{})
+		if err != nil {
+			return err
+		}
+		sibling.Parent = event.Parent
+		if err := b.Backend.Append(sibling); err != nil {
+			return err
+		}
+	}
+	return b.Backend.Append(event)
+}
+
+func TestTask_ResultIsNotLostAfterManyConflicts(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	siblings := maxConflictRetries + 2
+	taskAPI := NewTaskAPI(nil, &siblingBackend{Backend: backend, siblings: siblings}, nil)
+
+	// Every attempt conflicts with the result of a sibling, but as long as the task has not finished the result should
+	// be appended eventually.
+	err := taskAPI.Skip(invocationID, "task-1", WithExpectedGeneration(1))
+	assert.NoError(t, err)
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.Len(t, invocation.TaskInvocations(), siblings+1)
+	task, ok := invocation.TaskInvocation("task-1")
+	assert.True(t, ok)
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, task.GetStatus().GetStatus())
+}
+
+// conflictingBackend rejects every conditional append with a concurrent modification.
+type conflictingBackend struct {
+	*mem.Backend
+	appends int
+}
+
+func (b *conflictingBackend) Append(event *fes.Event) error {
+	if event.GetExpectedGeneration() > 0 {
+		b.appends++
+		return fes.ErrConcurrentModification.WithEvent(event)
+	}
+	return b.Backend.Append(event)
+}
+
+func TestTask_PersistentConflictIsReturned(t *testing.T) {
+	defer func(backoff, maxBackoff time.Duration) {
+		conflictBackoff, maxConflictBackoff = backoff, maxBackoff
+	}(conflictBackoff, maxConflictBackoff)
+	conflictBackoff, maxConflictBackoff = time.Microsecond, time.Microsecond
+
+	_, backend, invocationID := setupInvocation(t)
+	conflicting := &conflictingBackend{Backend: backend}
+	taskAPI := NewTaskAPI(nil, conflicting, nil)
+
+	// Even though the precondition keeps holding, the append should give up eventually.
+	err := taskAPI.Skip(invocationID, "task-1", WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	assert.Equal(t, maxPreconditionRetries+1, conflicting.appends)
+
+	// Without a precondition, the append should give up much sooner.
+	conflicting.appends = 0
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate("task-2"), &events.TaskSkipped{})
+	assert.NoError(t, err)
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	err = appendToInvocation(conflicting, invocationID, event, 1, nil)
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	assert.Equal(t, maxConflictRetries+1, conflicting.appends)
+}
+
+func TestTask_Skip(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+
+	err := taskAPI.Skip(invocationID, "task-1", WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	task, ok := invocation.TaskInvocation("task-1")
+	assert.True(t, ok)
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, task.GetStatus().GetStatus())
+
+	// Both calls observed generation 1, but a skipped task has finished, so it cannot be failed anymore.
+	err = taskAPI.Fail(invocationID, "task-1", "failure", WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+}
+
+func TestTask_Abort(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+
+	err := taskAPI.Abort(invocationID, "task-1", "no longer needed", WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	task, ok := invocation.TaskInvocation("task-1")
+	assert.True(t, ok)
+	assert.Equal(t, types.TaskInvocationStatus_ABORTED, task.GetStatus().GetStatus())
+	assert.Equal(t, "no longer needed", task.GetStatus().GetError().GetMessage())
+
+	// The result of the aborted task, which observed generation 1, should be ignored.
+	err = taskAPI.Fail(invocationID, "task-1", "context canceled", WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+}
+
+func TestTask_Reuse(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+	spec := &types.TaskInvocationSpec{
+		InvocationId: invocationID,
+		TaskId:       "task-1",
+		FnRef:        &types.FnRef{Runtime: "test", ID: "fn"},
+		Task:         &types.Task{Metadata: types.NewObjectMetadata("task-1")},
+	}
+
+	// Only successful results can be reused.
+	_, err := taskAPI.Reuse(spec, &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_FAILED})
+	assert.Error(t, err)
+
+	task, err := taskAPI.Reuse(spec, &types.TaskInvocationStatus{
+		Status: types.TaskInvocationStatus_SUCCEEDED,
+		Output: typedvalues.MustWrap("cached"),
+	}, WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	assert.True(t, task.GetStatus().GetCached())
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	taskRun, ok := invocation.TaskInvocation("task-1")
+	assert.True(t, ok)
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, taskRun.GetStatus().GetStatus())
+	assert.True(t, taskRun.GetStatus().GetCached())
+	assert.Equal(t, "cached", typedvalues.MustUnwrap(taskRun.GetStatus().GetOutput()))
+}
+
+func TestTask_InvokeRecordsStartTime(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	runtime := mock.NewRuntime()
+	runtime.Functions["slow"] = func(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+		time.Sleep(50 * time.Millisecond)
+		return nil, nil
+	}
+	runtime.Functions["failing"] = func(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+		return nil, errors.New("failed")
+	}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{"test": runtime}, backend, nil)
+
+	for _, fn := range []string{"slow", "failing"} {
+		_, err := taskAPI.Invoke(&types.TaskInvocationSpec{
+			InvocationId: invocationID,
+			TaskId:       fn,
+			FnRef:        &types.FnRef{Runtime: "test", ID: fn},
+			Task:         &types.Task{Metadata: types.NewObjectMetadata(fn)},
+		})
+		assert.NoError(t, err)
+	}
+
+	// The start time should be read from the events, rather than from the time of the projection.
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	taskRun, _ := invocation.TaskInvocation("slow")
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, taskRun.GetStatus().GetStatus())
+	startedAt, err := ptypes.Timestamp(taskRun.GetStatus().GetStartedAt())
+	assert.NoError(t, err)
+	finishedAt, err := ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt())
+	assert.NoError(t, err)
+	assert.True(t, finishedAt.Sub(startedAt) >= 50*time.Millisecond)
+
+	taskRun, _ = invocation.TaskInvocation("failing")
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, taskRun.GetStatus().GetStatus())
+	assert.NotNil(t, taskRun.GetStatus().GetStartedAt())
+}
+
+func TestTask_RetryKeepsAttemptHistory(t *testing.T) {
+	_, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+
+	err := taskAPI.Fail(invocationID, "task-1", "first failure", WithExpectedGeneration(1))
+	assert.NoError(t, err)
+
+	// Both retries observed generation 2, but the failed attempt can only be retried once.
+	err = taskAPI.Retry(invocationID, "task-1", 2, WithExpectedGeneration(2))
+	assert.NoError(t, err)
+	err = taskAPI.Retry(invocationID, "task-1", 2, WithExpectedGeneration(2))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	task, ok := invocation.TaskInvocation("task-1")
+	assert.True(t, ok)
+	assert.Equal(t, types.TaskInvocationStatus_IN_PROGRESS, task.GetStatus().GetStatus())
+	assert.Equal(t, 2, task.GetStatus().Attempt())
+	assert.Len(t, task.GetStatus().GetAttempts(), 1)
+	assert.Equal(t, "first failure", task.GetStatus().GetAttempts()[0].GetError().GetMessage())
+
+	err = taskAPI.Fail(invocationID, "task-1", "second failure", WithExpectedGeneration(3))
+	assert.NoError(t, err)
+	invocation, err = loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	task, _ = invocation.TaskInvocation("task-1")
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, task.GetStatus().GetStatus())
+	assert.Equal(t, "second failure", task.GetStatus().GetError().GetMessage())
+	assert.Equal(t, 2, task.GetStatus().Attempt())
+}
+
+func TestTask_CompensationIsRecordedOnce(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+
+	err := invocationAPI.Fail(invocationID, errors.New("task failed"), WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = invocationAPI.StartCompensation(invocationID, []string{"task-2", "task-1"}, WithExpectedGeneration(2))
+	assert.NoError(t, err)
+
+	// Both calls observed generation 3, but a task can only be compensated once.
+	err = taskAPI.FailCompensation(invocationID, "task-2", "first failure", WithExpectedGeneration(3))
+	assert.NoError(t, err)
+	err = taskAPI.FailCompensation(invocationID, "task-2", "second failure", WithExpectedGeneration(3))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	// A compensation that could not be run is recorded as failed.
+	result, err := taskAPI.Compensate(&types.TaskInvocationSpec{
+		InvocationId: invocationID,
+		TaskId:       "task-1",
+		FnRef:        &types.FnRef{Runtime: "unknown", ID: "undo"},
+	}, WithExpectedGeneration(4))
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, result.GetStatus())
+
+	err = invocationAPI.CompleteCompensation(invocationID, errors.New("failed to compensate task-2"),
+		WithExpectedGeneration(5))
+	assert.NoError(t, err)
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	compensation := invocation.GetStatus().GetCompensation()
+	assert.Equal(t, types.CompensationStatus_FAILED, compensation.GetStatus())
+	assert.Equal(t, []string{"task-2", "task-1"}, compensation.GetOrder())
+	assert.Equal(t, "first failure", compensation.GetTasks()["task-2"].GetError().GetMessage())
+	assert.Len(t, compensation.GetTasks(), 2)
+	assert.False(t, invocation.CompensationPending())
+}
+
+func TestInvocation_Start(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.True(t, invocation.GetStatus().Scheduled())
+
+	// Both calls observed generation 1, but the invocation can only be started once.
+	err = invocationAPI.Start(invocationID, WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = invocationAPI.Start(invocationID, WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	invocation, err = loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, invocation.GetStatus().GetStatus())
+	assert.NotNil(t, invocation.GetStatus().GetStartedAt())
+}
+
+func TestInvocation_PauseAndResume(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+	err := invocationAPI.Start(invocationID, WithExpectedGeneration(1))
+	assert.NoError(t, err)
+
+	// Both calls observed generation 2, but the invocation can only be paused once.
+	err = invocationAPI.Pause(invocationID, WithExpectedGeneration(2))
+	assert.NoError(t, err)
+	err = invocationAPI.Pause(invocationID, WithExpectedGeneration(2))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.True(t, invocation.GetStatus().Paused())
+	assert.False(t, invocation.GetStatus().Finished())
+
+	// The results of running tasks are still recorded while paused.
+	taskAPI := NewTaskAPI(nil, backend, nil)
+	err = taskAPI.Fail(invocationID, "task-1", "failure", WithExpectedGeneration(3))
+	assert.NoError(t, err)
+
+	err = invocationAPI.Resume(invocationID, WithExpectedGeneration(4))
+	assert.NoError(t, err)
+	err = invocationAPI.Resume(invocationID, WithExpectedGeneration(4))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	invocation, err = loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, invocation.GetStatus().GetStatus())
+	assert.Contains(t, invocation.GetStatus().GetTasks(), "task-1")
+}
+
+func TestInvocation_ResumeBeforeStart(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+
+	err := invocationAPI.Pause(invocationID)
+	assert.NoError(t, err)
+	err = invocationAPI.Resume(invocationID)
+	assert.NoError(t, err)
+
+	// An invocation that was paused before it started is scheduled again.
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.True(t, invocation.GetStatus().Scheduled())
+}
+
+func TestInvocation_RerunIsSeeded(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+	taskAPI := NewTaskAPI(nil, backend, nil)
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("b", &types.TaskSpec{FunctionRef: "noop", Requires: types.Require("a")})
+
+	// Only finished invocations that did not succeed can be rerun.
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	_, err = invocationAPI.Rerun(invocation, wf)
+	assert.Error(t, err)
+
+	err = taskAPI.Fail(invocationID, "b", "failure")
+	assert.NoError(t, err)
+	err = invocationAPI.Fail(invocationID, errors.New("task b failed"))
+	assert.NoError(t, err)
+	invocation, err = loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	invocation.Spec.Workflow = wf
+	invocation.Spec.Labels = map[string]string{"team": "billing"}
+	invocation.Status.Tasks["a"] = &types.TaskInvocation{
+		Metadata: types.NewObjectMetadata("a"),
+		Status: &types.TaskInvocationStatus{
+			Status: types.TaskInvocationStatus_SUCCEEDED,
+			Output: typedvalues.MustWrap("output"),
+		},
+	}
+
+	rerunID, err := invocationAPI.Rerun(invocation, wf)
+	assert.NoError(t, err)
+	assert.NotEqual(t, invocationID, rerunID)
+	rerun, err := loadInvocation(backend, rerunID)
+	assert.NoError(t, err)
+	assert.Equal(t, invocationID, rerun.GetSpec().GetSeed().GetInvocationId())
+	assert.Equal(t, map[string]string{"team": "billing"}, rerun.GetSpec().GetLabels())
+	assert.Len(t, rerun.GetStatus().GetTasks(), 1)
+	taskRun, ok := rerun.TaskInvocation("a")
+	assert.True(t, ok)
+	assert.Equal(t, "output", typedvalues.MustUnwrap(taskRun.GetStatus().GetOutput()))
+}
+
+func TestInvocation_SignalIsReceivedOnce(t *testing.T) {
+	invocationAPI, backend, invocationID := setupInvocation(t)
+
+	// Both calls observed generation 1, but the invocation can only receive the signal once.
+	err := invocationAPI.Signal(invocationID, "approved", typedvalues.MustWrap("yes"), WithExpectedGeneration(1))
+	assert.NoError(t, err)
+	err = invocationAPI.Signal(invocationID, "approved", typedvalues.MustWrap("no"), WithExpectedGeneration(1))
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+
+	// Signals with other names are still received.
+	err = invocationAPI.Signal(invocationID, "paid", nil, WithExpectedGeneration(1))
+	assert.NoError(t, err)
+
+	invocation, err := loadInvocation(backend, invocationID)
+	assert.NoError(t, err)
+	assert.Len(t, invocation.GetStatus().GetSignals(), 2)
+	assert.Equal(t, "yes", typedvalues.MustUnwrap(invocation.GetStatus().GetSignals()["approved"].GetPayload()))
+}
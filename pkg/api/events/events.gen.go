@@ -11,19 +11,36 @@ type Event interface {
 }
 
 const (
-	EventWorkflowCreated       EventType = "WorkflowCreated"
-	EventWorkflowDeleted       EventType = "WorkflowDeleted"
-	EventWorkflowParsed        EventType = "WorkflowParsed"
-	EventWorkflowParsingFailed EventType = "WorkflowParsingFailed"
-	EventInvocationCreated     EventType = "InvocationCreated"
-	EventInvocationCompleted   EventType = "InvocationCompleted"
-	EventInvocationCanceled    EventType = "InvocationCanceled"
-	EventInvocationTaskAdded   EventType = "InvocationTaskAdded"
-	EventInvocationFailed      EventType = "InvocationFailed"
-	EventTaskStarted           EventType = "TaskStarted"
-	EventTaskSucceeded         EventType = "TaskSucceeded"
-	EventTaskSkipped           EventType = "TaskSkipped"
-	EventTaskFailed            EventType = "TaskFailed"
+	EventWorkflowCreated               EventType = "WorkflowCreated"
+	EventWorkflowDeleted               EventType = "WorkflowDeleted"
+	EventWorkflowParsed                EventType = "WorkflowParsed"
+	EventWorkflowParsingFailed         EventType = "WorkflowParsingFailed"
+	EventInvocationCreated             EventType = "InvocationCreated"
+	EventInvocationCompleted           EventType = "InvocationCompleted"
+	EventInvocationCanceled            EventType = "InvocationCanceled"
+	EventInvocationTaskAdded           EventType = "InvocationTaskAdded"
+	EventInvocationFailed              EventType = "InvocationFailed"
+	EventTaskStarted                   EventType = "TaskStarted"
+	EventTaskSucceeded                 EventType = "TaskSucceeded"
+	EventTaskSkipped                   EventType = "TaskSkipped"
+	EventTaskFailed                    EventType = "TaskFailed"
+	EventTaskAborted                   EventType = "TaskAborted"
+	EventTaskRetried                   EventType = "TaskRetried"
+	EventInvocationCompensationStarted EventType = "InvocationCompensationStarted"
+	EventInvocationTaskCompensated     EventType = "InvocationTaskCompensated"
+	EventInvocationCompensated         EventType = "InvocationCompensated"
+	EventInvocationStarted             EventType = "InvocationStarted"
+	EventInvocationPaused              EventType = "InvocationPaused"
+	EventInvocationResumed             EventType = "InvocationResumed"
+	EventInvocationSignaled            EventType = "InvocationSignaled"
+	EventTriggerCreated                EventType = "TriggerCreated"
+	EventTriggerUpdated                EventType = "TriggerUpdated"
+	EventTriggerDeleted                EventType = "TriggerDeleted"
+	EventTriggerFired                  EventType = "TriggerFired"
+	EventTriggerSkipped                EventType = "TriggerSkipped"
+	EventReplicaJoined                 EventType = "ReplicaJoined"
+	EventReplicaLeaseRenewed           EventType = "ReplicaLeaseRenewed"
+	EventReplicaLeft                   EventType = "ReplicaLeft"
 )
 
 func (m *WorkflowCreated) Type() EventType {
@@ -77,3 +94,71 @@ func (m *TaskSkipped) Type() EventType {
 func (m *TaskFailed) Type() EventType {
 	return EventTaskFailed
 }
+
+func (m *TaskAborted) Type() EventType {
+	return EventTaskAborted
+}
+
+func (m *TaskRetried) Type() EventType {
+	return EventTaskRetried
+}
+
+func (m *InvocationCompensationStarted) Type() EventType {
+	return EventInvocationCompensationStarted
+}
+
+func (m *InvocationTaskCompensated) Type() EventType {
+	return EventInvocationTaskCompensated
+}
+
+func (m *InvocationCompensated) Type() EventType {
+	return EventInvocationCompensated
+}
+
+func (m *InvocationStarted) Type() EventType {
+	return EventInvocationStarted
+}
+
+func (m *InvocationPaused) Type() EventType {
+	return EventInvocationPaused
+}
+
+func (m *InvocationResumed) Type() EventType {
+	return EventInvocationResumed
+}
+
+func (m *InvocationSignaled) Type() EventType {
+	return EventInvocationSignaled
+}
+
+func (m *TriggerCreated) Type() EventType {
+	return EventTriggerCreated
+}
+
+func (m *TriggerUpdated) Type() EventType {
+	return EventTriggerUpdated
+}
+
+func (m *TriggerDeleted) Type() EventType {
+	return EventTriggerDeleted
+}
+
+func (m *TriggerFired) Type() EventType {
+	return EventTriggerFired
+}
+
+func (m *TriggerSkipped) Type() EventType {
+	return EventTriggerSkipped
+}
+
+func (m *ReplicaJoined) Type() EventType {
+	return EventReplicaJoined
+}
+
+func (m *ReplicaLeaseRenewed) Type() EventType {
+	return EventReplicaLeaseRenewed
+}
+
+func (m *ReplicaLeft) Type() EventType {
+	return EventReplicaLeft
+}
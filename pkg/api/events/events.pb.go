@@ -21,6 +21,23 @@ It has these top-level messages:
 	TaskSucceeded
 	TaskSkipped
 	TaskFailed
+	TaskAborted
+	TaskRetried
+	InvocationCompensationStarted
+	InvocationTaskCompensated
+	InvocationCompensated
+	InvocationStarted
+	InvocationPaused
+	InvocationResumed
+	InvocationSignaled
+	TriggerCreated
+	TriggerUpdated
+	TriggerDeleted
+	TriggerFired
+	TriggerSkipped
+	ReplicaJoined
+	ReplicaLeaseRenewed
+	ReplicaLeft
 */
 package events
 
@@ -29,6 +46,7 @@ import fmt "fmt"
 import math "math"
 import fission_workflows_types1 "github.com/fission/fission-workflows/pkg/types"
 import fission_workflows_types "github.com/fission/fission-workflows/pkg/types/typedvalues"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -207,6 +225,10 @@ func (m *TaskStarted) GetSpec() *fission_workflows_types1.TaskInvocationSpec {
 
 type TaskSucceeded struct {
 	Result *fission_workflows_types1.TaskInvocationStatus `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	// Cached indicates that the result was served from the result cache, rather than by running the function.
+	Cached bool `protobuf:"varint,2,opt,name=cached" json:"cached,omitempty"`
+	// StartedAt is the time at which the function of the task started running.
+	StartedAt *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=startedAt" json:"startedAt,omitempty"`
 }
 
 func (m *TaskSucceeded) Reset()                    { *m = TaskSucceeded{} }
@@ -221,6 +243,20 @@ func (m *TaskSucceeded) GetResult() *fission_workflows_types1.TaskInvocationStat
 	return nil
 }
 
+func (m *TaskSucceeded) GetCached() bool {
+	if m != nil {
+		return m.Cached
+	}
+	return false
+}
+
+func (m *TaskSucceeded) GetStartedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
 type TaskSkipped struct {
 }
 
@@ -231,6 +267,8 @@ func (*TaskSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int
 
 type TaskFailed struct {
 	Error *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	// StartedAt is the time at which the function of the task started running, if it was run at all.
+	StartedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=startedAt" json:"startedAt,omitempty"`
 }
 
 func (m *TaskFailed) Reset()                    { *m = TaskFailed{} }
@@ -245,6 +283,327 @@ func (m *TaskFailed) GetError() *fission_workflows_types1.Error {
 	return nil
 }
 
+func (m *TaskFailed) GetStartedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+// TaskAborted marks the cancellation of a task of which the result is no longer needed.
+type TaskAborted struct {
+	Error *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TaskAborted) Reset()                    { *m = TaskAborted{} }
+func (m *TaskAborted) String() string            { return proto.CompactTextString(m) }
+func (*TaskAborted) ProtoMessage()               {}
+func (*TaskAborted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TaskAborted) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// TaskRetried marks the start of a new attempt of a failed task.
+type TaskRetried struct {
+	// Attempt is the number of the new attempt, where the initial attempt is 1.
+	Attempt int32 `protobuf:"varint,1,opt,name=attempt" json:"attempt,omitempty"`
+}
+
+func (m *TaskRetried) Reset()                    { *m = TaskRetried{} }
+func (m *TaskRetried) String() string            { return proto.CompactTextString(m) }
+func (*TaskRetried) ProtoMessage()               {}
+func (*TaskRetried) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *TaskRetried) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+// InvocationCompensationStarted marks the start of the compensation of a failed invocation.
+type InvocationCompensationStarted struct {
+	// Tasks contains the IDs of the tasks to be compensated, in the order in which they are compensated.
+	Tasks []string `protobuf:"bytes,1,rep,name=tasks" json:"tasks,omitempty"`
+}
+
+func (m *InvocationCompensationStarted) Reset()                    { *m = InvocationCompensationStarted{} }
+func (m *InvocationCompensationStarted) String() string            { return proto.CompactTextString(m) }
+func (*InvocationCompensationStarted) ProtoMessage()               {}
+func (*InvocationCompensationStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *InvocationCompensationStarted) GetTasks() []string {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+// InvocationTaskCompensated contains the result of the compensation of a single task.
+type InvocationTaskCompensated struct {
+	TaskId string                                         `protobuf:"bytes,1,opt,name=taskId" json:"taskId,omitempty"`
+	Result *fission_workflows_types1.TaskInvocationStatus `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *InvocationTaskCompensated) Reset()                    { *m = InvocationTaskCompensated{} }
+func (m *InvocationTaskCompensated) String() string            { return proto.CompactTextString(m) }
+func (*InvocationTaskCompensated) ProtoMessage()               {}
+func (*InvocationTaskCompensated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *InvocationTaskCompensated) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *InvocationTaskCompensated) GetResult() *fission_workflows_types1.TaskInvocationStatus {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// InvocationCompensated marks the end of the compensation of a failed invocation.
+type InvocationCompensated struct {
+	Error *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *InvocationCompensated) Reset()                    { *m = InvocationCompensated{} }
+func (m *InvocationCompensated) String() string            { return proto.CompactTextString(m) }
+func (*InvocationCompensated) ProtoMessage()               {}
+func (*InvocationCompensated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *InvocationCompensated) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// InvocationStarted marks the start of the processing of a scheduled invocation, once it is allowed to run by the
+// concurrency limit of its workflow.
+type InvocationStarted struct {
+}
+
+func (m *InvocationStarted) Reset()                    { *m = InvocationStarted{} }
+func (m *InvocationStarted) String() string            { return proto.CompactTextString(m) }
+func (*InvocationStarted) ProtoMessage()               {}
+func (*InvocationStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+// InvocationPaused marks that no new tasks of the invocation should be scheduled, until the invocation is resumed.
+type InvocationPaused struct {
+}
+
+func (m *InvocationPaused) Reset()                    { *m = InvocationPaused{} }
+func (m *InvocationPaused) String() string            { return proto.CompactTextString(m) }
+func (*InvocationPaused) ProtoMessage()               {}
+func (*InvocationPaused) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+// InvocationResumed marks the continuation of a paused invocation.
+type InvocationResumed struct {
+}
+
+func (m *InvocationResumed) Reset()                    { *m = InvocationResumed{} }
+func (m *InvocationResumed) String() string            { return proto.CompactTextString(m) }
+func (*InvocationResumed) ProtoMessage()               {}
+func (*InvocationResumed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+// InvocationSignaled contains an external signal that was sent to the invocation.
+type InvocationSignaled struct {
+	Signal *fission_workflows_types1.Signal `protobuf:"bytes,1,opt,name=signal" json:"signal,omitempty"`
+}
+
+func (m *InvocationSignaled) Reset()                    { *m = InvocationSignaled{} }
+func (m *InvocationSignaled) String() string            { return proto.CompactTextString(m) }
+func (*InvocationSignaled) ProtoMessage()               {}
+func (*InvocationSignaled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *InvocationSignaled) GetSignal() *fission_workflows_types1.Signal {
+	if m != nil {
+		return m.Signal
+	}
+	return nil
+}
+
+// TriggerCreated contains the spec of a newly created trigger.
+type TriggerCreated struct {
+	Spec *fission_workflows_types1.TriggerSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *TriggerCreated) Reset()                    { *m = TriggerCreated{} }
+func (m *TriggerCreated) String() string            { return proto.CompactTextString(m) }
+func (*TriggerCreated) ProtoMessage()               {}
+func (*TriggerCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *TriggerCreated) GetSpec() *fission_workflows_types1.TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+// TriggerUpdated replaces the spec of a trigger.
+type TriggerUpdated struct {
+	Spec *fission_workflows_types1.TriggerSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *TriggerUpdated) Reset()                    { *m = TriggerUpdated{} }
+func (m *TriggerUpdated) String() string            { return proto.CompactTextString(m) }
+func (*TriggerUpdated) ProtoMessage()               {}
+func (*TriggerUpdated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *TriggerUpdated) GetSpec() *fission_workflows_types1.TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+// TriggerDeleted marks that a trigger should no longer fire.
+type TriggerDeleted struct {
+}
+
+func (m *TriggerDeleted) Reset()                    { *m = TriggerDeleted{} }
+func (m *TriggerDeleted) String() string            { return proto.CompactTextString(m) }
+func (*TriggerDeleted) ProtoMessage()               {}
+func (*TriggerDeleted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+// TriggerFired marks that a trigger has started an invocation.
+type TriggerFired struct {
+	// ScheduledAt is the time at which the trigger was scheduled to fire.
+	ScheduledAt  *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	InvocationId string                     `protobuf:"bytes,2,opt,name=invocationId" json:"invocationId,omitempty"`
+	// SourceInvocationId is the id of the finished invocation that fired a completion trigger.
+	SourceInvocationId string `protobuf:"bytes,3,opt,name=sourceInvocationId" json:"sourceInvocationId,omitempty"`
+}
+
+func (m *TriggerFired) Reset()                    { *m = TriggerFired{} }
+func (m *TriggerFired) String() string            { return proto.CompactTextString(m) }
+func (*TriggerFired) ProtoMessage()               {}
+func (*TriggerFired) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *TriggerFired) GetScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *TriggerFired) GetInvocationId() string {
+	if m != nil {
+		return m.InvocationId
+	}
+	return ""
+}
+
+func (m *TriggerFired) GetSourceInvocationId() string {
+	if m != nil {
+		return m.SourceInvocationId
+	}
+	return ""
+}
+
+// TriggerSkipped marks that a trigger did not start an invocation at the time it was scheduled to fire.
+type TriggerSkipped struct {
+	ScheduledAt *google_protobuf.Timestamp      `protobuf:"bytes,1,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+	Error       *fission_workflows_types1.Error `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// SourceInvocationId is the id of the finished invocation that fired a completion trigger.
+	SourceInvocationId string `protobuf:"bytes,3,opt,name=sourceInvocationId" json:"sourceInvocationId,omitempty"`
+}
+
+func (m *TriggerSkipped) Reset()                    { *m = TriggerSkipped{} }
+func (m *TriggerSkipped) String() string            { return proto.CompactTextString(m) }
+func (*TriggerSkipped) ProtoMessage()               {}
+func (*TriggerSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *TriggerSkipped) GetScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *TriggerSkipped) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *TriggerSkipped) GetSourceInvocationId() string {
+	if m != nil {
+		return m.SourceInvocationId
+	}
+	return ""
+}
+
+// ReplicaJoined marks that a replica has started to take part in processing the invocations.
+type ReplicaJoined struct {
+	LeaseExpiresAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=leaseExpiresAt" json:"leaseExpiresAt,omitempty"`
+	// JoinedAt is the time at which the replica joined the cluster, if it differs from the time of the event; when the
+	// event stream of the replica is compacted, the replica rejoins without losing its seniority.
+	JoinedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=joinedAt" json:"joinedAt,omitempty"`
+}
+
+func (m *ReplicaJoined) Reset()                    { *m = ReplicaJoined{} }
+func (m *ReplicaJoined) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaJoined) ProtoMessage()               {}
+func (*ReplicaJoined) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *ReplicaJoined) GetLeaseExpiresAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LeaseExpiresAt
+	}
+	return nil
+}
+
+func (m *ReplicaJoined) GetJoinedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return nil
+}
+
+// ReplicaLeaseRenewed extends the lease of a replica.
+type ReplicaLeaseRenewed struct {
+	LeaseExpiresAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=leaseExpiresAt" json:"leaseExpiresAt,omitempty"`
+}
+
+func (m *ReplicaLeaseRenewed) Reset()                    { *m = ReplicaLeaseRenewed{} }
+func (m *ReplicaLeaseRenewed) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaLeaseRenewed) ProtoMessage()               {}
+func (*ReplicaLeaseRenewed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *ReplicaLeaseRenewed) GetLeaseExpiresAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LeaseExpiresAt
+	}
+	return nil
+}
+
+// ReplicaLeft marks that a replica no longer takes part in processing the invocations, either because it stopped or
+// because its lease expired.
+type ReplicaLeft struct {
+	Error *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReplicaLeft) Reset()                    { *m = ReplicaLeft{} }
+func (m *ReplicaLeft) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaLeft) ProtoMessage()               {}
+func (*ReplicaLeft) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *ReplicaLeft) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WorkflowCreated)(nil), "fission.workflows.events.WorkflowCreated")
 	proto.RegisterType((*WorkflowDeleted)(nil), "fission.workflows.events.WorkflowDeleted")
@@ -259,41 +618,81 @@ func init() {
 	proto.RegisterType((*TaskSucceeded)(nil), "fission.workflows.events.TaskSucceeded")
 	proto.RegisterType((*TaskSkipped)(nil), "fission.workflows.events.TaskSkipped")
 	proto.RegisterType((*TaskFailed)(nil), "fission.workflows.events.TaskFailed")
+	proto.RegisterType((*TaskAborted)(nil), "fission.workflows.events.TaskAborted")
+	proto.RegisterType((*TaskRetried)(nil), "fission.workflows.events.TaskRetried")
+	proto.RegisterType((*InvocationCompensationStarted)(nil), "fission.workflows.events.InvocationCompensationStarted")
+	proto.RegisterType((*InvocationTaskCompensated)(nil), "fission.workflows.events.InvocationTaskCompensated")
+	proto.RegisterType((*InvocationCompensated)(nil), "fission.workflows.events.InvocationCompensated")
+	proto.RegisterType((*InvocationStarted)(nil), "fission.workflows.events.InvocationStarted")
+	proto.RegisterType((*InvocationPaused)(nil), "fission.workflows.events.InvocationPaused")
+	proto.RegisterType((*InvocationResumed)(nil), "fission.workflows.events.InvocationResumed")
+	proto.RegisterType((*InvocationSignaled)(nil), "fission.workflows.events.InvocationSignaled")
+	proto.RegisterType((*TriggerCreated)(nil), "fission.workflows.events.TriggerCreated")
+	proto.RegisterType((*TriggerUpdated)(nil), "fission.workflows.events.TriggerUpdated")
+	proto.RegisterType((*TriggerDeleted)(nil), "fission.workflows.events.TriggerDeleted")
+	proto.RegisterType((*TriggerFired)(nil), "fission.workflows.events.TriggerFired")
+	proto.RegisterType((*TriggerSkipped)(nil), "fission.workflows.events.TriggerSkipped")
+	proto.RegisterType((*ReplicaJoined)(nil), "fission.workflows.events.ReplicaJoined")
+	proto.RegisterType((*ReplicaLeaseRenewed)(nil), "fission.workflows.events.ReplicaLeaseRenewed")
+	proto.RegisterType((*ReplicaLeft)(nil), "fission.workflows.events.ReplicaLeft")
 }
 
 func init() { proto.RegisterFile("pkg/api/events/events.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 488 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xdf, 0x6a, 0xd4, 0x40,
-	0x14, 0xc6, 0x49, 0xdb, 0x5d, 0xf4, 0x2c, 0xab, 0xed, 0x88, 0xb0, 0xac, 0x28, 0x25, 0x22, 0x14,
-	0xa4, 0x09, 0xb6, 0x5e, 0xd8, 0x7a, 0x21, 0xb6, 0xae, 0xec, 0x4a, 0xfd, 0x43, 0x2a, 0x55, 0x04,
-	0x2f, 0xa6, 0x99, 0xd3, 0x35, 0x24, 0xcd, 0x0c, 0x33, 0x93, 0x2d, 0xfb, 0x30, 0xbe, 0x88, 0x4f,
-	0x27, 0x93, 0x99, 0x98, 0x04, 0xdd, 0xad, 0x74, 0x6f, 0x36, 0x43, 0xf6, 0x7c, 0x3f, 0xce, 0xf7,
-	0x9d, 0x33, 0x81, 0x07, 0x22, 0x9d, 0x86, 0x54, 0x24, 0x21, 0xce, 0x30, 0xd7, 0xca, 0x3d, 0x02,
-	0x21, 0xb9, 0xe6, 0x64, 0x70, 0x91, 0x28, 0x95, 0xf0, 0x3c, 0xb8, 0xe2, 0x32, 0xbd, 0xc8, 0xf8,
-	0x95, 0x0a, 0xec, 0xff, 0xc3, 0xc3, 0x69, 0xa2, 0x7f, 0x14, 0xe7, 0x41, 0xcc, 0x2f, 0x43, 0x57,
-	0x54, 0x3d, 0x77, 0xff, 0x14, 0x87, 0x86, 0xad, 0xe7, 0x02, 0x95, 0xfd, 0xb5, 0xd4, 0xe1, 0xc9,
-	0x0d, 0xb4, 0x6c, 0x46, 0xb3, 0xa2, 0x7d, 0xb6, 0x34, 0xff, 0x04, 0xee, 0x7e, 0x71, 0xa2, 0x63,
-	0x89, 0x54, 0x23, 0x23, 0x07, 0xb0, 0xa1, 0x04, 0xc6, 0x03, 0x6f, 0xdb, 0xdb, 0xe9, 0xed, 0x3d,
-	0x09, 0xfe, 0x76, 0x61, 0xdb, 0xa9, 0x74, 0xa7, 0x02, 0xe3, 0xa8, 0x94, 0xf8, 0x5b, 0x35, 0xed,
-	0x0d, 0x66, 0xa8, 0x91, 0xf9, 0xbf, 0x3c, 0xb8, 0x53, 0xbd, 0xfb, 0x44, 0xa5, 0x42, 0x46, 0x26,
-	0xd0, 0xd1, 0x54, 0xa5, 0x6a, 0xe0, 0x6d, 0xaf, 0xef, 0xf4, 0xf6, 0xf6, 0x83, 0x45, 0x39, 0x05,
-	0x6d, 0x61, 0xf0, 0xd9, 0xa8, 0x46, 0xb9, 0x96, 0xf3, 0xc8, 0x12, 0x86, 0xdf, 0x01, 0xea, 0x97,
-	0x64, 0x13, 0xd6, 0x53, 0x9c, 0x97, 0x8d, 0xdf, 0x8e, 0xcc, 0x91, 0x1c, 0x40, 0xa7, 0xb4, 0x3b,
-	0x58, 0x2b, 0xcd, 0x3c, 0x5e, 0x68, 0xc6, 0x50, 0x4e, 0x35, 0xd5, 0x85, 0x8a, 0xac, 0xe2, 0x70,
-	0xed, 0x85, 0xe7, 0xbf, 0x87, 0xfb, 0xcd, 0x16, 0x92, 0x7c, 0xfa, 0x96, 0x26, 0x19, 0x32, 0xf2,
-	0x1c, 0x3a, 0x28, 0x25, 0x97, 0x2e, 0xa4, 0x47, 0x0b, 0xb9, 0x23, 0x53, 0x15, 0xd9, 0x62, 0xff,
-	0x2b, 0x6c, 0x4d, 0xf2, 0x19, 0x8f, 0xa9, 0x4e, 0x78, 0x5e, 0xc5, 0x7d, 0xdc, 0x8a, 0x3b, 0xbc,
-	0x36, 0xee, 0x9a, 0xd0, 0x08, 0xfe, 0xa7, 0x07, 0xf7, 0x1a, 0x68, 0x7e, 0x29, 0xca, 0xf4, 0xc9,
-	0x4b, 0xe8, 0xf2, 0x42, 0x8b, 0x42, 0x3b, 0xfc, 0x92, 0x00, 0xcc, 0x6a, 0x9c, 0x19, 0xe7, 0x91,
-	0x93, 0x90, 0x09, 0xf4, 0x3f, 0x96, 0xa7, 0x31, 0x52, 0x86, 0x52, 0x5d, 0x1f, 0x62, 0xcd, 0x68,
-	0x2b, 0xfd, 0x77, 0x40, 0x1a, 0xed, 0xd1, 0x3c, 0xc6, 0x9b, 0xa7, 0x38, 0x6e, 0x5a, 0x35, 0x73,
-	0x7b, 0xcd, 0x18, 0x32, 0xf2, 0x0c, 0x36, 0xcc, 0x4e, 0x38, 0xd6, 0xc3, 0xa5, 0x93, 0x8e, 0xca,
-	0x52, 0x7f, 0x0c, 0x9b, 0x35, 0x69, 0xa5, 0xc9, 0x7e, 0x80, 0x9e, 0xdb, 0x20, 0x69, 0x62, 0x7f,
-	0xd5, 0x9a, 0xe9, 0xd3, 0xa5, 0xbd, 0xfc, 0x73, 0x9e, 0x67, 0xd0, 0x2f, 0x79, 0x45, 0x1c, 0x23,
-	0x1a, 0x77, 0x23, 0xe8, 0x4a, 0x54, 0x45, 0x56, 0x0d, 0x72, 0xf7, 0x7f, 0x99, 0x76, 0xa7, 0x9d,
-	0xd8, 0xef, 0xbb, 0x3e, 0xd3, 0x44, 0x08, 0x64, 0xfe, 0x91, 0xbd, 0x3e, 0xab, 0x58, 0x3f, 0xba,
-	0xf5, 0xad, 0x6b, 0x6f, 0xeb, 0x79, 0xb7, 0xfc, 0xa4, 0xec, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x86, 0x61, 0xe6, 0x8d, 0x15, 0x05, 0x00, 0x00,
+	// 854 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xd6, 0x3a, 0x8d, 0x49, 0x4e, 0x9a, 0x90, 0x4e, 0x00, 0x99, 0xa0, 0xd2, 0x6a, 0x00, 0x51,
+	0x09, 0x75, 0x2d, 0x5a, 0x7e, 0xd2, 0x82, 0x84, 0xd2, 0x90, 0x2a, 0x8e, 0x52, 0xa8, 0x36, 0xe1,
+	0x57, 0xe2, 0x62, 0xb2, 0x73, 0xe2, 0x0e, 0x5e, 0xef, 0xac, 0x66, 0x66, 0x13, 0x82, 0x78, 0x03,
+	0x9e, 0x01, 0x89, 0x4b, 0x1e, 0x80, 0x2b, 0x9e, 0x0e, 0xcd, 0xcf, 0xda, 0xbb, 0x10, 0x27, 0x8e,
+	0xdd, 0x1b, 0xef, 0xcc, 0xec, 0x39, 0xdf, 0x7c, 0xe7, 0x3b, 0x3f, 0x5e, 0x78, 0xab, 0x18, 0xf4,
+	0xbb, 0xac, 0x10, 0x5d, 0x3c, 0xc5, 0xdc, 0xe8, 0xf0, 0x88, 0x0b, 0x25, 0x8d, 0x24, 0x9d, 0x13,
+	0xa1, 0xb5, 0x90, 0x79, 0x7c, 0x26, 0xd5, 0xe0, 0x24, 0x93, 0x67, 0x3a, 0xf6, 0xef, 0x37, 0x1f,
+	0xf7, 0x85, 0x79, 0x51, 0x1e, 0xc7, 0xa9, 0x1c, 0x76, 0x83, 0x51, 0xf5, 0xbc, 0x3f, 0x32, 0xee,
+	0x5a, 0x6c, 0x73, 0x5e, 0xa0, 0xf6, 0xbf, 0x1e, 0x75, 0xf3, 0x60, 0x06, 0x5f, 0x7e, 0xca, 0xb2,
+	0xb2, 0xb9, 0x0e, 0x68, 0x77, 0xfa, 0x52, 0xf6, 0x33, 0xec, 0xba, 0xdd, 0x71, 0x79, 0xd2, 0x35,
+	0x62, 0x88, 0xda, 0xb0, 0x61, 0xe1, 0x0d, 0xe8, 0x01, 0xbc, 0xfa, 0x5d, 0x40, 0xdd, 0x51, 0xc8,
+	0x0c, 0x72, 0xf2, 0x08, 0x6e, 0xe8, 0x02, 0xd3, 0x4e, 0x74, 0x37, 0xba, 0xb7, 0xf2, 0xe0, 0xbd,
+	0xf8, 0xff, 0x61, 0x7a, 0xbe, 0x95, 0xdf, 0x61, 0x81, 0x69, 0xe2, 0x5c, 0xe8, 0xad, 0x31, 0xda,
+	0x97, 0x98, 0xa1, 0x41, 0x4e, 0xff, 0x89, 0x60, 0xad, 0x3a, 0x7b, 0xce, 0x94, 0x46, 0x4e, 0x7a,
+	0xb0, 0x68, 0x98, 0x1e, 0xe8, 0x4e, 0x74, 0x77, 0xe1, 0xde, 0xca, 0x83, 0x87, 0xf1, 0x24, 0x21,
+	0xe3, 0xa6, 0x63, 0x7c, 0x64, 0xbd, 0x76, 0x73, 0xa3, 0xce, 0x13, 0x8f, 0xb0, 0xf9, 0x13, 0xc0,
+	0xf8, 0x90, 0xac, 0xc3, 0xc2, 0x00, 0xcf, 0x1d, 0xf1, 0xe5, 0xc4, 0x2e, 0xc9, 0x23, 0x58, 0x74,
+	0x7a, 0x74, 0x5a, 0x2e, 0x98, 0x77, 0x26, 0x06, 0x63, 0x51, 0x0e, 0x0d, 0x33, 0xa5, 0x4e, 0xbc,
+	0xc7, 0xe3, 0xd6, 0x56, 0x44, 0x9f, 0xc1, 0xeb, 0x75, 0x0a, 0x22, 0xef, 0x3f, 0x65, 0x22, 0x43,
+	0x4e, 0x3e, 0x82, 0x45, 0x54, 0x4a, 0xaa, 0x20, 0xd2, 0xdb, 0x13, 0x71, 0x77, 0xad, 0x55, 0xe2,
+	0x8d, 0xe9, 0xf7, 0x70, 0xab, 0x97, 0x9f, 0xca, 0x94, 0x19, 0x21, 0xf3, 0x4a, 0xee, 0x9d, 0x86,
+	0xdc, 0xdd, 0x2b, 0xe5, 0x1e, 0x23, 0xd4, 0x84, 0xff, 0x23, 0x82, 0x8d, 0x1a, 0xb4, 0x1c, 0x16,
+	0x4e, 0x7d, 0xf2, 0x19, 0xb4, 0x65, 0x69, 0x8a, 0xd2, 0x74, 0xa2, 0xab, 0x04, 0xb0, 0xb5, 0xf3,
+	0xad, 0x8d, 0x3c, 0x09, 0x2e, 0xa4, 0x07, 0xab, 0x5f, 0xbb, 0xd5, 0x1e, 0x32, 0x8e, 0x4a, 0x77,
+	0x5a, 0xd3, 0x63, 0x34, 0x3d, 0xe9, 0x3e, 0x90, 0x1a, 0x3d, 0x96, 0xa7, 0x38, 0xbb, 0x8a, 0x7b,
+	0xf5, 0x50, 0x6d, 0xde, 0xb6, 0x39, 0x47, 0x4e, 0x3e, 0x84, 0x1b, 0xb6, 0x26, 0x02, 0xd6, 0xed,
+	0x4b, 0x33, 0x9d, 0x38, 0x53, 0xba, 0x07, 0xeb, 0x63, 0xa4, 0xb9, 0x32, 0xfb, 0x15, 0xac, 0x84,
+	0x0a, 0x52, 0x56, 0xf6, 0x2f, 0x1a, 0x39, 0xfd, 0xe0, 0x52, 0x2e, 0x17, 0xe6, 0xf3, 0xaf, 0x08,
+	0x56, 0x1d, 0x60, 0x99, 0xa6, 0x88, 0x36, 0xbc, 0x5d, 0x68, 0x2b, 0xd4, 0x65, 0x56, 0x65, 0xf2,
+	0xfe, 0xb4, 0xa0, 0xbe, 0xa8, 0x83, 0x33, 0x79, 0x03, 0xda, 0x29, 0x4b, 0x5f, 0x20, 0x77, 0xc9,
+	0x5c, 0x4a, 0xc2, 0x8e, 0x6c, 0xc1, 0xb2, 0xf6, 0xe4, 0xb7, 0x4d, 0x67, 0xc1, 0xdd, 0xb0, 0x19,
+	0xfb, 0xe1, 0x11, 0x57, 0xc3, 0x23, 0x3e, 0xaa, 0x86, 0x47, 0x32, 0x36, 0xa6, 0xab, 0x21, 0xf4,
+	0x81, 0x28, 0x0a, 0xe4, 0xf4, 0x37, 0xdf, 0x91, 0xf3, 0xa8, 0xd9, 0x24, 0xd3, 0xba, 0x0e, 0x99,
+	0x1d, 0x4f, 0x66, 0xfb, 0x58, 0x2a, 0x33, 0xeb, 0xf5, 0xf4, 0x7d, 0x0f, 0x92, 0xa0, 0x51, 0x02,
+	0x39, 0xe9, 0xc0, 0x2b, 0xcc, 0x18, 0x1c, 0x16, 0x5e, 0xfa, 0xc5, 0xa4, 0xda, 0xd2, 0x8f, 0xe1,
+	0x76, 0xb3, 0xe9, 0x30, 0xd7, 0x95, 0xe8, 0xee, 0xfe, 0xd7, 0xea, 0x93, 0x6e, 0x39, 0x0c, 0x2d,
+	0xfa, 0x2b, 0xbc, 0xd9, 0x2c, 0xe0, 0x91, 0x2b, 0x72, 0x9b, 0x20, 0x6b, 0xd5, 0xe3, 0x61, 0x8c,
+	0x85, 0x5d, 0x2d, 0xff, 0xad, 0x39, 0xf2, 0x6f, 0x27, 0xda, 0x05, 0x94, 0x67, 0x96, 0x6a, 0xa3,
+	0x3e, 0xd1, 0x42, 0xd4, 0x94, 0xd4, 0xdb, 0xea, 0x39, 0x2b, 0x35, 0xf2, 0xa6, 0x61, 0x82, 0xba,
+	0x1c, 0x22, 0xa7, 0xcf, 0xea, 0x53, 0xe1, 0x50, 0xf4, 0x73, 0x66, 0x6b, 0xe6, 0x53, 0x68, 0x6b,
+	0xb7, 0x0e, 0x54, 0xee, 0x4c, 0xa4, 0xe2, 0x5d, 0x92, 0x60, 0x4e, 0xf7, 0x61, 0xed, 0x48, 0x89,
+	0x7e, 0x1f, 0x55, 0x35, 0x5b, 0xb7, 0x1a, 0x7d, 0xf8, 0xee, 0x64, 0xc9, 0xbc, 0x5b, 0xad, 0x01,
+	0xc7, 0x58, 0xdf, 0x14, 0x7c, 0x4e, 0xac, 0xf5, 0x11, 0x56, 0xf5, 0xa7, 0xf8, 0x67, 0x04, 0x37,
+	0xc3, 0xd1, 0x53, 0xa1, 0x90, 0x93, 0xcf, 0x61, 0x45, 0xdb, 0x3e, 0x2c, 0x33, 0x57, 0xf3, 0xd1,
+	0x95, 0x35, 0x5f, 0x37, 0x27, 0x14, 0x6e, 0x8a, 0x91, 0x8e, 0x3d, 0xdf, 0xda, 0xcb, 0x49, 0xe3,
+	0x8c, 0xc4, 0x40, 0xb4, 0x2c, 0x55, 0x8a, 0xbd, 0xba, 0xe5, 0x82, 0xb3, 0xbc, 0xe0, 0x0d, 0xfd,
+	0x3b, 0x1a, 0xb1, 0x0e, 0xad, 0x3d, 0x27, 0xc9, 0x51, 0x81, 0xb5, 0xae, 0x33, 0x0a, 0xae, 0x4b,
+	0xfb, 0xf7, 0x08, 0x56, 0x13, 0x2c, 0x32, 0x91, 0xb2, 0x7d, 0x29, 0x72, 0xe4, 0xe4, 0x09, 0xac,
+	0x65, 0xc8, 0x34, 0xee, 0xfe, 0x52, 0x08, 0x85, 0x7a, 0x2a, 0xe2, 0xff, 0xf1, 0x20, 0x9f, 0xc0,
+	0xd2, 0xcf, 0x0e, 0x6d, 0xaa, 0x79, 0x34, 0xb2, 0xa5, 0x3f, 0xc0, 0x46, 0x20, 0x73, 0x60, 0x01,
+	0x13, 0xcc, 0xf1, 0xec, 0xe5, 0x50, 0xb2, 0x93, 0x6e, 0x04, 0x7d, 0x62, 0x66, 0x6b, 0xdf, 0x27,
+	0x4b, 0x3f, 0xb6, 0xfd, 0x97, 0xd6, 0x71, 0xdb, 0x5d, 0xf9, 0xf0, 0xdf, 0x01, 0x00, 0xb0, 0x32,
+	0x7d, 0x33, 0xf2, 0x0a, 0x00, 0x00,
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/api/projectors"
@@ -11,6 +12,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 )
@@ -66,6 +68,7 @@ func (ia *Invocation) Invoke(spec *types.WorkflowInvocationSpec, opts ...CallOpt
 		}
 	}
 
+	event.ExpectedGeneration = fes.ExpectNoEvents
 	err = ia.es.Append(event)
 	if err != nil {
 		return "", err
@@ -74,10 +77,46 @@ func (ia *Invocation) Invoke(spec *types.WorkflowInvocationSpec, opts ...CallOpt
 	return invocationID, nil
 }
 
+// Rerun starts a new invocation of a failed or canceled invocation, using the given workflow. The workflow is either the
+// workflow of the original invocation or a newer revision of it. The new invocation reuses the results of the tasks of
+// the original invocation that succeeded, so that only the failed tasks and their dependents are run again. It has the
+// same inputs, labels and runtime duration as the original invocation.
+// The function either returns the invocationID of the new invocation or an error.
+func (ia *Invocation) Rerun(invocation *types.WorkflowInvocation, wf *types.Workflow, opts ...CallOption) (string,
+	error) {
+	switch invocation.GetStatus().GetStatus() {
+	case types.WorkflowInvocationStatus_FAILED, types.WorkflowInvocationStatus_ABORTED:
+	default:
+		return "", fmt.Errorf("only failed or canceled invocations can be rerun, but invocation %s is %v",
+			invocation.ID(), invocation.GetStatus().GetStatus())
+	}
+	if wf == nil {
+		wf = invocation.Workflow()
+	}
+
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId: wf.ID(),
+		Workflow:   wf,
+		Inputs:     invocation.GetSpec().GetInputs(),
+		Seed:       invocation.RerunSeed(wf),
+		Labels:     invocation.GetSpec().GetLabels(),
+	}
+
+	// Give the rerun the same runtime duration as the original invocation.
+	createdAt, err := ptypes.Timestamp(invocation.GetMetadata().GetCreatedAt())
+	if err == nil {
+		if deadline, err := ptypes.Timestamp(invocation.GetSpec().GetDeadline()); err == nil {
+			spec.Deadline, _ = ptypes.TimestampProto(time.Now().Add(deadline.Sub(createdAt)))
+		}
+	}
+	return ia.Invoke(spec, opts...)
+}
+
 // Cancel halts an invocation. This does not guarantee that tasks currently running are halted,
 // but beyond the invocation will not progress any further than those tasks. The state of the invocation will
 // become ABORTED. If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Cancel(invocationID string) error {
+func (ia *Invocation) Cancel(invocationID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -92,17 +131,95 @@ func (ia *Invocation) Cancel(invocationID string) error {
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	err = ia.es.Append(event)
+	err = appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationNotFinished)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// Start starts the processing of a scheduled invocation, which turns the state of the invocation into IN_PROGRESS. If
+// an expected generation is provided, and the invocation has been started, paused or canceled in the meantime, the API
+// will return a fes.ErrConcurrentModification.
+func (ia *Invocation) Start(invocationID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationStarted{})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationScheduled)
+}
+
+// Pause pauses an invocation, which stops the controller from scheduling any new tasks of the invocation. Tasks that
+// are already running are allowed to complete and record their results. The state of the invocation will become
+// PAUSED until it is resumed. If an expected generation is provided, and the invocation has finished or has been paused
+// in the meantime, the API will return a fes.ErrConcurrentModification.
+func (ia *Invocation) Pause(invocationID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationPaused{})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationRunning)
+}
+
+// Resume resumes a paused invocation, which turns the state of the invocation back into IN_PROGRESS. If an expected
+// generation is provided, and the invocation has been resumed in the meantime, the API will return a
+// fes.ErrConcurrentModification.
+func (ia *Invocation) Resume(invocationID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationResumed{})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationPaused)
+}
+
+// Signal sends an external signal with an optional payload to an invocation, which resumes the tasks that await the
+// signal. The signal is stored in the invocation, so tasks that start awaiting it later on receive it as well. An
+// invocation receives a signal with a specific name at most once. If an expected generation is provided, and the
+// invocation has finished or received the signal in the meantime, the API will return a fes.ErrConcurrentModification.
+func (ia *Invocation) Signal(invocationID string, name string, payload *typedvalues.TypedValue,
+	opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(name) == 0 {
+		return validate.NewError("name", errors.New("signal name should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationSignaled{
+		Signal: &types.Signal{
+			Name:       name,
+			Payload:    payload,
+			ReceivedAt: ptypes.TimestampNow(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, signalNotReceived(name))
+}
+
 // Complete forces the completion of an invocation. This function - used by the controller - is the only way
 // to ensure that a workflow invocation turns into the COMPLETED state.
 // If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Complete(invocationID string, output *typedvalues.TypedValue, outputHeaders *typedvalues.TypedValue) error {
+func (ia *Invocation) Complete(invocationID string, output *typedvalues.TypedValue,
+	outputHeaders *typedvalues.TypedValue, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -116,13 +233,14 @@ func (ia *Invocation) Complete(invocationID string, output *typedvalues.TypedVal
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	return ia.es.Append(event)
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationNotFinished)
 }
 
 // Fail changes the state of the invocation to FAILED.
 // Optionally you can provide a custom error message to indicate the specific reason for the FAILED state.
 // If the API fails to append the event to the event store, it will return an error.
-func (ia *Invocation) Fail(invocationID string, errMsg error) error {
+func (ia *Invocation) Fail(invocationID string, errMsg error, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -141,13 +259,56 @@ func (ia *Invocation) Fail(invocationID string, errMsg error) error {
 		return err
 	}
 	event.Hints = &fes.EventHints{Completed: true}
-	return ia.es.Append(event)
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationNotFinished)
+}
+
+// StartCompensation starts the compensation of a failed invocation, in which the tasks are compensated in the
+// provided order. The progress of the compensation is tracked in the compensation status of the invocation.
+// If the invocation has not failed, or the compensation has already started, the API will return an error.
+func (ia *Invocation) StartCompensation(invocationID string, taskIDs []string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID),
+		&events.InvocationCompensationStarted{
+			Tasks: taskIDs,
+		})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, compensationNotStarted)
+}
+
+// CompleteCompensation finishes the compensation of a failed invocation. If one of the compensations failed, the
+// error should be provided, which turns the compensation status into FAILED.
+// If the API fails to append the event to the event store, it will return an error.
+func (ia *Invocation) CompleteCompensation(invocationID string, compensationErr error, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	var errMsg *types.Error
+	if compensationErr != nil {
+		errMsg = &types.Error{Message: compensationErr.Error()}
+	}
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationCompensated{
+		Error: errMsg,
+	})
+	if err != nil {
+		return err
+	}
+	event.Hints = &fes.EventHints{Completed: true}
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, compensationInProgress)
 }
 
 // AddTask provides functionality to add a task to a specific invocation (instead of a workflow).
 // This allows users to modify specific invocations (see dynamic API).
 // The error can be a validate.Err, proto marshall error, or a fes error.
-func (ia *Invocation) AddTask(invocationID string, task *types.Task) error {
+func (ia *Invocation) AddTask(invocationID string, task *types.Task, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -162,5 +323,5 @@ func (ia *Invocation) AddTask(invocationID string, task *types.Task) error {
 	if err != nil {
 		return err
 	}
-	return ia.es.Append(event)
+	return appendToInvocation(ia.es, invocationID, event, cfg.expectedGeneration, invocationNotFinished)
 }
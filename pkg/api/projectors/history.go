@@ -0,0 +1,69 @@
+package projectors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// ErrEventIndexOutOfRange is returned when a point-in-time read refers to an event that is not in the event stream.
+var ErrEventIndexOutOfRange = fes.EventStoreErr{S: "event index out of range"}
+
+// ProjectUntilIndex projects the entity as it was right after the event at the (zero-based) index in its event stream.
+func ProjectUntilIndex(backend fes.Backend, projector fes.Projector, key fes.Aggregate, index int) (fes.Entity,
+	error) {
+	events, err := getEvents(backend, key)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(events) {
+		return nil, ErrEventIndexOutOfRange.WithAggregate(&key).WithError(fmt.Errorf("index %d, but there are %d events",
+			index, len(events)))
+	}
+	return project(projector, key, events[:index+1])
+}
+
+// ProjectUntilTime projects the entity from the events in its event stream that occurred at or before t.
+func ProjectUntilTime(backend fes.Backend, projector fes.Projector, key fes.Aggregate, t time.Time) (fes.Entity,
+	error) {
+	events, err := getEvents(backend, key)
+	if err != nil {
+		return nil, err
+	}
+	var n int
+	for _, event := range events {
+		ts, err := ptypes.Timestamp(event.GetTimestamp())
+		if err != nil {
+			return nil, err
+		}
+		if ts.After(t) {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return nil, fes.ErrEntityNotFound.WithAggregate(&key).WithError(fmt.Errorf("no events at or before %v", t))
+	}
+	return project(projector, key, events[:n])
+}
+
+func getEvents(backend fes.Backend, key fes.Aggregate) ([]*fes.Event, error) {
+	events, err := backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fes.ErrEntityNotFound.WithAggregate(&key)
+	}
+	return events, nil
+}
+
+func project(projector fes.Projector, key fes.Aggregate, events []*fes.Event) (fes.Entity, error) {
+	base, err := projector.NewProjection(key)
+	if err != nil {
+		return nil, err
+	}
+	return projector.Project(base, events...)
+}
@@ -0,0 +1,76 @@
+package projectors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupInvocation appends an invocation with the given number of tasks, in which the events are a minute apart.
+func setupInvocation(t *testing.T, key fes.Aggregate, tasks int, start time.Time) fes.Backend {
+	backend := mem.NewBackend()
+	payloads := []proto.Message{
+		&events.InvocationCreated{
+			Spec: types.NewWorkflowInvocationSpec("wf-1", start.Add(time.Hour)),
+		},
+	}
+	for i := 0; i < tasks; i++ {
+		payloads = append(payloads, &events.InvocationTaskAdded{
+			Task: types.NewTask(fmt.Sprintf("task-%d", i), "noop"),
+		})
+	}
+	for i, payload := range payloads {
+		event, err := fes.NewEvent(key, payload)
+		assert.NoError(t, err)
+		event.Timestamp, err = ptypes.TimestampProto(start.Add(time.Duration(i) * time.Minute))
+		assert.NoError(t, err)
+		assert.NoError(t, backend.Append(event))
+	}
+	return backend
+}
+
+func TestProjectUntilIndex(t *testing.T) {
+	key := NewInvocationAggregate("wi-1")
+	backend := setupInvocation(t, key, 3, time.Now())
+
+	entity, err := ProjectUntilIndex(backend, NewWorkflowInvocation(), key, 0)
+	assert.NoError(t, err)
+	wfi := entity.(*types.WorkflowInvocation)
+	assert.Equal(t, "wf-1", wfi.GetSpec().GetWorkflowId())
+	assert.Len(t, wfi.GetStatus().GetDynamicTasks(), 0)
+
+	entity, err = ProjectUntilIndex(backend, NewWorkflowInvocation(), key, 2)
+	assert.NoError(t, err)
+	assert.Len(t, entity.(*types.WorkflowInvocation).GetStatus().GetDynamicTasks(), 2)
+
+	_, err = ProjectUntilIndex(backend, NewWorkflowInvocation(), key, 4)
+	assert.True(t, ErrEventIndexOutOfRange.Is(err), "unexpected error: %v", err)
+
+	_, err = ProjectUntilIndex(backend, NewWorkflowInvocation(), NewInvocationAggregate("missing"), 0)
+	assert.True(t, fes.ErrEntityNotFound.Is(err), "unexpected error: %v", err)
+}
+
+func TestProjectUntilTime(t *testing.T) {
+	key := NewInvocationAggregate("wi-1")
+	start := time.Now()
+	backend := setupInvocation(t, key, 3, start)
+
+	entity, err := ProjectUntilTime(backend, NewWorkflowInvocation(), key, start.Add(90*time.Second))
+	assert.NoError(t, err)
+	assert.Len(t, entity.(*types.WorkflowInvocation).GetStatus().GetDynamicTasks(), 1)
+
+	entity, err = ProjectUntilTime(backend, NewWorkflowInvocation(), key, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, entity.(*types.WorkflowInvocation).GetStatus().GetDynamicTasks(), 3)
+
+	_, err = ProjectUntilTime(backend, NewWorkflowInvocation(), key, start.Add(-time.Second))
+	assert.True(t, fes.ErrEntityNotFound.Is(err), "unexpected error: %v", err)
+}
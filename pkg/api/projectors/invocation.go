@@ -6,6 +6,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 )
 
@@ -43,8 +44,14 @@ func (i *WorkflowInvocation) Project(base fes.Entity, events ...*fes.Event) (upd
 
 func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Event) error {
 	// If the event is a task event, use the Task Aggregate to resolve it.
+	// Task events are part of the event stream of the invocation, so they also increment the generation.
 	if event.Aggregate.Type == types.TypeTaskRun {
-		return i.applyTaskEvent(wi, event)
+		if err := i.applyTaskEvent(wi, event); err != nil {
+			return err
+		}
+		startImplicitly(wi, event)
+		wi.Metadata.Generation++
+		return nil
 	}
 
 	if err := i.ensureNextEvent(event); err != nil {
@@ -63,10 +70,17 @@ func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Ev
 		}
 		wi.Spec = m.GetSpec()
 		wi.Status = &types.WorkflowInvocationStatus{
-			Status:       types.WorkflowInvocationStatus_IN_PROGRESS,
+			Status:       types.WorkflowInvocationStatus_SCHEDULED,
 			Tasks:        map[string]*types.TaskInvocation{},
 			DynamicTasks: map[string]*types.Task{},
 		}
+		// Start with the task results that are reused from a previous invocation, if any.
+		for id, task := range m.GetSpec().GetSeed().GetTasks() {
+			wi.Status.Tasks[id] = task.Copy()
+		}
+		for id, task := range m.GetSpec().GetSeed().GetDynamicTasks() {
+			wi.Status.DynamicTasks[id] = proto.Clone(task).(*types.Task)
+		}
 	case *events.InvocationCanceled:
 		wi.Status.Status = types.WorkflowInvocationStatus_ABORTED
 		wi.Status.Error = m.GetError()
@@ -80,9 +94,53 @@ func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Ev
 			wi.Status.DynamicTasks = map[string]*types.Task{}
 		}
 		wi.Status.DynamicTasks[task.ID()] = task
+		startImplicitly(wi, event)
 	case *events.InvocationFailed:
 		wi.Status.Error = m.GetError()
 		wi.Status.Status = types.WorkflowInvocationStatus_FAILED
+	case *events.InvocationStarted:
+		wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		wi.Status.StartedAt = event.GetTimestamp()
+	case *events.InvocationPaused:
+		wi.Status.Status = types.WorkflowInvocationStatus_PAUSED
+	case *events.InvocationResumed:
+		// An invocation that was paused before it started has to wait for its turn again.
+		if wi.Status.StartedAt == nil {
+			wi.Status.Status = types.WorkflowInvocationStatus_SCHEDULED
+		} else {
+			wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		}
+	case *events.InvocationSignaled:
+		if wi.Status.Signals == nil {
+			wi.Status.Signals = map[string]*types.Signal{}
+		}
+		wi.Status.Signals[m.GetSignal().GetName()] = m.GetSignal()
+	case *events.InvocationCompensationStarted:
+		wi.Status.Compensation = &types.CompensationStatus{
+			Status:    types.CompensationStatus_IN_PROGRESS,
+			UpdatedAt: event.GetTimestamp(),
+			Order:     m.GetTasks(),
+			Tasks:     map[string]*types.TaskInvocationStatus{},
+		}
+	case *events.InvocationTaskCompensated:
+		if wi.Status.Compensation == nil {
+			return fmt.Errorf("compensation of task %s before the start of the compensation", m.GetTaskId())
+		}
+		if wi.Status.Compensation.Tasks == nil {
+			wi.Status.Compensation.Tasks = map[string]*types.TaskInvocationStatus{}
+		}
+		wi.Status.Compensation.Tasks[m.GetTaskId()] = m.GetResult()
+		wi.Status.Compensation.UpdatedAt = event.GetTimestamp()
+	case *events.InvocationCompensated:
+		if wi.Status.Compensation == nil {
+			wi.Status.Compensation = &types.CompensationStatus{}
+		}
+		wi.Status.Compensation.Status = types.CompensationStatus_SUCCEEDED
+		if m.GetError() != nil {
+			wi.Status.Compensation.Status = types.CompensationStatus_FAILED
+			wi.Status.Compensation.Error = m.GetError()
+		}
+		wi.Status.Compensation.UpdatedAt = event.GetTimestamp()
 	default:
 		//key := wi.Aggregate()
 		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
@@ -92,6 +150,17 @@ func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Ev
 	return err
 }
 
+// startImplicitly marks a scheduled invocation as started at the time of the event. Event streams that were recorded
+// before invocations had to be started explicitly lack the InvocationStarted event; their invocations are considered to
+// have started once they made progress, such as running a task.
+func startImplicitly(wi *types.WorkflowInvocation, event *fes.Event) {
+	if wi.GetStatus().GetStatus() != types.WorkflowInvocationStatus_SCHEDULED {
+		return
+	}
+	wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+	wi.Status.StartedAt = event.GetTimestamp()
+}
+
 func (i *WorkflowInvocation) NewProjection(key fes.Aggregate) (fes.Entity, error) {
 	if key.Type != types.TypeInvocation {
 		return nil, fes.ErrInvalidAggregate.WithAggregate(&key)
@@ -0,0 +1,60 @@
+package projectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowInvocation_ProjectStart(t *testing.T) {
+	key := NewInvocationAggregate("wi-1")
+	created := newInvocationEvent(t, key, &events.InvocationCreated{
+		Spec: types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Hour)),
+	})
+	started := newInvocationEvent(t, key, &events.InvocationStarted{})
+	taskStarted := newInvocationEvent(t, NewTaskRunAggregate("task-1"), &events.TaskStarted{
+		Spec: &types.TaskInvocationSpec{
+			TaskId:       "task-1",
+			InvocationId: key.Id,
+		},
+	})
+	taskStarted.Parent = &key
+
+	// A new invocation is scheduled until it has been started explicitly.
+	wi := projectInvocation(t, created)
+	assert.Equal(t, types.WorkflowInvocationStatus_SCHEDULED, wi.GetStatus().GetStatus())
+	wi = projectInvocation(t, created, started, taskStarted)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, wi.GetStatus().GetStatus())
+	assert.Equal(t, started.GetTimestamp(), wi.GetStatus().GetStartedAt())
+
+	// Event streams recorded before invocations had to be started explicitly are started by their first progress.
+	wi = projectInvocation(t, created, taskStarted)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, wi.GetStatus().GetStatus())
+	assert.Equal(t, taskStarted.GetTimestamp(), wi.GetStatus().GetStartedAt())
+	taskAdded := newInvocationEvent(t, key, &events.InvocationTaskAdded{
+		Task: types.NewTask("task-2", "noop"),
+	})
+	wi = projectInvocation(t, created, taskAdded)
+	assert.Equal(t, types.WorkflowInvocationStatus_IN_PROGRESS, wi.GetStatus().GetStatus())
+}
+
+func newInvocationEvent(t *testing.T, key fes.Aggregate, payload proto.Message) *fes.Event {
+	event, err := fes.NewEvent(key, payload)
+	assert.NoError(t, err)
+	event.Timestamp, err = ptypes.TimestampProto(time.Now())
+	assert.NoError(t, err)
+	return event
+}
+
+func projectInvocation(t *testing.T, events ...*fes.Event) *types.WorkflowInvocation {
+	projector := NewWorkflowInvocation()
+	entity, err := projector.Project(nil, events...)
+	assert.NoError(t, err)
+	return entity.(*types.WorkflowInvocation)
+}
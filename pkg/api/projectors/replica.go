@@ -0,0 +1,111 @@
+package projectors
+
+import (
+	"fmt"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+)
+
+type Replica struct {
+}
+
+func NewReplica() *Replica {
+	return &Replica{}
+}
+
+func (r *Replica) Project(base fes.Entity, events ...*fes.Event) (updated fes.Entity, err error) {
+	var replica *types.Replica
+	if base == nil {
+		replica = &types.Replica{}
+	} else {
+		var ok bool
+		replica, ok = base.(*types.Replica)
+		if !ok {
+			return nil, fmt.Errorf("entity expected replica, but was %T", base)
+		}
+		replica = replica.Copy()
+	}
+
+	for _, event := range events {
+		err := r.project(replica, event)
+		if err != nil {
+			return replica, err
+		}
+	}
+	return replica, nil
+}
+
+func (r *Replica) project(replica *types.Replica, event *fes.Event) error {
+	if err := r.ensureValidEvent(event); err != nil {
+		return err
+	}
+
+	eventData, err := fes.ParseEventData(event)
+	if err != nil {
+		return err
+	}
+
+	switch m := eventData.(type) {
+	case *events.ReplicaJoined:
+		// A replica that restarts with the same id rejoins in the existing event stream.
+		if replica.GetMetadata().GetGeneration() == 0 {
+			replica.Metadata = &types.ObjectMetadata{
+				Id:        replica.GetMetadata().GetId(),
+				CreatedAt: event.GetTimestamp(),
+			}
+		}
+		joinedAt := event.GetTimestamp()
+		if m.GetJoinedAt() != nil {
+			joinedAt = m.GetJoinedAt()
+		}
+		replica.Status = &types.ReplicaStatus{
+			Status:         types.ReplicaStatus_ACTIVE,
+			JoinedAt:       joinedAt,
+			LeaseExpiresAt: m.GetLeaseExpiresAt(),
+		}
+	case *events.ReplicaLeaseRenewed:
+		replica.Status.LeaseExpiresAt = m.GetLeaseExpiresAt()
+	case *events.ReplicaLeft:
+		replica.Status.Status = types.ReplicaStatus_LEFT
+		replica.Status.Error = m.GetError()
+	default:
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	replica.Metadata.Generation++
+	replica.Status.UpdatedAt = event.GetTimestamp()
+	return nil
+}
+
+func (r *Replica) ensureValidEvent(event *fes.Event) error {
+	if err := fes.ValidateEvent(event); err != nil {
+		return err
+	}
+
+	if event.Aggregate.Type != types.TypeReplica {
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	return nil
+}
+
+func (r *Replica) NewProjection(key fes.Aggregate) (fes.Entity, error) {
+	if key.Type != types.TypeReplica {
+		return nil, fes.ErrInvalidAggregate.WithAggregate(&key)
+	}
+	return &types.Replica{
+		Metadata: &types.ObjectMetadata{
+			Id:        key.Id,
+			CreatedAt: ptypes.TimestampNow(),
+		},
+		Status: &types.ReplicaStatus{},
+	}, nil
+}
+
+func NewReplicaAggregate(id string) fes.Aggregate {
+	return fes.Aggregate{
+		Id:   id,
+		Type: types.TypeReplica,
+	}
+}
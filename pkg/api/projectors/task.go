@@ -62,9 +62,25 @@ func (t *TaskRun) project(taskRun *types.TaskInvocation, event *fes.Event) error
 		taskRun.Status.Output = m.GetResult().Output
 		taskRun.Status.OutputHeaders = m.GetResult().OutputHeaders
 		taskRun.Status.Status = types.TaskInvocationStatus_SUCCEEDED
+		taskRun.Status.Cached = m.GetCached()
+		taskRun.Status.StartedAt = m.GetStartedAt()
 	case *events.TaskFailed:
 		taskRun.Status.Error = m.GetError()
 		taskRun.Status.Status = types.TaskInvocationStatus_FAILED
+		taskRun.Status.StartedAt = m.GetStartedAt()
+	case *events.TaskRetried:
+		// Archive the failed attempt, and reset the status for the new attempt.
+		attempts := append(taskRun.Status.GetAttempts(), &types.TaskAttempt{
+			FailedAt: taskRun.Status.GetUpdatedAt(),
+			Error:    taskRun.Status.GetError(),
+		})
+		taskRun.Status = &types.TaskInvocationStatus{
+			Status:   types.TaskInvocationStatus_IN_PROGRESS,
+			Attempts: attempts,
+		}
+	case *events.TaskAborted:
+		taskRun.Status.Error = m.GetError()
+		taskRun.Status.Status = types.TaskInvocationStatus_ABORTED
 	case *events.TaskSkipped:
 		// TODO ensure that object (spec/status) is present
 		taskRun.Status.Status = types.TaskInvocationStatus_SKIPPED
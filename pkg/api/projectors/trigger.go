@@ -0,0 +1,144 @@
+package projectors
+
+import (
+	"fmt"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+type Trigger struct {
+}
+
+func NewTrigger() *Trigger {
+	return &Trigger{}
+}
+
+func (t *Trigger) Project(base fes.Entity, events ...*fes.Event) (updated fes.Entity, err error) {
+	var trigger *types.Trigger
+	if base == nil {
+		trigger = &types.Trigger{}
+	} else {
+		var ok bool
+		trigger, ok = base.(*types.Trigger)
+		if !ok {
+			return nil, fmt.Errorf("entity expected trigger, but was %T", base)
+		}
+		trigger = trigger.Copy()
+	}
+
+	for _, event := range events {
+		err := t.project(trigger, event)
+		if err != nil {
+			return trigger, err
+		}
+	}
+	return trigger, nil
+}
+
+func (t *Trigger) project(trigger *types.Trigger, event *fes.Event) error {
+	if err := t.ensureValidEvent(event); err != nil {
+		return err
+	}
+
+	eventData, err := fes.ParseEventData(event)
+	if err != nil {
+		return err
+	}
+
+	switch m := eventData.(type) {
+	case *events.TriggerCreated:
+		trigger.Metadata = &types.ObjectMetadata{
+			Id:        trigger.GetMetadata().GetId(),
+			Name:      m.GetSpec().GetName(),
+			CreatedAt: event.GetTimestamp(),
+		}
+		trigger.Spec = m.GetSpec()
+		trigger.Status = &types.TriggerStatus{
+			Status: types.TriggerStatus_ACTIVE,
+		}
+	case *events.TriggerUpdated:
+		trigger.Metadata.Name = m.GetSpec().GetName()
+		trigger.Spec = m.GetSpec()
+	case *events.TriggerDeleted:
+		trigger.Status.Status = types.TriggerStatus_DELETED
+	case *events.TriggerFired:
+		trigger.Status.LastScheduledAt = m.GetScheduledAt()
+		trigger.Status.LastInvocationId = m.GetInvocationId()
+		trigger.Status.Fired++
+		trigger.Status.Error = nil
+		if err := t.complete(trigger, m.GetSourceInvocationId(), event); err != nil {
+			return err
+		}
+	case *events.TriggerSkipped:
+		trigger.Status.LastScheduledAt = m.GetScheduledAt()
+		trigger.Status.Skipped++
+		trigger.Status.Error = m.GetError()
+		if err := t.complete(trigger, m.GetSourceInvocationId(), event); err != nil {
+			return err
+		}
+	default:
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	trigger.Metadata.Generation++
+	trigger.Status.UpdatedAt = event.GetTimestamp()
+	return nil
+}
+
+// complete records that the finished invocation has been handled by the completion trigger. To bound the size of the
+// trigger, handled invocations are forgotten after types.TriggerCompletionRetention, relative to the time of the event
+// so that the projection does not depend on when it is computed.
+func (t *Trigger) complete(trigger *types.Trigger, invocationID string, event *fes.Event) error {
+	if len(invocationID) == 0 {
+		return nil
+	}
+	ts, err := ptypes.Timestamp(event.GetTimestamp())
+	if err != nil {
+		return err
+	}
+	if trigger.Status.Completions == nil {
+		trigger.Status.Completions = map[string]*timestamp.Timestamp{}
+	}
+	for id, handledAt := range trigger.Status.Completions {
+		if handled, err := ptypes.Timestamp(handledAt); err != nil || ts.Sub(handled) > types.TriggerCompletionRetention {
+			delete(trigger.Status.Completions, id)
+		}
+	}
+	trigger.Status.Completions[invocationID] = event.GetTimestamp()
+	return nil
+}
+
+func (t *Trigger) ensureValidEvent(event *fes.Event) error {
+	if err := fes.ValidateEvent(event); err != nil {
+		return err
+	}
+
+	if event.Aggregate.Type != types.TypeTrigger {
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	return nil
+}
+
+func (t *Trigger) NewProjection(key fes.Aggregate) (fes.Entity, error) {
+	if key.Type != types.TypeTrigger {
+		return nil, fes.ErrInvalidAggregate.WithAggregate(&key)
+	}
+	return &types.Trigger{
+		Metadata: &types.ObjectMetadata{
+			Id:        key.Id,
+			CreatedAt: ptypes.TimestampNow(),
+		},
+		Spec:   &types.TriggerSpec{},
+		Status: &types.TriggerStatus{},
+	}, nil
+}
+
+func NewTriggerAggregate(id string) fes.Aggregate {
+	return fes.Aggregate{
+		Id:   id,
+		Type: types.TypeTrigger,
+	}
+}
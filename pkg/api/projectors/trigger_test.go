@@ -0,0 +1,50 @@
+package projectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrigger_ProjectCompletions(t *testing.T) {
+	key := NewTriggerAggregate("tr-1")
+	created := newInvocationEvent(t, key, &events.TriggerCreated{
+		Spec: &types.TriggerSpec{Completion: &types.CompletionTrigger{WorkflowId: "wf-1"}},
+	})
+	fired := newInvocationEvent(t, key, &events.TriggerFired{
+		ScheduledAt:        util.MustTimestampProto(time.Now()),
+		InvocationId:       "wi-2",
+		SourceInvocationId: "wi-1",
+	})
+	skipped := newInvocationEvent(t, key, &events.TriggerSkipped{
+		ScheduledAt:        util.MustTimestampProto(time.Now()),
+		SourceInvocationId: "wi-3",
+	})
+
+	trigger := projectTrigger(t, created, fired, skipped)
+	assert.True(t, trigger.GetStatus().Completed("wi-1"))
+	assert.True(t, trigger.GetStatus().Completed("wi-3"))
+	assert.False(t, trigger.GetStatus().Completed("wi-2"))
+
+	// Handled invocations are forgotten once they are older than the retention.
+	late := newInvocationEvent(t, key, &events.TriggerFired{
+		ScheduledAt:        util.MustTimestampProto(time.Now()),
+		InvocationId:       "wi-5",
+		SourceInvocationId: "wi-4",
+	})
+	late.Timestamp = util.MustTimestampProto(time.Now().Add(types.TriggerCompletionRetention + time.Minute))
+	trigger = projectTrigger(t, created, fired, skipped, late)
+	assert.False(t, trigger.GetStatus().Completed("wi-1"))
+	assert.True(t, trigger.GetStatus().Completed("wi-4"))
+}
+
+func projectTrigger(t *testing.T, events ...*fes.Event) *types.Trigger {
+	entity, err := NewTrigger().Project(nil, events...)
+	assert.NoError(t, err)
+	return entity.(*types.Trigger)
+}
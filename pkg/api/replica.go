@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Replica contains the API functionality for tracking the replicas of the workflow engine that share the event store.
+// This includes joining, renewing the lease of, and leaving the cluster of replicas.
+type Replica struct {
+	es fes.Backend
+}
+
+// NewReplicaAPI creates the Replica API.
+func NewReplicaAPI(esClient fes.Backend) *Replica {
+	return &Replica{esClient}
+}
+
+// Join marks the replica as active, with a lease that expires at the provided time. A replica that left before can
+// join again.
+func (ra *Replica) Join(replicaID string, leaseExpiresAt time.Time, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(replicaID) == 0 {
+		return validate.NewError("replicaID", errors.New("id should not be empty"))
+	}
+	ts, err := ptypes.TimestampProto(leaseExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	event, err := fes.NewEvent(projectors.NewReplicaAggregate(replicaID), &events.ReplicaJoined{
+		LeaseExpiresAt: ts,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ra.es.Append(event)
+}
+
+// Compact replaces the event stream of the replica with a single event, in which the replica joins with the provided
+// join time and lease. This bounds the event stream of a replica, which otherwise grows with every renewal of its
+// lease. It requires a backend that implements fes.Replacer, which replaces the event stream in a single operation.
+func (ra *Replica) Compact(replicaID string, joinedAt time.Time, leaseExpiresAt time.Time) error {
+	if len(replicaID) == 0 {
+		return validate.NewError("replicaID", errors.New("id should not be empty"))
+	}
+	replacer, ok := ra.es.(fes.Replacer)
+	if !ok {
+		return fmt.Errorf("event store %T does not support the compaction of replicas", ra.es)
+	}
+	joinedAtTs, err := ptypes.TimestampProto(joinedAt)
+	if err != nil {
+		return err
+	}
+	leaseExpiresAtTs, err := ptypes.TimestampProto(leaseExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	event, err := fes.NewEvent(projectors.NewReplicaAggregate(replicaID), &events.ReplicaJoined{
+		LeaseExpiresAt: leaseExpiresAtTs,
+		JoinedAt:       joinedAtTs,
+	})
+	if err != nil {
+		return err
+	}
+	return replacer.Replace(*event.Aggregate, event)
+}
+
+// Renew extends the lease of the replica until the provided time.
+func (ra *Replica) Renew(replicaID string, leaseExpiresAt time.Time, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(replicaID) == 0 {
+		return validate.NewError("replicaID", errors.New("id should not be empty"))
+	}
+	ts, err := ptypes.TimestampProto(leaseExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	event, err := fes.NewEvent(projectors.NewReplicaAggregate(replicaID), &events.ReplicaLeaseRenewed{
+		LeaseExpiresAt: ts,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ra.es.Append(event)
+}
+
+// Leave marks the replica as no longer active. The reason is nil if the replica left by itself.
+// If an expected generation is provided, and the replica has been modified in the meantime, for example because it
+// renewed its lease, the API will return a fes.ErrConcurrentModification.
+func (ra *Replica) Leave(replicaID string, reason error, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(replicaID) == 0 {
+		return validate.NewError("replicaID", errors.New("id should not be empty"))
+	}
+
+	var leaveErr *types.Error
+	if reason != nil {
+		leaveErr = &types.Error{Message: reason.Error()}
+	}
+	event, err := fes.NewEvent(projectors.NewReplicaAggregate(replicaID), &events.ReplicaLeft{
+		Error: leaveErr,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ra.es.Append(event)
+}
@@ -1,4 +1,4 @@
-// package store provides typed, centralized access to the event-sourced workflow and invocation models
+// package store provides typed, centralized access to the event-sourced workflow, invocation and trigger models
 package store
 
 import (
@@ -122,6 +122,76 @@ func (s *Invocations) GetInvocationUpdates() *InvocationSubscription {
 	return sub
 }
 
+type Triggers struct {
+	fes.CacheReader
+}
+
+func NewTriggerStore(triggers fes.CacheReader) *Triggers {
+	return &Triggers{
+		triggers,
+	}
+}
+
+// GetTrigger returns an event-sourced trigger.
+// If an error occurred the error is returned, if no trigger was found both return values are nil.
+func (s *Triggers) GetTrigger(triggerID string) (*types.Trigger, error) {
+	key := fes.Aggregate{Type: types.TypeTrigger, Id: triggerID}
+	entity, err := s.GetAggregate(key)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	trigger, ok := entity.(*types.Trigger)
+	if !ok {
+		panic(fmt.Sprintf("aggregate type mismatch for key %s (expected: %T, got %T)", key.Format(),
+			&types.Trigger{}, entity))
+	}
+
+	return trigger, nil
+}
+
+type Replicas struct {
+	fes.CacheReader
+}
+
+func NewReplicaStore(replicas fes.CacheReader) *Replicas {
+	return &Replicas{
+		replicas,
+	}
+}
+
+// GetReplica returns an event-sourced replica.
+// If an error occurred the error is returned, if no replica was found both return values are nil.
+func (s *Replicas) GetReplica(replicaID string) (*types.Replica, error) {
+	key := fes.Aggregate{Type: types.TypeReplica, Id: replicaID}
+	entity, err := s.GetAggregate(key)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	replica, ok := entity.(*types.Replica)
+	if !ok {
+		panic(fmt.Sprintf("aggregate type mismatch for key %s (expected: %T, got %T)", key.Format(),
+			&types.Replica{}, entity))
+	}
+
+	return replica, nil
+}
+
+// Invalidate evicts the replica from the cache, if the cache supports it, such that it is loaded from the event store
+// the next time that it is accessed.
+func (s *Replicas) Invalidate(replicaID string) {
+	if writer, ok := s.CacheReader.(fes.CacheWriter); ok {
+		writer.Invalidate(fes.Aggregate{Type: types.TypeReplica, Id: replicaID})
+	}
+}
+
 type WorkflowSubscription struct {
 	*pubsub.Subscription
 	closeFn func() error
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/sirupsen/logrus"
+)
+
+// WatchPollInterval is the interval at which a watch checks the cache for updates.
+//
+// If the cache supports pubsub, updates are received as soon as they are published, and polling only serves to catch
+// up on notifications that were dropped because the subscription buffer was full.
+var WatchPollInterval = time.Second
+
+// WatchWorkflow sends the current state of the workflow to the returned channel, followed by every subsequent update
+// to it. The channel is closed once the workflow has been deleted or the context is done.
+//
+// It returns an error if the workflow could not be found.
+func (s *Workflows) WatchWorkflow(ctx context.Context, workflowID string) (<-chan *types.Workflow, error) {
+	key := fes.Aggregate{Type: types.TypeWorkflow, Id: workflowID}
+	matcher := labels.And(
+		labels.In(fes.PubSubLabelAggregateType, types.TypeWorkflow),
+		labels.In(fes.PubSubLabelAggregateID, workflowID))
+
+	updates := make(chan *types.Workflow)
+	err := watch(ctx, s.CacheReader, key, matcher, func(entity fes.Entity) bool {
+		wf := entity.(*types.Workflow)
+		select {
+		case updates <- wf:
+		case <-ctx.Done():
+			return true
+		}
+		return wf.GetStatus().GetStatus() == types.WorkflowStatus_DELETED
+	}, func() {
+		close(updates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// WatchInvocation sends the current state of the invocation to the returned channel, followed by every subsequent
+// update to it, including updates to its tasks. The channel is closed once the invocation has finished, including the
+// compensation of a failed invocation, or the context is done.
+//
+// It returns an error if the invocation could not be found.
+func (s *Invocations) WatchInvocation(ctx context.Context, invocationID string) (<-chan *types.WorkflowInvocation,
+	error) {
+	key := fes.Aggregate{Type: types.TypeInvocation, Id: invocationID}
+	matcher := labels.Or(
+		labels.And(
+			labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation),
+			labels.In(fes.PubSubLabelAggregateID, invocationID)),
+		labels.And(
+			labels.In("parent.type", types.TypeInvocation),
+			labels.In("parent.id", invocationID)))
+
+	updates := make(chan *types.WorkflowInvocation)
+	err := watch(ctx, s.CacheReader, key, matcher, func(entity fes.Entity) bool {
+		wfi := entity.(*types.WorkflowInvocation)
+		select {
+		case updates <- wfi:
+		case <-ctx.Done():
+			return true
+		}
+		return wfi.GetStatus().Finished() && !wfi.CompensationPending()
+	}, func() {
+		close(updates)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// watch passes the entity to fn every time that it has been updated, until fn returns true or the context is done,
+// after which it calls closeFn. The current state of the entity is fetched before watch returns, to ensure that the
+// entity exists.
+//
+// Updates are received through a subscription if the cache supports pubsub. Regardless, the cache is also polled at
+// the WatchPollInterval to ensure that the final update is not missed. Updates are only passed to fn if they are
+// newer than the last update, based on the generation of the entity.
+func watch(ctx context.Context, cache fes.CacheReader, key fes.Aggregate, matcher labels.Matcher,
+	fn func(entity fes.Entity) (done bool), closeFn func()) error {
+	// Subscribe before fetching the current state, to avoid missing updates in between.
+	var sub *pubsub.Subscription
+	pub, ok := cache.(pubsub.Publisher)
+	if ok {
+		sub = pub.Subscribe(pubsub.SubscriptionOptions{
+			Buffer:       fes.DefaultNotificationBuffer,
+			LabelMatcher: matcher,
+		})
+	}
+	unsubscribe := func() {
+		if sub != nil {
+			if err := pub.Unsubscribe(sub); err != nil {
+				logrus.Warnf("Failed to unsubscribe watch of %v: %v", key.Format(), err)
+			}
+		}
+	}
+
+	current, err := cache.GetAggregate(key)
+	if err == nil && current == nil {
+		err = fes.ErrEntityNotFound.WithAggregate(&key)
+	}
+	if err != nil {
+		unsubscribe()
+		return err
+	}
+
+	go func() {
+		defer closeFn()
+		defer unsubscribe()
+
+		var generation int64 = -1
+		update := func(entity fes.Entity) bool {
+			if e, ok := entity.(fes.SequencedEntity); ok {
+				if e.Generation() <= generation {
+					return false
+				}
+				generation = e.Generation()
+			}
+			return fn(entity)
+		}
+		if update(current) {
+			return
+		}
+
+		var notifications chan pubsub.Msg
+		if sub != nil {
+			notifications = sub.Ch
+		}
+		ticker := time.NewTicker(WatchPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-notifications:
+				if !ok {
+					// The publisher has been closed, which also ended the subscription; continue by polling.
+					notifications = nil
+					sub = nil
+					continue
+				}
+				n, isNotification := msg.(*fes.Notification)
+				if !isNotification {
+					continue
+				}
+				if update(n.Updated) {
+					return
+				}
+			case <-ticker.C:
+				entity, err := cache.GetAggregate(key)
+				if err != nil || entity == nil {
+					logrus.Debugf("Watch failed to fetch %v: %v", key.Format(), err)
+					continue
+				}
+				if update(entity) {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fes/cache"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupInvocations(t *testing.T) (*Invocations, *api.Invocation) {
+	backend := mem.NewBackend()
+	projector := projectors.NewWorkflowInvocation()
+	c := cache.NewSubscribedCache(cache.NewLoadingCache(cache.NewLRUCache(10), backend, projector), projector,
+		backend.Subscribe())
+	return NewInvocationStore(c), api.NewInvocationAPI(backend)
+}
+
+func invoke(t *testing.T, invocationAPI *api.Invocation) string {
+	spec := types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Minute))
+	spec.Workflow = &types.Workflow{
+		Metadata: types.NewObjectMetadata("wf-1"),
+		Spec:     types.NewWorkflowSpec(),
+		Status:   &types.WorkflowStatus{Status: types.WorkflowStatus_READY},
+	}
+	invocationID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	return invocationID
+}
+
+func TestInvocations_WatchInvocation(t *testing.T) {
+	invocations, invocationAPI := setupInvocations(t)
+	invocationID := invoke(t, invocationAPI)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates, err := invocations.WatchInvocation(ctx, invocationID)
+	assert.NoError(t, err)
+
+	// The current state is sent first.
+	wfi := <-updates
+	assert.Equal(t, invocationID, wfi.ID())
+	assert.False(t, wfi.GetStatus().Finished())
+
+	// Updates to tasks are part of the updates of the invocation.
+	assert.NoError(t, invocationAPI.AddTask(invocationID, types.NewTask("task-1", "noop")))
+	wfi = <-updates
+	assert.Contains(t, wfi.GetStatus().GetDynamicTasks(), "task-1")
+
+	// The watch ends once the invocation has finished.
+	assert.NoError(t, invocationAPI.Complete(invocationID, typedvalues.MustWrap("done"), nil))
+	var last *types.WorkflowInvocation
+	for wfi := range updates {
+		last = wfi
+	}
+	assert.NotNil(t, last)
+	assert.True(t, last.GetStatus().Successful())
+	assert.NoError(t, ctx.Err())
+}
+
+func TestInvocations_WatchInvocationCanceled(t *testing.T) {
+	invocations, invocationAPI := setupInvocations(t)
+	invocationID := invoke(t, invocationAPI)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	updates, err := invocations.WatchInvocation(ctx, invocationID)
+	assert.NoError(t, err)
+	<-updates
+	cancel()
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func TestInvocations_WatchInvocationNotFound(t *testing.T) {
+	invocations, _ := setupInvocations(t)
+	_, err := invocations.WatchInvocation(context.Background(), "missing")
+	assert.True(t, fes.ErrEntityNotFound.Is(err), "unexpected error: %v", err)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 )
@@ -70,15 +71,21 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 		return nil, err
 	}
 
+	startedAt := ptypes.TimestampNow()
 	fnResult, err := ap.runtime[spec.FnRef.Runtime].Invoke(spec, fnenv.WithContext(cfg.ctx),
 		fnenv.AwaitWorkflow(cfg.awaitWorkflow))
+	if err == fnenv.ErrTaskParked {
+		// The task has not finished yet, and will be run again later on.
+		return nil, err
+	}
 	if fnResult == nil && err == nil {
 		err = errors.New("function crashed")
 	}
 	if err != nil {
 		// TODO improve error handling here (retries? internal or task related error?)
 		log.Infof("Failed to invoke task: %v", err)
-		esErr := ap.Fail(spec.InvocationId, taskID, err.Error())
+		esErr := ap.Fail(spec.InvocationId, taskID, err.Error(), WithExpectedGeneration(cfg.expectedGeneration),
+			WithStartedAt(startedAt))
 		if esErr != nil {
 			return nil, esErr
 		}
@@ -108,15 +115,17 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 
 	if fnResult.Status == types.TaskInvocationStatus_SUCCEEDED {
 		event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSucceeded{
-			Result: fnResult,
+			Result:    fnResult,
+			StartedAt: startedAt,
 		})
 		if err != nil {
 			return nil, err
 		}
 		event.Parent = &aggregate
-		err = ap.es.Append(event)
+		err = appendToInvocation(ap.es, spec.InvocationId, event, cfg.expectedGeneration, taskNotFinished(taskID))
 	} else {
-		err = ap.Fail(spec.InvocationId, taskID, fnResult.Error.GetMessage())
+		err = ap.Fail(spec.InvocationId, taskID, fnResult.Error.GetMessage(),
+			WithExpectedGeneration(cfg.expectedGeneration), WithStartedAt(startedAt))
 	}
 	if err != nil {
 		return nil, err
@@ -124,9 +133,56 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 	return task, nil
 }
 
+// Reuse completes a task with the result of an earlier run of the task, such as a result from a cache, instead of
+// running the function of the task. This turns the state of the task into SUCCEEDED, and marks the task as cached.
+func (ap *Task) Reuse(spec *types.TaskInvocationSpec, result *types.TaskInvocationStatus,
+	opts ...CallOption) (*types.TaskInvocation, error) {
+	cfg := parseCallOptions(opts)
+	err := validate.TaskInvocationSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Successful() {
+		return nil, fmt.Errorf("cannot reuse a result with status %v", result.GetStatus())
+	}
+
+	taskID := spec.TaskId
+	task := &types.TaskInvocation{
+		Metadata: &types.ObjectMetadata{
+			Id:        taskID,
+			CreatedAt: ptypes.TimestampNow(),
+		},
+		Spec:   spec,
+		Status: proto.Clone(result).(*types.TaskInvocationStatus),
+	}
+	task.Status.Cached = true
+	if cfg.postTransformer != nil {
+		err = cfg.postTransformer(task)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSucceeded{
+		Result: task.Status,
+		Cached: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	aggregate := projectors.NewInvocationAggregate(spec.InvocationId)
+	event.Parent = &aggregate
+	err = appendToInvocation(ap.es, spec.InvocationId, event, cfg.expectedGeneration, taskNotFinished(taskID))
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // Fail forces the failure of a task. This turns the state of a task into FAILED.
 // If the API fails to append the event to the event store, it will return an error.
-func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
+func (ap *Task) Fail(invocationID string, taskID string, errMsg string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -135,14 +191,165 @@ func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
 	}
 
 	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskFailed{
-		Error: &types.Error{Message: errMsg},
+		Error:     &types.Error{Message: errMsg},
+		StartedAt: cfg.startedAt,
 	})
 	if err != nil {
 		return err
 	}
 	aggregate := projectors.NewInvocationAggregate(invocationID)
 	event.Parent = &aggregate
-	return ap.es.Append(event)
+	return appendToInvocation(ap.es, invocationID, event, cfg.expectedGeneration, taskNotFinished(taskID))
+}
+
+// Skip skips a task, which turns the state of the task into SKIPPED without running it.
+// If the API fails to append the event to the event store, it will return an error.
+func (ap *Task) Skip(invocationID string, taskID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSkipped{})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return appendToInvocation(ap.es, invocationID, event, cfg.expectedGeneration, taskNotFinished(taskID))
+}
+
+// Abort aborts a task of which the result is no longer needed, which turns the state of the task into ABORTED. It
+// does not stop the function of the task, if it is running; the result of the function is ignored instead.
+// If the API fails to append the event to the event store, it will return an error.
+func (ap *Task) Abort(invocationID string, taskID string, reason string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskAborted{
+		Error: &types.Error{Message: reason},
+	})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return appendToInvocation(ap.es, invocationID, event, cfg.expectedGeneration, taskNotFinished(taskID))
+}
+
+// Retry starts a new attempt of a failed task, which turns the state of the task back into IN_PROGRESS. The failed
+// attempt is kept in the attempt history of the task.
+// If the task is not in a FAILED state (anymore), the API will return an error.
+func (ap *Task) Retry(invocationID string, taskID string, attempt int, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskRetried{
+		Attempt: int32(attempt),
+	})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return appendToInvocation(ap.es, invocationID, event, cfg.expectedGeneration, taskFailed(taskID, attempt))
+}
+
+// Fallback adds the fallback of the error handler of a failed task to the invocation. The fallback is added as a
+// dynamic task, which takes the place of the failed task.
+func (ap *Task) Fallback(invocationID string, task *types.Task) error {
+	handler := task.GetSpec().GetOnError()
+	var flow *controlflow.Flow
+	switch {
+	case handler.GetFallbackTask() != nil:
+		flow = controlflow.FlowTask(handler.GetFallbackTask())
+	case handler.GetFallbackWorkflow() != nil:
+		flow = controlflow.FlowWorkflow(handler.GetFallbackWorkflow())
+	default:
+		return fmt.Errorf("task %s does not have a fallback", task.ID())
+	}
+	return ap.dynamicAPI.AddDynamicFlow(invocationID, task.ID(), *flow.Clone())
+}
+
+// Compensate runs the compensation of a task of a failed invocation, using the spec of the compensation. The result of
+// the compensation is recorded in the compensation status of the invocation, regardless of whether it succeeded.
+// It only returns an error if the result could not be recorded.
+func (ap *Task) Compensate(spec *types.TaskInvocationSpec, opts ...CallOption) (*types.TaskInvocationStatus, error) {
+	log := logrus.WithField("fn", spec.FnRef).WithField("wi", spec.InvocationId).WithField("task", spec.TaskId)
+	cfg := parseCallOptions(opts)
+	err := validate.TaskInvocationSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *types.TaskInvocationStatus
+	runtime, ok := ap.runtime[spec.GetFnRef().GetRuntime()]
+	if ok {
+		result, err = runtime.Invoke(spec, fnenv.WithContext(cfg.ctx), fnenv.AwaitWorkflow(cfg.awaitWorkflow))
+	} else {
+		err = fmt.Errorf("could not find runtime for %s", spec.GetFnRef().Format())
+	}
+	if result == nil && err == nil {
+		err = errors.New("function crashed")
+	}
+	if err != nil {
+		log.Infof("Failed to compensate task: %v", err)
+		result = &types.TaskInvocationStatus{
+			Status: types.TaskInvocationStatus_FAILED,
+			Error:  &types.Error{Message: err.Error()},
+		}
+	}
+	result.UpdatedAt = ptypes.TimestampNow()
+
+	err = ap.appendCompensation(spec.InvocationId, spec.TaskId, result, cfg.expectedGeneration)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FailCompensation forces the failure of the compensation of a task, such as when the compensation could not be
+// started. If the API fails to append the event to the event store, it will return an error.
+func (ap *Task) FailCompensation(invocationID string, taskID string, errMsg string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	return ap.appendCompensation(invocationID, taskID, &types.TaskInvocationStatus{
+		Status:    types.TaskInvocationStatus_FAILED,
+		UpdatedAt: ptypes.TimestampNow(),
+		Error:     &types.Error{Message: errMsg},
+	}, cfg.expectedGeneration)
+}
+
+// appendCompensation records the result of the compensation of a task in the event stream of the invocation.
+func (ap *Task) appendCompensation(invocationID string, taskID string, result *types.TaskInvocationStatus,
+	expectedGeneration int64) error {
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), &events.InvocationTaskCompensated{
+		TaskId: taskID,
+		Result: result,
+	})
+	if err != nil {
+		return err
+	}
+	return appendToInvocation(ap.es, invocationID, event, expectedGeneration, taskNotCompensated(taskID))
 }
 
 func (ap *Task) Prepare(spec *types.TaskInvocationSpec, expectedAt time.Time, opts ...CallOption) error {
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Trigger contains the API functionality for controlling triggers, which invoke a workflow on a schedule.
+// This includes creating, updating and deleting triggers, and recording the invocations started by them.
+type Trigger struct {
+	es fes.Backend
+}
+
+// NewTriggerAPI creates the Trigger API.
+func NewTriggerAPI(esClient fes.Backend) *Trigger {
+	return &Trigger{esClient}
+}
+
+// Create creates a new trigger based on the provided spec.
+// The function either returns the id of the trigger or an error.
+// The error can be a validate.Err, proto marshall error, or a fes error.
+func (ta *Trigger) Create(spec *types.TriggerSpec, opts ...CallOption) (string, error) {
+	if err := validate.TriggerSpec(spec); err != nil {
+		return "", err
+	}
+
+	id := fmt.Sprintf("tr-%s", util.UID())
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(id), &events.TriggerCreated{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	err = ta.es.Append(event)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Update replaces the spec of the trigger. The schedule of the trigger continues from the last time that it fired.
+// If an expected generation is provided, and the trigger has been modified in the meantime, the API will return a
+// fes.ErrConcurrentModification.
+func (ta *Trigger) Update(triggerID string, spec *types.TriggerSpec, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+	if err := validate.TriggerSpec(spec); err != nil {
+		return err
+	}
+
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerUpdated{
+		Spec: spec,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ta.es.Append(event)
+}
+
+// Delete marks a trigger as deleted, which stops it from invoking the workflow any further.
+// If the API fails to append the event to the event store, it will return an error.
+func (ta *Trigger) Delete(triggerID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerDeleted{})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	event.Hints = &fes.EventHints{Completed: true}
+	return ta.es.Append(event)
+}
+
+// Fire records that the trigger started the invocation for the given scheduled time.
+// If an expected generation is provided, and the trigger has been modified in the meantime, for example because another
+// scheduler fired it already, the API will return a fes.ErrConcurrentModification.
+func (ta *Trigger) Fire(triggerID string, scheduledAt time.Time, invocationID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+	ts, err := ptypes.TimestampProto(scheduledAt)
+	if err != nil {
+		return err
+	}
+
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerFired{
+		ScheduledAt:        ts,
+		InvocationId:       invocationID,
+		SourceInvocationId: cfg.sourceInvocationID,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ta.es.Append(event)
+}
+
+// Skip records that the trigger did not start an invocation for the given scheduled time, along with the reason why.
+// If an expected generation is provided, and the trigger has been modified in the meantime, the API will return a
+// fes.ErrConcurrentModification.
+func (ta *Trigger) Skip(triggerID string, scheduledAt time.Time, reason error, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+	ts, err := ptypes.TimestampProto(scheduledAt)
+	if err != nil {
+		return err
+	}
+
+	var skipErr *types.Error
+	if reason != nil {
+		skipErr = &types.Error{Message: reason.Error()}
+	}
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerSkipped{
+		ScheduledAt:        ts,
+		Error:              skipErr,
+		SourceInvocationId: cfg.sourceInvocationID,
+	})
+	if err != nil {
+		return err
+	}
+	event.ExpectedGeneration = cfg.expectedGeneration
+	return ta.es.Append(event)
+}
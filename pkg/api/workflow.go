@@ -95,7 +95,15 @@ func (wa *Workflow) Parse(workflow *types.Workflow) (map[string]*types.TaskStatu
 		return nil, err
 	}
 
-	resolvedFns, err := fnenv.ResolveTasks(wa.resolver, workflow.Spec.Tasks)
+	// Resolve the functions of the compensations along with the functions of the tasks.
+	tasks := map[string]*types.TaskSpec{}
+	for id, t := range workflow.Spec.Tasks {
+		tasks[id] = t
+		if compensate := t.GetCompensate(); compensate != nil {
+			tasks[id+".compensate"] = compensate
+		}
+	}
+	resolvedFns, err := fnenv.ResolveTasks(wa.resolver, tasks)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve tasks in workflow: %v", err)
 	}
@@ -107,6 +115,9 @@ func (wa *Workflow) Parse(workflow *types.Workflow) (map[string]*types.TaskStatu
 			FnRef:     resolvedFns[t.FunctionRef],
 			Status:    types.TaskStatus_READY,
 		}
+		if compensate := t.GetCompensate(); compensate != nil {
+			taskStatuses[id].CompensateFnRef = resolvedFns[compensate.FunctionRef]
+		}
 	}
 
 	event, err := fes.NewEvent(projectors.NewWorkflowAggregate(workflow.ID()), &events.WorkflowParsed{
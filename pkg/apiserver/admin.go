@@ -1,18 +1,56 @@
 package apiserver
 
 import (
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backup"
 	"github.com/fission/fission-workflows/pkg/version"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	StatusOK       = "OK!"
+	StatusDraining = "DRAINING"
 )
 
-const StatusOK = "OK!"
+// Drainer drains the workflow engine, after which it no longer accepts new invocations. Draining is terminal; the
+// engine is expected to be stopped afterwards.
+type Drainer interface {
+	// Drain stops the engine from starting new work, and waits until the running work has finished or the context
+	// is done.
+	Drain(ctx context.Context) *DrainStatus
+
+	// Draining checks if the engine has started draining.
+	Draining() bool
+}
 
 // Admin is responsible for all administrative functions related to managing the workflow engine.
 type Admin struct {
+	backend fes.Backend
+	drainer Drainer
+}
+
+// NewAdmin creates the admin server. If drainer is nil, the engine cannot be drained.
+func NewAdmin(backend fes.Backend, drainer Drainer) *Admin {
+	return &Admin{
+		backend: backend,
+		drainer: drainer,
+	}
 }
 
 func (as *Admin) Status(ctx context.Context, _ *empty.Empty) (*Health, error) {
+	if as.drainer != nil && as.drainer.Draining() {
+		return &Health{
+			Status: StatusDraining,
+		}, nil
+	}
 	return &Health{
 		Status: StatusOK,
 	}, nil
@@ -22,3 +60,61 @@ func (as *Admin) Version(ctx context.Context, _ *empty.Empty) (*version.Info, er
 	v := version.VersionInfo()
 	return &v, nil
 }
+
+func (as *Admin) Export(req *ExportRequest, stream AdminAPI_ExportServer) error {
+	filter := backup.Filter{
+		Types: req.GetTypes(),
+	}
+	var err error
+	if filter.Since, err = parseOptionalTimestamp(req.GetSince()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid since: %v", err)
+	}
+	if filter.Until, err = parseOptionalTimestamp(req.GetUntil()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid until: %v", err)
+	}
+
+	n, err := backup.Export(as.backend, filter, stream.Send)
+	if err != nil {
+		return toErrorStatus(err)
+	}
+	logrus.Infof("Exported %d event(s)", n)
+	return nil
+}
+
+func (as *Admin) Import(stream AdminAPI_ImportServer) error {
+	n, skipped, err := backup.Import(as.backend, func() (*fes.Event, error) {
+		return stream.Recv()
+	})
+	if err != nil {
+		logrus.Errorf("Import aborted after %d event(s): %v", n, err)
+		return toErrorStatus(err)
+	}
+	logrus.Infof("Imported %d event(s); skipped %d event(s) that were already present", n, skipped)
+	return stream.SendAndClose(&ImportSummary{
+		Events: int64(n),
+	})
+}
+
+func (as *Admin) Drain(ctx context.Context, req *DrainRequest) (*DrainStatus, error) {
+	if as.drainer == nil {
+		return nil, status.Error(codes.Unimplemented, "draining is not supported by this engine")
+	}
+	if req.GetTimeout() != nil {
+		timeout, err := ptypes.Duration(req.GetTimeout())
+		if err != nil || timeout <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timeout: %v", req.GetTimeout())
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return as.drainer.Drain(ctx), nil
+}
+
+// parseOptionalTimestamp converts the timestamp to a time, returning the zero time if the timestamp is not set.
+func parseOptionalTimestamp(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return ptypes.Timestamp(ts)
+}
@@ -14,7 +14,10 @@ packages that are responsible for the business logic, such as `api`.
 package apiserver
 
 import (
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -28,8 +31,48 @@ func toErrorStatus(err error) error {
 	case validate.Error:
 		logrus.Errorf("Request error: %v", validate.FormatConcise(err))
 		return status.Error(codes.InvalidArgument, validate.Format(err))
+	case fes.EventStoreErr:
+		logrus.Errorf("Request error: %v", err)
+		if fes.ErrEntityNotFound.Is(err) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		if fes.ErrConcurrentModification.Is(err) {
+			return status.Error(codes.Aborted, err.Error())
+		}
+		return err
 	default:
 		logrus.Errorf("Request error: %v", err)
 		return err
 	}
 }
+
+// projectAt projects the entity as it was at the point in its event stream that is specified by the request.
+func projectAt(backend fes.Backend, projector fes.Projector, key fes.Aggregate, req *GetAtRequest) (fes.Entity,
+	error) {
+	var entity fes.Entity
+	var err error
+	switch at := req.GetAt().(type) {
+	case *GetAtRequest_Index:
+		entity, err = projectors.ProjectUntilIndex(backend, projector, key, int(at.Index))
+	case *GetAtRequest_Timestamp:
+		t, tsErr := ptypes.Timestamp(at.Timestamp)
+		if tsErr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid timestamp: %v", tsErr)
+		}
+		entity, err = projectors.ProjectUntilTime(backend, projector, key, t)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either an index or a timestamp is required")
+	}
+	if err != nil {
+		logrus.Errorf("Request error: %v", err)
+		switch {
+		case fes.ErrEntityNotFound.Is(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case projectors.ErrEventIndexOutOfRange.Is(err):
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		default:
+			return nil, err
+		}
+	}
+	return entity, nil
+}
@@ -10,10 +10,19 @@ It is generated from these files:
 It has these top-level messages:
 	WorkflowList
 	AddTaskRequest
+	SignalRequest
+	RerunRequest
 	InvocationListQuery
 	WorkflowInvocationList
+	GetAtRequest
 	ObjectEvents
 	Health
+	ExportRequest
+	ImportSummary
+	DrainRequest
+	DrainStatus
+	TriggerList
+	UpdateTriggerRequest
 */
 package apiserver
 
@@ -21,9 +30,12 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import fission_workflows_types1 "github.com/fission/fission-workflows/pkg/types"
+import fission_workflows_types "github.com/fission/fission-workflows/pkg/types/typedvalues"
 import fission_workflows_version "github.com/fission/fission-workflows/pkg/version"
 import fission_workflows_eventstore "github.com/fission/fission-workflows/pkg/fes"
 import google_protobuf3 "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf1 "github.com/golang/protobuf/ptypes/duration"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
 
 import (
@@ -82,6 +94,70 @@ func (m *AddTaskRequest) GetTask() *fission_workflows_types1.Task {
 	return nil
 }
 
+// SignalRequest contains the signal to send to an invocation.
+type SignalRequest struct {
+	// Id is the ID of the invocation to send the signal to.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Name is the name of the signal, which tasks use to await it.
+	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	// Payload contains the (optional) data of the signal.
+	Payload *fission_workflows_types.TypedValue `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
+}
+
+func (m *SignalRequest) Reset()                    { *m = SignalRequest{} }
+func (m *SignalRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignalRequest) ProtoMessage()               {}
+func (*SignalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *SignalRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SignalRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SignalRequest) GetPayload() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+// RerunRequest identifies the invocation to rerun.
+type RerunRequest struct {
+	// Id is the ID of the failed or canceled invocation to rerun.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// WorkflowId is the ID of the workflow to rerun the invocation against. If empty, the workflow of the original
+	// invocation is used.
+	WorkflowId string `protobuf:"bytes,2,opt,name=workflowId" json:"workflowId,omitempty"`
+}
+
+func (m *RerunRequest) Reset()                    { *m = RerunRequest{} }
+func (m *RerunRequest) String() string            { return proto.CompactTextString(m) }
+func (*RerunRequest) ProtoMessage()               {}
+func (*RerunRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *RerunRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RerunRequest) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
 type InvocationListQuery struct {
 	Workflows []string `protobuf:"bytes,1,rep,name=workflows" json:"workflows,omitempty"`
 }
@@ -89,7 +165,7 @@ type InvocationListQuery struct {
 func (m *InvocationListQuery) Reset()                    { *m = InvocationListQuery{} }
 func (m *InvocationListQuery) String() string            { return proto.CompactTextString(m) }
 func (*InvocationListQuery) ProtoMessage()               {}
-func (*InvocationListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*InvocationListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *InvocationListQuery) GetWorkflows() []string {
 	if m != nil {
@@ -105,7 +181,7 @@ type WorkflowInvocationList struct {
 func (m *WorkflowInvocationList) Reset()                    { *m = WorkflowInvocationList{} }
 func (m *WorkflowInvocationList) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationList) ProtoMessage()               {}
-func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *WorkflowInvocationList) GetInvocations() []string {
 	if m != nil {
@@ -114,6 +190,129 @@ func (m *WorkflowInvocationList) GetInvocations() []string {
 	return nil
 }
 
+// GetAtRequest identifies a point in the event stream of an entity.
+type GetAtRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Types that are valid to be assigned to At:
+	//	*GetAtRequest_Index
+	//	*GetAtRequest_Timestamp
+	At isGetAtRequest_At `protobuf_oneof:"at"`
+}
+
+func (m *GetAtRequest) Reset()                    { *m = GetAtRequest{} }
+func (m *GetAtRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetAtRequest) ProtoMessage()               {}
+func (*GetAtRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type isGetAtRequest_At interface{ isGetAtRequest_At() }
+
+type GetAtRequest_Index struct {
+	Index int64 `protobuf:"varint,2,opt,name=index,oneof"`
+}
+type GetAtRequest_Timestamp struct {
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=timestamp,oneof"`
+}
+
+func (*GetAtRequest_Index) isGetAtRequest_At()     {}
+func (*GetAtRequest_Timestamp) isGetAtRequest_At() {}
+
+func (m *GetAtRequest) GetAt() isGetAtRequest_At {
+	if m != nil {
+		return m.At
+	}
+	return nil
+}
+
+func (m *GetAtRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetAtRequest) GetIndex() int64 {
+	if x, ok := m.GetAt().(*GetAtRequest_Index); ok {
+		return x.Index
+	}
+	return 0
+}
+
+func (m *GetAtRequest) GetTimestamp() *google_protobuf.Timestamp {
+	if x, ok := m.GetAt().(*GetAtRequest_Timestamp); ok {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*GetAtRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _GetAtRequest_OneofMarshaler, _GetAtRequest_OneofUnmarshaler, _GetAtRequest_OneofSizer, []interface{}{
+		(*GetAtRequest_Index)(nil),
+		(*GetAtRequest_Timestamp)(nil),
+	}
+}
+
+func _GetAtRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*GetAtRequest)
+	// at
+	switch x := m.At.(type) {
+	case *GetAtRequest_Index:
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Index))
+	case *GetAtRequest_Timestamp:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Timestamp); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("GetAtRequest.At has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _GetAtRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*GetAtRequest)
+	switch tag {
+	case 2: // at.index
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.At = &GetAtRequest_Index{int64(x)}
+		return true, err
+	case 3: // at.timestamp
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(google_protobuf.Timestamp)
+		err := b.DecodeMessage(msg)
+		m.At = &GetAtRequest_Timestamp{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _GetAtRequest_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*GetAtRequest)
+	// at
+	switch x := m.At.(type) {
+	case *GetAtRequest_Index:
+		n += proto.SizeVarint(2<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Index))
+	case *GetAtRequest_Timestamp:
+		s := proto.Size(x.Timestamp)
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
 type ObjectEvents struct {
 	Metadata *fission_workflows_types1.ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
 	Events   []*fission_workflows_eventstore.Event    `protobuf:"bytes,2,rep,name=events" json:"events,omitempty"`
@@ -122,7 +321,7 @@ type ObjectEvents struct {
 func (m *ObjectEvents) Reset()                    { *m = ObjectEvents{} }
 func (m *ObjectEvents) String() string            { return proto.CompactTextString(m) }
 func (*ObjectEvents) ProtoMessage()               {}
-func (*ObjectEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*ObjectEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *ObjectEvents) GetMetadata() *fission_workflows_types1.ObjectMetadata {
 	if m != nil {
@@ -145,7 +344,7 @@ type Health struct {
 func (m *Health) Reset()                    { *m = Health{} }
 func (m *Health) String() string            { return proto.CompactTextString(m) }
 func (*Health) ProtoMessage()               {}
-func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *Health) GetStatus() string {
 	if m != nil {
@@ -154,13 +353,167 @@ func (m *Health) GetStatus() string {
 	return ""
 }
 
+type ExportRequest struct {
+	// Types limits the export to aggregates of these types. If empty, all aggregates are exported.
+	Types []string `protobuf:"bytes,1,rep,name=types" json:"types,omitempty"`
+	// Since, if set, excludes events that occurred before this time.
+	Since *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=since" json:"since,omitempty"`
+	// Until, if set, excludes events that occurred at or after this time.
+	Until *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=until" json:"until,omitempty"`
+}
+
+func (m *ExportRequest) Reset()                    { *m = ExportRequest{} }
+func (m *ExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()               {}
+func (*ExportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ExportRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *ExportRequest) GetSince() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *ExportRequest) GetUntil() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Until
+	}
+	return nil
+}
+
+type ImportSummary struct {
+	Events int64 `protobuf:"varint,1,opt,name=events" json:"events,omitempty"`
+}
+
+func (m *ImportSummary) Reset()                    { *m = ImportSummary{} }
+func (m *ImportSummary) String() string            { return proto.CompactTextString(m) }
+func (*ImportSummary) ProtoMessage()               {}
+func (*ImportSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ImportSummary) GetEvents() int64 {
+	if m != nil {
+		return m.Events
+	}
+	return 0
+}
+
+type DrainRequest struct {
+	// Timeout is the maximum time to wait for the running tasks to finish.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,1,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *DrainRequest) Reset()                    { *m = DrainRequest{} }
+func (m *DrainRequest) String() string            { return proto.CompactTextString(m) }
+func (*DrainRequest) ProtoMessage()               {}
+func (*DrainRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *DrainRequest) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+type DrainStatus struct {
+	// Drained indicates whether all running tasks finished before the timeout.
+	Drained bool `protobuf:"varint,1,opt,name=drained" json:"drained,omitempty"`
+	// PendingTasks is the number of tasks that were still queued or running at the end of the drain.
+	PendingTasks int64 `protobuf:"varint,2,opt,name=pendingTasks" json:"pendingTasks,omitempty"`
+	// Invocations contains the ids of the invocations that still had pending tasks at the end of the drain.
+	Invocations []string `protobuf:"bytes,3,rep,name=invocations" json:"invocations,omitempty"`
+}
+
+func (m *DrainStatus) Reset()                    { *m = DrainStatus{} }
+func (m *DrainStatus) String() string            { return proto.CompactTextString(m) }
+func (*DrainStatus) ProtoMessage()               {}
+func (*DrainStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *DrainStatus) GetDrained() bool {
+	if m != nil {
+		return m.Drained
+	}
+	return false
+}
+
+func (m *DrainStatus) GetPendingTasks() int64 {
+	if m != nil {
+		return m.PendingTasks
+	}
+	return 0
+}
+
+func (m *DrainStatus) GetInvocations() []string {
+	if m != nil {
+		return m.Invocations
+	}
+	return nil
+}
+
+type TriggerList struct {
+	Triggers []string `protobuf:"bytes,1,rep,name=triggers" json:"triggers,omitempty"`
+}
+
+func (m *TriggerList) Reset()                    { *m = TriggerList{} }
+func (m *TriggerList) String() string            { return proto.CompactTextString(m) }
+func (*TriggerList) ProtoMessage()               {}
+func (*TriggerList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TriggerList) GetTriggers() []string {
+	if m != nil {
+		return m.Triggers
+	}
+	return nil
+}
+
+// UpdateTriggerRequest contains the new spec of a trigger.
+type UpdateTriggerRequest struct {
+	// Id is the ID of the trigger to update.
+	Id   string                                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Spec *fission_workflows_types1.TriggerSpec `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *UpdateTriggerRequest) Reset()                    { *m = UpdateTriggerRequest{} }
+func (m *UpdateTriggerRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateTriggerRequest) ProtoMessage()               {}
+func (*UpdateTriggerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *UpdateTriggerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UpdateTriggerRequest) GetSpec() *fission_workflows_types1.TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WorkflowList)(nil), "fission.workflows.apiserver.WorkflowList")
 	proto.RegisterType((*AddTaskRequest)(nil), "fission.workflows.apiserver.AddTaskRequest")
+	proto.RegisterType((*SignalRequest)(nil), "fission.workflows.apiserver.SignalRequest")
+	proto.RegisterType((*RerunRequest)(nil), "fission.workflows.apiserver.RerunRequest")
 	proto.RegisterType((*InvocationListQuery)(nil), "fission.workflows.apiserver.InvocationListQuery")
 	proto.RegisterType((*WorkflowInvocationList)(nil), "fission.workflows.apiserver.WorkflowInvocationList")
+	proto.RegisterType((*GetAtRequest)(nil), "fission.workflows.apiserver.GetAtRequest")
 	proto.RegisterType((*ObjectEvents)(nil), "fission.workflows.apiserver.ObjectEvents")
 	proto.RegisterType((*Health)(nil), "fission.workflows.apiserver.Health")
+	proto.RegisterType((*ExportRequest)(nil), "fission.workflows.apiserver.ExportRequest")
+	proto.RegisterType((*ImportSummary)(nil), "fission.workflows.apiserver.ImportSummary")
+	proto.RegisterType((*DrainRequest)(nil), "fission.workflows.apiserver.DrainRequest")
+	proto.RegisterType((*DrainStatus)(nil), "fission.workflows.apiserver.DrainStatus")
+	proto.RegisterType((*TriggerList)(nil), "fission.workflows.apiserver.TriggerList")
+	proto.RegisterType((*UpdateTriggerRequest)(nil), "fission.workflows.apiserver.UpdateTriggerRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -181,6 +534,11 @@ type WorkflowAPIClient interface {
 	Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 	Validate(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 	Events(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*ObjectEvents, error)
+	// GetAt returns the workflow as it was at a specific point in its event stream.
+	GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*fission_workflows_types1.Workflow, error)
+	// Watch streams the current state of the workflow, followed by every update to it, until the workflow has been
+	// deleted. Over HTTP, the updates are streamed as server-sent events if the client accepts 'text/event-stream'.
+	Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowAPI_WatchClient, error)
 }
 
 type workflowAPIClient struct {
@@ -254,6 +612,47 @@ func (c *workflowAPIClient) Events(ctx context.Context, in *fission_workflows_ty
 	return out, nil
 }
 
+func (c *workflowAPIClient) GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*fission_workflows_types1.Workflow, error) {
+	out := new(fission_workflows_types1.Workflow)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/GetAt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowAPIClient) Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowAPI_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_WorkflowAPI_serviceDesc.Streams[0], c.cc, "/fission.workflows.apiserver.WorkflowAPI/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workflowAPIWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkflowAPI_WatchClient interface {
+	Recv() (*fission_workflows_types1.Workflow, error)
+	grpc.ClientStream
+}
+
+type workflowAPIWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *workflowAPIWatchClient) Recv() (*fission_workflows_types1.Workflow, error) {
+	m := new(fission_workflows_types1.Workflow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for WorkflowAPI service
 
 type WorkflowAPIServer interface {
@@ -264,6 +663,11 @@ type WorkflowAPIServer interface {
 	Delete(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
 	Validate(context.Context, *fission_workflows_types1.WorkflowSpec) (*google_protobuf3.Empty, error)
 	Events(context.Context, *fission_workflows_types1.ObjectMetadata) (*ObjectEvents, error)
+	// GetAt returns the workflow as it was at a specific point in its event stream.
+	GetAt(context.Context, *GetAtRequest) (*fission_workflows_types1.Workflow, error)
+	// Watch streams the current state of the workflow, followed by every update to it, until the workflow has been
+	// deleted. Over HTTP, the updates are streamed as server-sent events if the client accepts 'text/event-stream'.
+	Watch(*fission_workflows_types1.ObjectMetadata, WorkflowAPI_WatchServer) error
 }
 
 func RegisterWorkflowAPIServer(s *grpc.Server, srv WorkflowAPIServer) {
@@ -396,6 +800,45 @@ func _WorkflowAPI_Events_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowAPI_GetAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowAPIServer).GetAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowAPI/GetAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowAPIServer).GetAt(ctx, req.(*GetAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowAPI_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(fission_workflows_types1.ObjectMetadata)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkflowAPIServer).Watch(m, &workflowAPIWatchServer{stream})
+}
+
+type WorkflowAPI_WatchServer interface {
+	Send(*fission_workflows_types1.Workflow) error
+	grpc.ServerStream
+}
+
+type workflowAPIWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *workflowAPIWatchServer) Send(m *fission_workflows_types1.Workflow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.WorkflowAPI",
 	HandlerType: (*WorkflowAPIServer)(nil),
@@ -428,8 +871,18 @@ var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Events",
 			Handler:    _WorkflowAPI_Events_Handler,
 		},
+		{
+			MethodName: "GetAt",
+			Handler:    _WorkflowAPI_GetAt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _WorkflowAPI_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiserver/apiserver.proto",
 }
 
@@ -448,6 +901,31 @@ type WorkflowInvocationAPIClient interface {
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Pause a workflow invocation
+	//
+	// A paused invocation does not schedule any new tasks, but tasks that are already running are allowed to complete
+	// and record their results. The deadline of the invocation still applies while it is paused.
+	// In case that an invocation has already finished, a HTTP 409 error status is returned.
+	Pause(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Resume a paused workflow invocation
+	//
+	// In case that an invocation is not paused, a HTTP 409 error status is returned.
+	Resume(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Signal sends an external signal to a workflow invocation
+	//
+	// The signal, together with its optional payload, is stored in the invocation. Tasks that await a signal with the
+	// same name (using the 'await' function) complete with the payload as their output.
+	// In case that an invocation has already finished or has already received a signal with the same name, a HTTP 412
+	// error status is returned.
+	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Rerun a failed or canceled workflow invocation from the point of failure
+	//
+	// Rerun creates a new invocation with the same inputs as the original invocation, which reuses the results of the
+	// tasks that succeeded in the original invocation. Only the failed tasks and the tasks that depend on them are run.
+	// Optionally, the invocation is rerun against a newer revision of the workflow, in which case the tasks that have
+	// changed in the new revision are run as well.
+	// In case that an invocation has not failed or has not been canceled, a HTTP 412 error status is returned.
+	Rerun(ctx context.Context, in *RerunRequest, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error)
 	List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
@@ -455,6 +933,15 @@ type WorkflowInvocationAPIClient interface {
 	// To lighten the request load, consider using a more specific request.
 	Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.WorkflowInvocation, error)
 	Events(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*ObjectEvents, error)
+	// GetAt returns the workflow invocation as it was at a specific point in its event stream.
+	//
+	// The invocation is projected from the events up to and including the event at the given index or timestamp.
+	// This is useful for debugging, as it allows you to inspect the state of an invocation after each event.
+	GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*fission_workflows_types1.WorkflowInvocation, error)
+	// Watch streams the current state of the workflow invocation, followed by every update to it, until the
+	// invocation has finished. Over HTTP, the updates are streamed as server-sent events if the client accepts
+	// 'text/event-stream'.
+	Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error)
 	Validate(ctx context.Context, in *fission_workflows_types1.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 }
 
@@ -502,6 +989,42 @@ func (c *workflowInvocationAPIClient) Cancel(ctx context.Context, in *fission_wo
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Pause(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Pause", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Resume(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Resume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Signal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Rerun(ctx context.Context, in *RerunRequest, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error) {
+	out := new(fission_workflows_types1.ObjectMetadata)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Rerun", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowInvocationAPIClient) List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error) {
 	out := new(WorkflowInvocationList)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/List", in, out, c.cc, opts...)
@@ -529,6 +1052,47 @@ func (c *workflowInvocationAPIClient) Events(ctx context.Context, in *fission_wo
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) GetAt(ctx context.Context, in *GetAtRequest, opts ...grpc.CallOption) (*fission_workflows_types1.WorkflowInvocation, error) {
+	out := new(fission_workflows_types1.WorkflowInvocation)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/GetAt", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_WorkflowInvocationAPI_serviceDesc.Streams[0], c.cc, "/fission.workflows.apiserver.WorkflowInvocationAPI/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workflowInvocationAPIWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkflowInvocationAPI_WatchClient interface {
+	Recv() (*fission_workflows_types1.WorkflowInvocation, error)
+	grpc.ClientStream
+}
+
+type workflowInvocationAPIWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *workflowInvocationAPIWatchClient) Recv() (*fission_workflows_types1.WorkflowInvocation, error) {
+	m := new(fission_workflows_types1.WorkflowInvocation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *workflowInvocationAPIClient) Validate(ctx context.Context, in *fission_workflows_types1.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
 	out := new(google_protobuf3.Empty)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Validate", in, out, c.cc, opts...)
@@ -553,6 +1117,31 @@ type WorkflowInvocationAPIServer interface {
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+	// Pause a workflow invocation
+	//
+	// A paused invocation does not schedule any new tasks, but tasks that are already running are allowed to complete
+	// and record their results. The deadline of the invocation still applies while it is paused.
+	// In case that an invocation has already finished, a HTTP 409 error status is returned.
+	Pause(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+	// Resume a paused workflow invocation
+	//
+	// In case that an invocation is not paused, a HTTP 409 error status is returned.
+	Resume(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+	// Signal sends an external signal to a workflow invocation
+	//
+	// The signal, together with its optional payload, is stored in the invocation. Tasks that await a signal with the
+	// same name (using the 'await' function) complete with the payload as their output.
+	// In case that an invocation has already finished or has already received a signal with the same name, a HTTP 412
+	// error status is returned.
+	Signal(context.Context, *SignalRequest) (*google_protobuf3.Empty, error)
+	// Rerun a failed or canceled workflow invocation from the point of failure
+	//
+	// Rerun creates a new invocation with the same inputs as the original invocation, which reuses the results of the
+	// tasks that succeeded in the original invocation. Only the failed tasks and the tasks that depend on them are run.
+	// Optionally, the invocation is rerun against a newer revision of the workflow, in which case the tasks that have
+	// changed in the new revision are run as well.
+	// In case that an invocation has not failed or has not been canceled, a HTTP 412 error status is returned.
+	Rerun(context.Context, *RerunRequest) (*fission_workflows_types1.ObjectMetadata, error)
 	List(context.Context, *InvocationListQuery) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
@@ -560,6 +1149,15 @@ type WorkflowInvocationAPIServer interface {
 	// To lighten the request load, consider using a more specific request.
 	Get(context.Context, *fission_workflows_types1.ObjectMetadata) (*fission_workflows_types1.WorkflowInvocation, error)
 	Events(context.Context, *fission_workflows_types1.ObjectMetadata) (*ObjectEvents, error)
+	// GetAt returns the workflow invocation as it was at a specific point in its event stream.
+	//
+	// The invocation is projected from the events up to and including the event at the given index or timestamp.
+	// This is useful for debugging, as it allows you to inspect the state of an invocation after each event.
+	GetAt(context.Context, *GetAtRequest) (*fission_workflows_types1.WorkflowInvocation, error)
+	// Watch streams the current state of the workflow invocation, followed by every update to it, until the
+	// invocation has finished. Over HTTP, the updates are streamed as server-sent events if the client accepts
+	// 'text/event-stream'.
+	Watch(*fission_workflows_types1.ObjectMetadata, WorkflowInvocationAPI_WatchServer) error
 	Validate(context.Context, *fission_workflows_types1.WorkflowInvocationSpec) (*google_protobuf3.Empty, error)
 }
 
@@ -639,17 +1237,89 @@ func _WorkflowInvocationAPI_Cancel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _WorkflowInvocationAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InvocationListQuery)
+func _WorkflowInvocationAPI_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WorkflowInvocationAPIServer).List(ctx, in)
+		return srv.(WorkflowInvocationAPIServer).Pause(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/List",
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Pause(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Resume(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Signal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Signal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Signal(ctx, req.(*SignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Rerun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RerunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Rerun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Rerun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Rerun(ctx, req.(*RerunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvocationListQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/List",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(WorkflowInvocationAPIServer).List(ctx, req.(*InvocationListQuery))
@@ -693,6 +1363,45 @@ func _WorkflowInvocationAPI_Events_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_GetAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).GetAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/GetAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).GetAt(ctx, req.(*GetAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(fission_workflows_types1.ObjectMetadata)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkflowInvocationAPIServer).Watch(m, &workflowInvocationAPIWatchServer{stream})
+}
+
+type WorkflowInvocationAPI_WatchServer interface {
+	Send(*fission_workflows_types1.WorkflowInvocation) error
+	grpc.ServerStream
+}
+
+type workflowInvocationAPIWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *workflowInvocationAPIWatchServer) Send(m *fission_workflows_types1.WorkflowInvocation) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WorkflowInvocationAPI_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(fission_workflows_types1.WorkflowInvocationSpec)
 	if err := dec(in); err != nil {
@@ -731,6 +1440,22 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Cancel",
 			Handler:    _WorkflowInvocationAPI_Cancel_Handler,
 		},
+		{
+			MethodName: "Pause",
+			Handler:    _WorkflowInvocationAPI_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _WorkflowInvocationAPI_Resume_Handler,
+		},
+		{
+			MethodName: "Signal",
+			Handler:    _WorkflowInvocationAPI_Signal_Handler,
+		},
+		{
+			MethodName: "Rerun",
+			Handler:    _WorkflowInvocationAPI_Rerun_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _WorkflowInvocationAPI_List_Handler,
@@ -743,12 +1468,22 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Events",
 			Handler:    _WorkflowInvocationAPI_Events_Handler,
 		},
+		{
+			MethodName: "GetAt",
+			Handler:    _WorkflowInvocationAPI_GetAt_Handler,
+		},
 		{
 			MethodName: "Validate",
 			Handler:    _WorkflowInvocationAPI_Validate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _WorkflowInvocationAPI_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "pkg/apiserver/apiserver.proto",
 }
 
@@ -757,6 +1492,17 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 type AdminAPIClient interface {
 	Status(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*Health, error)
 	Version(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*fission_workflows_version.Info, error)
+	// Export streams all events in the event store that match the request.
+	//
+	// The events of an aggregate are streamed in the order in which they were appended.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (AdminAPI_ExportClient, error)
+	// Import appends the streamed events to the event store, preserving their ids, timestamps and parent links.
+	Import(ctx context.Context, opts ...grpc.CallOption) (AdminAPI_ImportClient, error)
+	// Drain stops the engine from accepting new invocations and from scheduling new tasks, and waits for the tasks
+	// that are running to finish, up to the timeout. It returns the work that remained at the end of the drain.
+	//
+	// Draining cannot be undone; the engine is expected to be stopped afterwards.
+	Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainStatus, error)
 }
 
 type adminAPIClient struct {
@@ -785,11 +1531,97 @@ func (c *adminAPIClient) Version(ctx context.Context, in *google_protobuf3.Empty
 	return out, nil
 }
 
+func (c *adminAPIClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (AdminAPI_ExportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_AdminAPI_serviceDesc.Streams[0], c.cc, "/fission.workflows.apiserver.AdminAPI/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminAPIExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminAPI_ExportClient interface {
+	Recv() (*fission_workflows_eventstore.Event, error)
+	grpc.ClientStream
+}
+
+type adminAPIExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminAPIExportClient) Recv() (*fission_workflows_eventstore.Event, error) {
+	m := new(fission_workflows_eventstore.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminAPIClient) Import(ctx context.Context, opts ...grpc.CallOption) (AdminAPI_ImportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_AdminAPI_serviceDesc.Streams[1], c.cc, "/fission.workflows.apiserver.AdminAPI/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminAPIImportClient{stream}
+	return x, nil
+}
+
+type AdminAPI_ImportClient interface {
+	Send(*fission_workflows_eventstore.Event) error
+	CloseAndRecv() (*ImportSummary, error)
+	grpc.ClientStream
+}
+
+type adminAPIImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminAPIImportClient) Send(m *fission_workflows_eventstore.Event) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminAPIImportClient) CloseAndRecv() (*ImportSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminAPIClient) Drain(ctx context.Context, in *DrainRequest, opts ...grpc.CallOption) (*DrainStatus, error) {
+	out := new(DrainStatus)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.AdminAPI/Drain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for AdminAPI service
 
 type AdminAPIServer interface {
 	Status(context.Context, *google_protobuf3.Empty) (*Health, error)
 	Version(context.Context, *google_protobuf3.Empty) (*fission_workflows_version.Info, error)
+	// Export streams all events in the event store that match the request.
+	//
+	// The events of an aggregate are streamed in the order in which they were appended.
+	Export(*ExportRequest, AdminAPI_ExportServer) error
+	// Import appends the streamed events to the event store, preserving their ids, timestamps and parent links.
+	Import(AdminAPI_ImportServer) error
+	// Drain stops the engine from accepting new invocations and from scheduling new tasks, and waits for the tasks
+	// that are running to finish, up to the timeout. It returns the work that remained at the end of the drain.
+	//
+	// Draining cannot be undone; the engine is expected to be stopped afterwards.
+	Drain(context.Context, *DrainRequest) (*DrainStatus, error)
 }
 
 func RegisterAdminAPIServer(s *grpc.Server, srv AdminAPIServer) {
@@ -832,6 +1664,71 @@ func _AdminAPI_Version_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminAPI_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminAPIServer).Export(m, &adminAPIExportServer{stream})
+}
+
+type AdminAPI_ExportServer interface {
+	Send(*fission_workflows_eventstore.Event) error
+	grpc.ServerStream
+}
+
+type adminAPIExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminAPIExportServer) Send(m *fission_workflows_eventstore.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminAPI_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminAPIServer).Import(&adminAPIImportServer{stream})
+}
+
+type AdminAPI_ImportServer interface {
+	SendAndClose(*ImportSummary) error
+	Recv() (*fission_workflows_eventstore.Event, error)
+	grpc.ServerStream
+}
+
+type adminAPIImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminAPIImportServer) SendAndClose(m *ImportSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminAPIImportServer) Recv() (*fission_workflows_eventstore.Event, error) {
+	m := new(fission_workflows_eventstore.Event)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminAPI_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminAPIServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.AdminAPI/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminAPIServer).Drain(ctx, req.(*DrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.AdminAPI",
 	HandlerType: (*AdminAPIServer)(nil),
@@ -844,6 +1741,219 @@ var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _AdminAPI_Version_Handler,
 		},
+		{
+			MethodName: "Drain",
+			Handler:    _AdminAPI_Drain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _AdminAPI_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _AdminAPI_Import_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/apiserver/apiserver.proto",
+}
+
+// Client API for TriggerAPI service
+
+type TriggerAPIClient interface {
+	Create(ctx context.Context, in *fission_workflows_types1.TriggerSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error)
+	List(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*TriggerList, error)
+	Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.Trigger, error)
+	// Update replaces the spec of the trigger. The schedule of the trigger continues from the last time that it fired.
+	Update(ctx context.Context, in *UpdateTriggerRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+}
+
+type triggerAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTriggerAPIClient(cc *grpc.ClientConn) TriggerAPIClient {
+	return &triggerAPIClient{cc}
+}
+
+func (c *triggerAPIClient) Create(ctx context.Context, in *fission_workflows_types1.TriggerSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error) {
+	out := new(fission_workflows_types1.ObjectMetadata)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) List(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*TriggerList, error) {
+	out := new(TriggerList)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.Trigger, error) {
+	out := new(fission_workflows_types1.Trigger)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) Update(ctx context.Context, in *UpdateTriggerRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for TriggerAPI service
+
+type TriggerAPIServer interface {
+	Create(context.Context, *fission_workflows_types1.TriggerSpec) (*fission_workflows_types1.ObjectMetadata, error)
+	List(context.Context, *google_protobuf3.Empty) (*TriggerList, error)
+	Get(context.Context, *fission_workflows_types1.ObjectMetadata) (*fission_workflows_types1.Trigger, error)
+	// Update replaces the spec of the trigger. The schedule of the trigger continues from the last time that it fired.
+	Update(context.Context, *UpdateTriggerRequest) (*google_protobuf3.Empty, error)
+	Delete(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+}
+
+func RegisterTriggerAPIServer(s *grpc.Server, srv TriggerAPIServer) {
+	s.RegisterService(&_TriggerAPI_serviceDesc, srv)
+}
+
+func _TriggerAPI_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.TriggerSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Create(ctx, req.(*fission_workflows_types1.TriggerSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(google_protobuf3.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).List(ctx, req.(*google_protobuf3.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Get(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Update(ctx, req.(*UpdateTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Delete(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TriggerAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "fission.workflows.apiserver.TriggerAPI",
+	HandlerType: (*TriggerAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TriggerAPI_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _TriggerAPI_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _TriggerAPI_Get_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TriggerAPI_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TriggerAPI_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiserver/apiserver.proto",
@@ -852,58 +1962,99 @@ var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/apiserver/apiserver.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 835 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xd1, 0x4e, 0xe3, 0x56,
-	0x10, 0x95, 0x01, 0x99, 0x64, 0x4c, 0x51, 0x3a, 0x40, 0x08, 0x01, 0x44, 0x7a, 0x51, 0xd5, 0x00,
-	0xad, 0xdd, 0x06, 0xa9, 0x0f, 0x54, 0xaa, 0x44, 0x01, 0xb5, 0x91, 0x5a, 0xd1, 0x0d, 0x08, 0x24,
-	0xb4, 0x2f, 0x8e, 0x73, 0x93, 0x78, 0x93, 0xd8, 0xc1, 0xbe, 0x09, 0x0a, 0x28, 0x2f, 0xec, 0x0f,
-	0xac, 0xb4, 0x8f, 0xfb, 0xb0, 0xcf, 0xfb, 0x09, 0xfb, 0x1d, 0xfb, 0x0b, 0xfb, 0x21, 0x2b, 0x5f,
-	0x5f, 0x27, 0x0e, 0xc1, 0x89, 0xa3, 0xd5, 0x3e, 0x40, 0x92, 0xf1, 0xcc, 0x9c, 0x39, 0x73, 0xe7,
-	0xcc, 0x35, 0x6c, 0xb7, 0x1b, 0x35, 0x4d, 0x6f, 0x9b, 0x2e, 0x75, 0xba, 0xd4, 0x19, 0x7e, 0x53,
-	0xdb, 0x8e, 0xcd, 0x6c, 0xdc, 0xac, 0x9a, 0xae, 0x6b, 0xda, 0x96, 0x7a, 0x67, 0x3b, 0x8d, 0x6a,
-	0xd3, 0xbe, 0x73, 0xd5, 0x81, 0x4b, 0xf6, 0xa8, 0x66, 0xb2, 0x7a, 0xa7, 0xac, 0x1a, 0x76, 0x4b,
-	0x13, 0x7e, 0xc1, 0xe7, 0x2f, 0x03, 0x7f, 0xcd, 0x03, 0x60, 0xbd, 0x36, 0x75, 0xfd, 0xff, 0x7e,
-	0xe2, 0xec, 0x9f, 0xb1, 0x63, 0xbb, 0xd4, 0xe1, 0x4f, 0xc5, 0xa7, 0x88, 0xff, 0x3d, 0x76, 0x7c,
-	0x95, 0xba, 0xde, 0x9f, 0x88, 0xdb, 0xac, 0xd9, 0x76, 0xad, 0x49, 0x35, 0xfe, 0xab, 0xdc, 0xa9,
-	0x6a, 0xb4, 0xd5, 0x66, 0x3d, 0xf1, 0x70, 0x4b, 0x3c, 0xd4, 0xdb, 0xa6, 0xa6, 0x5b, 0x96, 0xcd,
-	0x74, 0x66, 0xda, 0x96, 0x08, 0x25, 0x3f, 0xc3, 0xd2, 0xb5, 0xc8, 0xfc, 0xaf, 0xe9, 0x32, 0xdc,
-	0x82, 0xe4, 0x00, 0x29, 0x23, 0xe5, 0xe6, 0xf3, 0xc9, 0xd2, 0xd0, 0x40, 0x6a, 0xb0, 0x7c, 0x5c,
-	0xa9, 0x5c, 0xea, 0x6e, 0xa3, 0x44, 0x6f, 0x3b, 0xd4, 0x65, 0x48, 0x60, 0xc9, 0xb4, 0xba, 0xb6,
-	0xc1, 0x93, 0x16, 0x4f, 0x33, 0x52, 0x4e, 0xca, 0x27, 0x4b, 0x23, 0x36, 0xfc, 0x0d, 0x16, 0x98,
-	0xee, 0x36, 0x32, 0x73, 0x39, 0x29, 0xaf, 0x14, 0xb6, 0xd5, 0xf1, 0xf6, 0xfb, 0x4d, 0xe4, 0x79,
-	0xb9, 0x2b, 0x39, 0x84, 0x95, 0xe2, 0x20, 0x85, 0x57, 0xd8, 0x8b, 0x0e, 0x75, 0x7a, 0x53, 0xaa,
-	0x3b, 0x82, 0x74, 0xc0, 0x65, 0x34, 0x18, 0x73, 0xa0, 0x0c, 0x2b, 0x0a, 0x22, 0xc3, 0x26, 0xf2,
-	0x46, 0x82, 0xa5, 0xf3, 0xf2, 0x2b, 0x6a, 0xb0, 0xb3, 0x2e, 0xb5, 0x98, 0x8b, 0x27, 0x90, 0x68,
-	0x51, 0xa6, 0x57, 0x74, 0xa6, 0x73, 0x52, 0x4a, 0xe1, 0xa7, 0xc8, 0xc2, 0xfd, 0xc0, 0xff, 0x84,
-	0x7b, 0x69, 0x10, 0x88, 0x7f, 0x80, 0x4c, 0x79, 0xba, 0xcc, 0x5c, 0x6e, 0x3e, 0xaf, 0x14, 0x76,
-	0x9f, 0x49, 0xe1, 0x3b, 0x30, 0xdb, 0xa1, 0x2a, 0x87, 0x2e, 0x89, 0x10, 0x92, 0x03, 0xf9, 0x1f,
-	0xaa, 0x37, 0x59, 0x1d, 0xd3, 0x20, 0xbb, 0x4c, 0x67, 0x1d, 0x57, 0xb4, 0x57, 0xfc, 0x2a, 0xbc,
-	0x96, 0x41, 0x09, 0x18, 0x1f, 0xff, 0x5f, 0x44, 0x0b, 0xe4, 0x13, 0x87, 0xea, 0x8c, 0xe2, 0x8f,
-	0x91, 0xb5, 0x06, 0xfe, 0x17, 0x6d, 0x6a, 0x64, 0xe3, 0x52, 0x22, 0xab, 0x8f, 0x9f, 0x3e, 0xbf,
-	0x9d, 0x5b, 0x26, 0x49, 0x2d, 0x70, 0x3c, 0x92, 0xf6, 0xf1, 0x16, 0xc0, 0xc7, 0xbb, 0xe8, 0x59,
-	0x46, 0x5c, 0xcc, 0x1f, 0xa6, 0xba, 0x91, 0x0d, 0x8e, 0xb6, 0x42, 0x96, 0x07, 0x68, 0x9a, 0xdb,
-	0xb3, 0x0c, 0x0f, 0xf2, 0x25, 0x2c, 0xf0, 0x13, 0x4d, 0xab, 0xfe, 0x58, 0xab, 0xc1, 0xcc, 0xab,
-	0x67, 0xde, 0xcc, 0x67, 0xf7, 0xd4, 0x09, 0xe2, 0x56, 0xc3, 0xa3, 0x4e, 0xbe, 0xe7, 0x28, 0x0a,
-	0x0e, 0x39, 0xa1, 0x09, 0xf3, 0x7f, 0x53, 0x86, 0x71, 0xdb, 0x12, 0x87, 0x4b, 0x9a, 0xa3, 0xa4,
-	0x30, 0xc4, 0xe5, 0xc1, 0xac, 0xf4, 0x51, 0x07, 0xf9, 0x94, 0x36, 0x29, 0xa3, 0xf1, 0xd1, 0x22,
-	0x38, 0x07, 0x10, 0xfb, 0x4f, 0x21, 0xea, 0x90, 0xb8, 0xd2, 0x9b, 0x66, 0x65, 0x86, 0x81, 0x88,
-	0x82, 0xd8, 0xe6, 0x10, 0xeb, 0x04, 0x87, 0x10, 0x5d, 0x91, 0xda, 0x3b, 0x95, 0x07, 0x90, 0x85,
-	0x6c, 0x62, 0x93, 0x99, 0x7c, 0x50, 0x61, 0x29, 0x06, 0xe0, 0xb8, 0x36, 0xca, 0x4f, 0xf3, 0x75,
-	0x52, 0xf8, 0x90, 0x80, 0xb5, 0x71, 0xdd, 0x7b, 0x7a, 0xb8, 0x07, 0xd9, 0x33, 0x34, 0x28, 0x6a,
-	0x53, 0xe9, 0x0f, 0x23, 0x67, 0x53, 0x86, 0x68, 0x3e, 0x51, 0xb4, 0xe1, 0x3a, 0xf1, 0x5a, 0xf2,
-	0x4e, 0x02, 0xf0, 0xc1, 0xb9, 0x38, 0x66, 0x2e, 0xe0, 0x60, 0x86, 0x00, 0xa2, 0xf1, 0x22, 0xf6,
-	0x48, 0x2a, 0x54, 0x44, 0x20, 0x99, 0x1b, 0xc4, 0x31, 0x33, 0xbe, 0x97, 0x60, 0x51, 0x6c, 0x72,
-	0x3c, 0x98, 0x78, 0x12, 0xa3, 0xfb, 0x3e, 0x72, 0x40, 0xce, 0x79, 0x05, 0x45, 0x92, 0x0b, 0x43,
-	0x3d, 0x84, 0xaf, 0x81, 0xbe, 0xe6, 0x6d, 0x76, 0xd7, 0xab, 0x88, 0x64, 0xa7, 0xba, 0xa1, 0x01,
-	0xf2, 0x89, 0x6e, 0x19, 0xb4, 0xf9, 0xf5, 0xfa, 0xc8, 0xf0, 0xda, 0x70, 0x3f, 0x35, 0x0a, 0x5a,
-	0xe9, 0xe3, 0xa3, 0x24, 0xd6, 0xc9, 0xaf, 0x13, 0x7b, 0xf0, 0xcc, 0x55, 0x94, 0x3d, 0x8c, 0xb5,
-	0x68, 0x46, 0x23, 0xc9, 0x0a, 0xaf, 0xe4, 0x3b, 0x0c, 0x0f, 0x0b, 0x76, 0x66, 0x5c, 0x3a, 0x33,
-	0x4d, 0x86, 0xe0, 0x8e, 0xe3, 0xdc, 0xfb, 0xdf, 0x54, 0xb3, 0x3b, 0x1c, 0x77, 0x03, 0xd7, 0x9f,
-	0xe2, 0x0a, 0xd5, 0x22, 0x0b, 0x2d, 0xa7, 0x99, 0xc5, 0x11, 0x75, 0xd2, 0x02, 0x95, 0xac, 0x86,
-	0x51, 0x43, 0x8b, 0xaa, 0xf0, 0x51, 0x82, 0xc4, 0x71, 0xa5, 0x65, 0xf2, 0xf5, 0x70, 0x0d, 0xf2,
-	0x05, 0xbf, 0x48, 0x23, 0x6f, 0x93, 0xdd, 0x89, 0x84, 0xfd, 0xdb, 0x99, 0xa4, 0x38, 0x28, 0x60,
-	0x42, 0xab, 0x73, 0xc3, 0x3d, 0x5e, 0xc2, 0xe2, 0x95, 0xff, 0x62, 0x17, 0x99, 0x79, 0xe7, 0x99,
-	0xcc, 0xc1, 0xcb, 0x60, 0xd1, 0xaa, 0xda, 0xa1, 0xac, 0xc2, 0xfc, 0x97, 0x72, 0x93, 0x1c, 0x60,
-	0x97, 0x65, 0x9e, 0xef, 0xf0, 0x4b, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8f, 0xab, 0xd0, 0x49, 0xeb,
-	0x0a, 0x00, 0x00,
+	// 1495 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x6f, 0x13, 0x47,
+	0x10, 0xe7, 0x92, 0xf8, 0x62, 0x8f, 0x9d, 0x34, 0x6c, 0x12, 0x63, 0x1c, 0x08, 0x66, 0x69, 0x45,
+	0x30, 0xad, 0x2f, 0x24, 0x52, 0x55, 0xb9, 0x2a, 0x52, 0x48, 0x10, 0x58, 0xa2, 0x82, 0x5e, 0x52,
+	0x90, 0xa2, 0x3e, 0xb0, 0xf1, 0x6d, 0x9c, 0x23, 0xf6, 0xdd, 0x71, 0xb7, 0x17, 0x30, 0x69, 0x5a,
+	0x89, 0xb7, 0xbe, 0x55, 0xe2, 0x91, 0x87, 0x7e, 0xa8, 0x7e, 0x85, 0x3e, 0xf5, 0x3b, 0x54, 0xaa,
+	0x76, 0x6f, 0xcf, 0xbe, 0xb3, 0x73, 0xf6, 0x99, 0xa6, 0x0f, 0x10, 0xef, 0x7a, 0x66, 0x7e, 0x33,
+	0xb3, 0xf3, 0xd7, 0x70, 0xdd, 0x39, 0x6e, 0x69, 0xc4, 0x31, 0x3d, 0xea, 0x9e, 0x50, 0xb7, 0xff,
+	0xa9, 0xe6, 0xb8, 0x36, 0xb3, 0xd1, 0xca, 0xa1, 0xe9, 0x79, 0xa6, 0x6d, 0xd5, 0xde, 0xd8, 0xee,
+	0xf1, 0x61, 0xdb, 0x7e, 0xe3, 0xd5, 0x7a, 0x24, 0xe5, 0x7a, 0xcb, 0x64, 0x47, 0xfe, 0x41, 0xad,
+	0x69, 0x77, 0x34, 0x49, 0x17, 0xfe, 0xfd, 0xaa, 0x47, 0xaf, 0x71, 0x00, 0xd6, 0x75, 0xa8, 0x17,
+	0xfc, 0x1f, 0x08, 0x2e, 0x3f, 0xf9, 0x04, 0x5e, 0xe3, 0x84, 0xb4, 0xfd, 0xf8, 0x67, 0x29, 0xed,
+	0x7e, 0x6a, 0x69, 0x27, 0xd4, 0x15, 0xdf, 0xca, 0xbf, 0x92, 0xff, 0xeb, 0xd4, 0xfc, 0x87, 0xd4,
+	0xe3, 0xff, 0x24, 0xdf, 0x4a, 0xcb, 0xb6, 0x5b, 0x6d, 0xaa, 0x89, 0xd3, 0x81, 0x7f, 0xa8, 0xd1,
+	0x8e, 0xc3, 0xba, 0xf2, 0xcb, 0xd5, 0xc1, 0x2f, 0x0d, 0xdf, 0x25, 0xac, 0x0f, 0x7a, 0x63, 0xf0,
+	0x7b, 0x66, 0x76, 0xa8, 0xc7, 0x48, 0xc7, 0x91, 0x04, 0xd7, 0x24, 0x01, 0x71, 0x4c, 0x8d, 0x58,
+	0x96, 0xcd, 0x04, 0xb7, 0xc4, 0xc6, 0x5f, 0x42, 0xe1, 0x85, 0x54, 0xed, 0x89, 0xe9, 0x31, 0x74,
+	0x0d, 0x72, 0x3d, 0x55, 0x4b, 0x4a, 0x65, 0x7a, 0x2d, 0xa7, 0xf7, 0x2f, 0x70, 0x0b, 0xe6, 0xb7,
+	0x0c, 0x63, 0x8f, 0x78, 0xc7, 0x3a, 0x7d, 0xed, 0x53, 0x8f, 0x21, 0x0c, 0x05, 0xd3, 0x3a, 0xb1,
+	0x9b, 0x42, 0x68, 0x63, 0xa7, 0xa4, 0x54, 0x94, 0xb5, 0x9c, 0x1e, 0xbb, 0x43, 0xf7, 0x60, 0x86,
+	0x11, 0xef, 0xb8, 0x34, 0x55, 0x51, 0xd6, 0xf2, 0x1b, 0xd7, 0x6b, 0xc3, 0xd1, 0x10, 0xbc, 0xa9,
+	0x90, 0x2b, 0x48, 0xb1, 0x0b, 0x73, 0xbb, 0x66, 0xcb, 0x22, 0xed, 0x10, 0x67, 0x1e, 0xa6, 0x4c,
+	0x43, 0x4a, 0x9f, 0x32, 0x0d, 0x84, 0x60, 0xc6, 0x22, 0x1d, 0x2a, 0x64, 0xe6, 0x74, 0xf1, 0x19,
+	0x7d, 0x07, 0xb3, 0x0e, 0xe9, 0xb6, 0x6d, 0x62, 0x94, 0xa6, 0x05, 0xd4, 0xad, 0x64, 0x28, 0xfe,
+	0xf8, 0xcf, 0xf9, 0xe3, 0xeb, 0x21, 0x0f, 0xbe, 0x0f, 0x05, 0x9d, 0xba, 0xbe, 0x95, 0x04, 0xb9,
+	0x0a, 0x10, 0x8a, 0x69, 0x18, 0x12, 0x38, 0x72, 0x83, 0x37, 0x61, 0xb1, 0xd1, 0x33, 0x9b, 0x3b,
+	0xf3, 0x07, 0x9f, 0xba, 0xdd, 0x31, 0x1e, 0xad, 0x43, 0x31, 0xf4, 0x7f, 0x9c, 0x19, 0x55, 0x20,
+	0xdf, 0xf7, 0x62, 0xc8, 0x19, 0xbd, 0xc2, 0x6f, 0xa1, 0xf0, 0x88, 0xb2, 0x2d, 0x96, 0xa4, 0x70,
+	0x11, 0x32, 0xa6, 0x65, 0xd0, 0xb7, 0x42, 0xd7, 0xe9, 0xc7, 0x97, 0xf4, 0xe0, 0x88, 0xea, 0x90,
+	0xeb, 0x05, 0x89, 0xf4, 0x54, 0xb9, 0x16, 0x44, 0x49, 0x2d, 0x0c, 0xa3, 0xda, 0x5e, 0x48, 0xf1,
+	0xf8, 0x92, 0xde, 0x27, 0x7f, 0x30, 0x03, 0x53, 0x84, 0xe1, 0xdf, 0x15, 0x28, 0x3c, 0x3d, 0x78,
+	0x45, 0x9b, 0xec, 0xe1, 0x09, 0xb5, 0x98, 0x87, 0xb6, 0x21, 0xdb, 0xa1, 0x8c, 0x18, 0x84, 0x11,
+	0xa1, 0x40, 0x7e, 0xe3, 0x76, 0xa2, 0xef, 0x03, 0xc6, 0xef, 0x25, 0xb9, 0xde, 0x63, 0x44, 0xdf,
+	0x82, 0x4a, 0x85, 0xb8, 0xd2, 0x54, 0x65, 0x3a, 0xe1, 0xf9, 0x02, 0x02, 0x66, 0xbb, 0xb4, 0x26,
+	0xa0, 0x75, 0xc9, 0x82, 0x2b, 0xa0, 0x3e, 0xa6, 0xa4, 0xcd, 0x8e, 0x50, 0x11, 0x54, 0x8f, 0x11,
+	0xe6, 0x7b, 0xd2, 0x15, 0xf2, 0x84, 0x7f, 0x53, 0x60, 0xee, 0xe1, 0x5b, 0xc7, 0x76, 0x7b, 0x0e,
+	0x5b, 0x82, 0x8c, 0x50, 0x49, 0x3a, 0x37, 0x38, 0xa0, 0x75, 0xc8, 0x78, 0xa6, 0xd5, 0xa4, 0xa5,
+	0xa9, 0x71, 0xae, 0xd1, 0x03, 0x42, 0xce, 0xe1, 0x5b, 0xcc, 0x6c, 0x8f, 0x77, 0xa6, 0x1e, 0x10,
+	0xe2, 0xdb, 0x30, 0xd7, 0xe8, 0x70, 0x55, 0x76, 0xfd, 0x4e, 0x87, 0xb8, 0x5d, 0xae, 0xb4, 0xb4,
+	0x9d, 0x2b, 0x3d, 0xdd, 0x33, 0x6b, 0x1b, 0x0a, 0x3b, 0x2e, 0x31, 0x7b, 0x41, 0xb9, 0x09, 0xb3,
+	0xfc, 0x31, 0x6c, 0x9f, 0x49, 0x3f, 0x5f, 0x1d, 0x02, 0xdb, 0x91, 0x05, 0x42, 0x0f, 0x29, 0x71,
+	0x07, 0xf2, 0x42, 0xc8, 0xae, 0x70, 0x04, 0x2a, 0xc1, 0xac, 0xc1, 0x8f, 0x34, 0x08, 0x96, 0xac,
+	0x1e, 0x1e, 0x79, 0x36, 0x3b, 0xd4, 0x32, 0x4c, 0xab, 0xc5, 0x73, 0xd1, 0x0b, 0x02, 0x47, 0x8f,
+	0xdd, 0x0d, 0xc6, 0xe5, 0xf4, 0x70, 0x5c, 0xde, 0x81, 0xfc, 0x9e, 0x6b, 0xb6, 0x5a, 0xd4, 0x15,
+	0x81, 0x5c, 0x86, 0x2c, 0x0b, 0x8e, 0xa1, 0xa3, 0x7b, 0x67, 0xfc, 0x12, 0x96, 0x7e, 0x74, 0x0c,
+	0xc2, 0xa8, 0x64, 0x48, 0x0a, 0xe5, 0x6f, 0x60, 0xc6, 0x73, 0x68, 0x53, 0x3e, 0xc9, 0xe7, 0xc9,
+	0x79, 0x1d, 0x88, 0xd9, 0x75, 0x68, 0x53, 0x17, 0x1c, 0x1b, 0x7f, 0xcf, 0x42, 0x3e, 0xcc, 0xb0,
+	0xad, 0x67, 0x0d, 0x64, 0x81, 0xba, 0xed, 0x52, 0xc2, 0x28, 0xfa, 0x22, 0x51, 0x4a, 0x48, 0xcf,
+	0xc5, 0x94, 0xd3, 0x06, 0x32, 0x5e, 0x7a, 0xff, 0xe7, 0x5f, 0x1f, 0xa6, 0xe6, 0x71, 0x4e, 0x0b,
+	0x09, 0xeb, 0x4a, 0x15, 0xbd, 0x06, 0x08, 0xf0, 0x76, 0xbb, 0x56, 0x33, 0x2d, 0xe6, 0xcd, 0xb1,
+	0x64, 0xf8, 0xaa, 0x40, 0x5b, 0xc4, 0xf3, 0x3d, 0x34, 0xcd, 0xeb, 0x5a, 0x4d, 0x0e, 0xf9, 0x13,
+	0xcc, 0x08, 0xc7, 0x17, 0x87, 0x42, 0xe3, 0x21, 0x6f, 0x2c, 0xe5, 0x3b, 0xb5, 0x11, 0xfd, 0xb8,
+	0x16, 0x6d, 0x07, 0xf8, 0xb2, 0x40, 0xc9, 0xa3, 0xbe, 0x4d, 0xc8, 0x84, 0xe9, 0x47, 0x94, 0xa1,
+	0xb4, 0x6e, 0x49, 0x63, 0x4b, 0x51, 0xa0, 0x2c, 0xa0, 0x88, 0x2d, 0xa7, 0xa6, 0x71, 0x86, 0x08,
+	0xa8, 0x3b, 0xb4, 0x4d, 0x19, 0x4d, 0x8f, 0x96, 0x60, 0x73, 0x08, 0x51, 0x1d, 0x84, 0x38, 0x82,
+	0xec, 0x73, 0xd2, 0x36, 0x8d, 0x09, 0x02, 0x22, 0x09, 0xe2, 0xba, 0x80, 0xb8, 0x82, 0x51, 0x1f,
+	0xe2, 0x44, 0x8a, 0xe6, 0xaf, 0x72, 0x0a, 0xaa, 0x2c, 0x96, 0xa9, 0x8d, 0x19, 0xfd, 0x50, 0xd1,
+	0x02, 0x1c, 0x82, 0xa3, 0xe5, 0xb8, 0x7d, 0x5a, 0x50, 0x46, 0x90, 0x03, 0x19, 0xd1, 0x2a, 0xd0,
+	0x68, 0x91, 0xd1, 0x76, 0x32, 0x41, 0x10, 0xa2, 0xcb, 0x03, 0xa8, 0x84, 0x21, 0x0f, 0x32, 0x2f,
+	0x08, 0x6b, 0x1e, 0x5d, 0x68, 0xa0, 0x5c, 0x13, 0x78, 0x45, 0xb4, 0x34, 0x80, 0xf7, 0x86, 0x23,
+	0xad, 0x2b, 0x1b, 0xff, 0xcc, 0xc1, 0xf2, 0x70, 0x3b, 0xe5, 0x69, 0xff, 0x0e, 0x54, 0x7e, 0x71,
+	0x4c, 0x91, 0x36, 0x16, 0xa6, 0xcf, 0x39, 0x59, 0x01, 0x90, 0x31, 0x56, 0x57, 0xaa, 0x38, 0xaf,
+	0xf5, 0x0b, 0x22, 0xfa, 0xa8, 0x00, 0x04, 0xe0, 0xa2, 0x06, 0x4c, 0xac, 0xc0, 0xdd, 0x09, 0x18,
+	0xb0, 0x26, 0x94, 0xb8, 0x83, 0x17, 0x22, 0x1a, 0x84, 0x95, 0x61, 0x1f, 0xa1, 0xa1, 0x6b, 0xf4,
+	0x87, 0x02, 0xb3, 0x72, 0xa8, 0x43, 0x77, 0x47, 0x46, 0x47, 0x7c, 0xf4, 0x4b, 0xcc, 0x83, 0xa7,
+	0x42, 0x83, 0xc6, 0x3e, 0x2e, 0x57, 0xa2, 0x60, 0xa7, 0xd1, 0x99, 0xf0, 0x4c, 0xe3, 0x63, 0x9e,
+	0x87, 0xc7, 0x52, 0xf0, 0xcc, 0x69, 0x82, 0xba, 0x4d, 0xac, 0x26, 0x6d, 0xff, 0xf7, 0x32, 0x50,
+	0x12, 0xba, 0xa1, 0xea, 0x42, 0x1c, 0x57, 0x14, 0x82, 0xcc, 0x33, 0xe2, 0x7b, 0x17, 0x50, 0x6a,
+	0x56, 0x05, 0x46, 0x09, 0x17, 0x07, 0x31, 0x34, 0x47, 0x00, 0xbc, 0x02, 0x55, 0xa7, 0x9e, 0xdf,
+	0xb9, 0x00, 0xa8, 0x1b, 0x02, 0xea, 0x2a, 0xbe, 0x32, 0x04, 0xe5, 0x06, 0x08, 0x67, 0xa0, 0x06,
+	0x73, 0x34, 0xaa, 0x8e, 0x7c, 0xda, 0xd8, 0xb0, 0x9d, 0x08, 0x17, 0xc6, 0xd6, 0xea, 0x10, 0x9c,
+	0x27, 0xf8, 0xb5, 0x53, 0x3e, 0x87, 0x9f, 0xd5, 0xc3, 0x91, 0x1a, 0xfd, 0x02, 0x19, 0x31, 0x52,
+	0x8f, 0x29, 0x3b, 0xd1, 0xb1, 0x3b, 0x7d, 0xba, 0xdd, 0x14, 0xda, 0xac, 0xf0, 0x74, 0x2b, 0x9e,
+	0x63, 0x3f, 0x87, 0x7d, 0xaf, 0xc8, 0x56, 0xb8, 0x3e, 0x12, 0xff, 0x9c, 0xb1, 0xbd, 0xbc, 0x99,
+	0xaa, 0x49, 0xc6, 0x39, 0xf1, 0xa2, 0x50, 0x69, 0x0e, 0xc5, 0xd2, 0xdf, 0x9f, 0xb0, 0x61, 0x4e,
+	0x94, 0xee, 0x32, 0xa0, 0xd1, 0x70, 0x40, 0x9f, 0xfd, 0xaf, 0xfd, 0x46, 0x46, 0x1e, 0x1a, 0x8e,
+	0x3c, 0xd9, 0x71, 0x7e, 0xfe, 0x84, 0x8e, 0x33, 0x91, 0xe5, 0x2b, 0x42, 0x83, 0x65, 0xb4, 0x38,
+	0xa4, 0x01, 0x61, 0xe8, 0xd7, 0x89, 0xbb, 0xcf, 0x44, 0xd8, 0x32, 0xc5, 0xd1, 0x70, 0xdc, 0xc9,
+	0x4e, 0x84, 0x58, 0x64, 0xae, 0x98, 0xb8, 0xe0, 0x8f, 0x49, 0x77, 0x1e, 0xf1, 0x4b, 0x51, 0xe4,
+	0x70, 0xcc, 0xd8, 0xf8, 0x38, 0x03, 0xd9, 0x2d, 0xa3, 0x63, 0x8a, 0x96, 0xf7, 0x02, 0x54, 0x39,
+	0xf0, 0x27, 0x0d, 0x82, 0xb7, 0x46, 0x3e, 0x4d, 0xb0, 0x4e, 0xe1, 0x05, 0x01, 0x0a, 0x28, 0xab,
+	0x1d, 0x89, 0x8b, 0x77, 0x68, 0x0f, 0x66, 0x9f, 0x07, 0x3f, 0x7c, 0x24, 0x4a, 0xbe, 0x71, 0x8e,
+	0xe4, 0xf0, 0xc7, 0x92, 0x86, 0x75, 0x68, 0x47, 0xa4, 0xca, 0x6b, 0xe4, 0x82, 0x1a, 0x6c, 0x67,
+	0x63, 0x4a, 0x55, 0x6c, 0x85, 0x2b, 0xa7, 0xd9, 0x11, 0xf1, 0xb2, 0x00, 0xfb, 0x0c, 0xcd, 0x69,
+	0x84, 0x3b, 0x47, 0xa3, 0x42, 0xc4, 0xba, 0x82, 0x7c, 0x50, 0x83, 0x35, 0x0c, 0xa5, 0x91, 0x53,
+	0x1e, 0xad, 0x58, 0x6c, 0xa1, 0x0b, 0x13, 0x93, 0xbf, 0x55, 0x08, 0x6b, 0x0a, 0x82, 0x35, 0x0e,
+	0x9b, 0x11, 0xfb, 0xd8, 0x98, 0xdc, 0x88, 0x2e, 0x7e, 0xe5, 0xb5, 0xf1, 0xa4, 0xc1, 0x6b, 0xe3,
+	0x2b, 0x02, 0xf9, 0x32, 0x2e, 0x48, 0x58, 0xb1, 0xdc, 0xd5, 0x95, 0xea, 0xc6, 0x87, 0x19, 0x00,
+	0xb9, 0x20, 0xf1, 0xf8, 0x68, 0xf7, 0x36, 0xa1, 0x54, 0xfb, 0x54, 0xfa, 0xc2, 0x2c, 0xab, 0x20,
+	0xce, 0x6a, 0x72, 0xcf, 0xe3, 0x4d, 0x7c, 0x7f, 0xcc, 0x52, 0x32, 0xda, 0xbe, 0xc8, 0x3e, 0x19,
+	0x09, 0x1d, 0x29, 0x1e, 0xb5, 0x26, 0xac, 0xb0, 0x95, 0x71, 0xf6, 0x46, 0x22, 0x46, 0x62, 0x04,
+	0x35, 0xd5, 0x01, 0x35, 0x58, 0x57, 0xd1, 0xbd, 0x91, 0xea, 0x9e, 0xb7, 0xd3, 0x26, 0x66, 0xb5,
+	0x2c, 0x64, 0xe5, 0x38, 0x56, 0x5d, 0xac, 0xaf, 0xe8, 0xe5, 0xc5, 0xad, 0x40, 0xd2, 0xa6, 0x6a,
+	0x1c, 0xe7, 0x41, 0x7e, 0x3f, 0xd7, 0x53, 0xfc, 0x40, 0x15, 0x3c, 0x9b, 0xff, 0x0e, 0x00, 0x9f,
+	0x9a, 0x7d, 0x4e, 0xd1, 0x15, 0x00, 0x00,
 }
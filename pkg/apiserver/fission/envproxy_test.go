@@ -58,6 +58,14 @@ func (m *mockWorkflowClient) Events(ctx context.Context, in *types.ObjectMetadat
 	panic("implement me")
 }
 
+func (m *mockWorkflowClient) GetAt(ctx context.Context, in *apiserver.GetAtRequest, opts ...grpc.CallOption) (*types.Workflow, error) {
+	panic("implement me")
+}
+
+func (m *mockWorkflowClient) Watch(ctx context.Context, in *types.ObjectMetadata, opts ...grpc.CallOption) (apiserver.WorkflowAPI_WatchClient, error) {
+	panic("implement me")
+}
+
 func TestProxy_Specialize(t *testing.T) {
 	workflowServer := &mockWorkflowClient{}
 	workflowServer.On("CreateSync", mock.Anything).Return(&types.Workflow{
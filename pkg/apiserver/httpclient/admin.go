@@ -2,10 +2,17 @@ package httpclient
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/version"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 )
 
 type AdminAPI struct {
@@ -32,3 +39,80 @@ func (api *AdminAPI) Version(ctx context.Context) (*version.Info, error) {
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/version"), nil, result)
 	return result, err
 }
+
+// Export streams the events that match the request from the event store, passing each event to fn.
+func (api *AdminAPI) Export(ctx context.Context, req *apiserver.ExportRequest, fn func(event *fes.Event) error) error {
+	query := url.Values{}
+	for _, t := range req.GetTypes() {
+		query.Add("types", t)
+	}
+	if req.GetSince() != nil {
+		since, err := ptypes.Timestamp(req.GetSince())
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrSerialize, err)
+		}
+		query.Set("since", since.Format(time.RFC3339Nano))
+	}
+	if req.GetUntil() != nil {
+		until, err := ptypes.Timestamp(req.GetUntil())
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrSerialize, err)
+		}
+		query.Set("until", until.Format(time.RFC3339Nano))
+	}
+
+	resp, err := callWithStream(ctx, http.MethodGet, api.formatURL("/admin/export?"+query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return readStream(resp.Body, func() proto.Message {
+		return &fes.Event{}
+	}, func(msg proto.Message) error {
+		return fn(msg.(*fes.Event))
+	})
+}
+
+// Import streams the events returned by next to the event store, until next returns io.EOF.
+func (api *AdminAPI) Import(ctx context.Context, next func() (*fes.Event, error)) (*apiserver.ImportSummary,
+	error) {
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			event, err := next()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			if err := toJSON(pw, event); err != nil {
+				pw.CloseWithError(fmt.Errorf("%v: %v", ErrSerialize, err))
+				return
+			}
+			if _, err := pw.Write([]byte("\n")); err != nil {
+				return
+			}
+		}
+	}()
+
+	resp, err := callWithStream(ctx, http.MethodPost, api.formatURL("/admin/import"), pr)
+	pr.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result := &apiserver.ImportSummary{}
+	if err := fromJSON(resp.Body, result); err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrDeserialize, err)
+	}
+	return result, nil
+}
+
+// Drain drains the workflow engine, blocking until the running tasks have finished or the timeout has passed.
+func (api *AdminAPI) Drain(ctx context.Context, req *apiserver.DrainRequest) (*apiserver.DrainStatus, error) {
+	result := &apiserver.DrainStatus{}
+	err := callWithJSON(ctx, http.MethodPost, api.formatURL("/admin/drain"), req, result)
+	return result, err
+}
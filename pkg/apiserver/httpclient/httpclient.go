@@ -4,15 +4,21 @@ package httpclient
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 )
@@ -104,11 +110,96 @@ func callWithJSON(ctx context.Context, method string, url string, in proto.Messa
 	return nil
 }
 
+// callWithStream sends a request with the (optionally streamed) body and returns the response for the caller to
+// consume, which allows both the request and the response body to be streamed. The caller is responsible for closing
+// the response body.
+func callWithStream(ctx context.Context, method string, url string, body io.Reader) (*http.Response, error) {
+	logrus.Debugf("--> %s %s (streaming)", method, url)
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrRequestCreate, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// If set, inject opentracing into HTTP request
+	span := opentracing.SpanFromContext(ctx)
+	if span != nil {
+		carrier := opentracing.HTTPHeadersCarrier(req.Header)
+		opentracing.GlobalTracer().Inject(span.Context(), opentracing.HTTPHeaders, carrier)
+	}
+
+	resp, err := defaultHTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", ErrRequestSend, err)
+	}
+	logrus.Debugf("<-- %s - %s", resp.Status, url)
+
+	// Handle error responses
+	if resp.StatusCode >= 400 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%v (%s): %s", ErrResponseError, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return resp, nil
+}
+
+// streamChunk is a single message in a streamed response of the HTTP gateway, which contains either a result or an
+// error.
+type streamChunk struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		HTTPCode int    `json:"http_code"`
+		Message  string `json:"message"`
+	} `json:"error"`
+}
+
+// readStream reads the chunks of a streamed response, parsing each result into a message created by newMsg and
+// passing it to fn.
+func readStream(r io.Reader, newMsg func() proto.Message, fn func(msg proto.Message) error) error {
+	dec := json.NewDecoder(r)
+	for {
+		chunk := &streamChunk{}
+		err := dec.Decode(chunk)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrDeserialize, err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("%v (%d): %s", ErrResponseError, chunk.Error.HTTPCode, chunk.Error.Message)
+		}
+		msg := newMsg()
+		if err := fromJSON(bytes.NewReader(chunk.Result), msg); err != nil {
+			return fmt.Errorf("%v: %v", ErrDeserialize, err)
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+}
+
 type baseAPI struct {
 	endpoint string
 	client   http.Client
 }
 
+// formatGetAtURL returns the URL of the point-in-time read of the entity at the path.
+func (api *baseAPI) formatGetAtURL(path string, req *apiserver.GetAtRequest) (string, error) {
+	query := url.Values{}
+	switch at := req.GetAt().(type) {
+	case *apiserver.GetAtRequest_Index:
+		query.Set("index", strconv.FormatInt(at.Index, 10))
+	case *apiserver.GetAtRequest_Timestamp:
+		ts, err := ptypes.Timestamp(at.Timestamp)
+		if err != nil {
+			return "", fmt.Errorf("%v: %v", ErrSerialize, err)
+		}
+		query.Set("timestamp", ts.Format(time.RFC3339Nano))
+	}
+	return api.formatURL(path + "/" + req.GetId() + "/at?" + query.Encode()), nil
+}
+
 func (api *baseAPI) formatURL(path string) string {
 	return api.endpoint + path
 }
@@ -6,6 +6,8 @@ import (
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/proto"
 )
 
 type InvocationAPI struct {
@@ -39,6 +41,30 @@ func (api *InvocationAPI) Cancel(ctx context.Context, id string) error {
 	return callWithJSON(ctx, http.MethodDelete, api.formatURL("/invocation/"+id), nil, nil)
 }
 
+func (api *InvocationAPI) Pause(ctx context.Context, id string) error {
+	return callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/pause"), nil, nil)
+}
+
+func (api *InvocationAPI) Resume(ctx context.Context, id string) error {
+	return callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/resume"), nil, nil)
+}
+
+func (api *InvocationAPI) Signal(ctx context.Context, id string, name string,
+	payload *typedvalues.TypedValue) error {
+	// The payload is sent as the body of the request, which cannot be empty.
+	if payload == nil {
+		payload = &typedvalues.TypedValue{}
+	}
+	return callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/signal/"+name), payload, nil)
+}
+
+func (api *InvocationAPI) Rerun(ctx context.Context, id string, workflowID string) (*types.ObjectMetadata, error) {
+	result := &types.ObjectMetadata{}
+	err := callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/rerun"),
+		&apiserver.RerunRequest{Id: id, WorkflowId: workflowID}, result)
+	return result, err
+}
+
 func (api *InvocationAPI) List(ctx context.Context) (*apiserver.WorkflowInvocationList, error) {
 	result := &apiserver.WorkflowInvocationList{}
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation"), nil, result)
@@ -60,3 +86,29 @@ func (api *InvocationAPI) Events(ctx context.Context, id string) (*apiserver.Obj
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation/"+id+"/events"), nil, result)
 	return result, err
 }
+
+// GetAt returns the invocation as it was at the event index or timestamp of the request.
+func (api *InvocationAPI) GetAt(ctx context.Context, req *apiserver.GetAtRequest) (*types.WorkflowInvocation, error) {
+	u, err := api.formatGetAtURL("/invocation", req)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.WorkflowInvocation{}
+	err = callWithJSON(ctx, http.MethodGet, u, nil, result)
+	return result, err
+}
+
+// Watch passes the current state of the invocation to fn, followed by every update to it, until the invocation has
+// finished, fn returns an error, or the context is canceled.
+func (api *InvocationAPI) Watch(ctx context.Context, id string, fn func(wfi *types.WorkflowInvocation) error) error {
+	resp, err := callWithStream(ctx, http.MethodGet, api.formatURL("/invocation/"+id+"/watch"), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return readStream(resp.Body, func() proto.Message {
+		return &types.WorkflowInvocation{}
+	}, func(msg proto.Message) error {
+		return fn(msg.(*types.WorkflowInvocation))
+	})
+}
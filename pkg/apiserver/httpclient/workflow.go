@@ -6,6 +6,7 @@ import (
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
 )
 
 type WorkflowAPI struct {
@@ -55,3 +56,29 @@ func (api *WorkflowAPI) Events(ctx context.Context, id string) (*apiserver.Objec
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/workflow/"+id+"/events"), nil, result)
 	return result, err
 }
+
+// GetAt returns the workflow as it was at the event index or timestamp of the request.
+func (api *WorkflowAPI) GetAt(ctx context.Context, req *apiserver.GetAtRequest) (*types.Workflow, error) {
+	u, err := api.formatGetAtURL("/workflow", req)
+	if err != nil {
+		return nil, err
+	}
+	result := &types.Workflow{}
+	err = callWithJSON(ctx, http.MethodGet, u, nil, result)
+	return result, err
+}
+
+// Watch passes the current state of the workflow to fn, followed by every update to it, until the workflow has been
+// deleted, fn returns an error, or the context is canceled.
+func (api *WorkflowAPI) Watch(ctx context.Context, id string, fn func(wf *types.Workflow) error) error {
+	resp, err := callWithStream(ctx, http.MethodGet, api.formatURL("/workflow/"+id+"/watch"), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return readStream(resp.Body, func() proto.Message {
+		return &types.Workflow{}
+	}, func(msg proto.Message) error {
+		return fn(msg.(*types.Workflow))
+	})
+}
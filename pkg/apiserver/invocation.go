@@ -16,6 +16,8 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Invocation is responsible for all functionality related to managing invocations.
@@ -25,15 +27,20 @@ type Invocation struct {
 	workflows   *store.Workflows
 	fnenv       *workflowFnenv.Runtime
 	backend     fes.Backend
+	drainer     Drainer
 }
 
-func NewInvocation(api *api.Invocation, invocations *store.Invocations, workflows *store.Workflows, backend fes.Backend) WorkflowInvocationAPIServer {
+// NewInvocation creates the invocation server. Once the drainer (if not nil) has started draining, the server no
+// longer accepts new invocations.
+func NewInvocation(api *api.Invocation, invocations *store.Invocations, workflows *store.Workflows,
+	backend fes.Backend, drainer Drainer) WorkflowInvocationAPIServer {
 	return &Invocation{
 		api:         api,
 		invocations: invocations,
 		workflows:   workflows,
 		fnenv:       workflowFnenv.NewRuntime(api, invocations, workflows),
 		backend:     backend,
+		drainer:     drainer,
 	}
 }
 
@@ -46,6 +53,9 @@ func (gi *Invocation) Validate(ctx context.Context, spec *types.WorkflowInvocati
 }
 
 func (gi *Invocation) Invoke(ctx context.Context, spec *types.WorkflowInvocationSpec) (*types.ObjectMetadata, error) {
+	if err := gi.ensureNotDraining(); err != nil {
+		return nil, err
+	}
 	// TODO go through same runtime as InvokeSync
 	// Check if the workflow required by the invocation exists
 	wf, err := gi.workflows.GetWorkflow(spec.GetWorkflowId())
@@ -63,6 +73,9 @@ func (gi *Invocation) Invoke(ctx context.Context, spec *types.WorkflowInvocation
 }
 
 func (gi *Invocation) InvokeSync(ctx context.Context, spec *types.WorkflowInvocationSpec) (*types.WorkflowInvocation, error) {
+	if err := gi.ensureNotDraining(); err != nil {
+		return nil, err
+	}
 	wfi, err := gi.fnenv.InvokeWorkflow(spec, fnenv.WithContext(ctx))
 	if err != nil {
 		return nil, toErrorStatus(err)
@@ -79,6 +92,102 @@ func (gi *Invocation) Cancel(ctx context.Context, objectMetadata *types.ObjectMe
 	return &empty.Empty{}, nil
 }
 
+func (gi *Invocation) Pause(ctx context.Context, objectMetadata *types.ObjectMetadata) (*empty.Empty, error) {
+	wi, err := gi.invocations.GetInvocation(objectMetadata.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if wi.GetStatus().Finished() || wi.GetStatus().Paused() {
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation cannot be paused in state %v",
+			wi.GetStatus().GetStatus())
+	}
+
+	err = gi.api.Pause(wi.ID(), api.WithExpectedGeneration(wi.Generation()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Resume(ctx context.Context, objectMetadata *types.ObjectMetadata) (*empty.Empty, error) {
+	wi, err := gi.invocations.GetInvocation(objectMetadata.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if !wi.GetStatus().Paused() {
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation cannot be resumed in state %v",
+			wi.GetStatus().GetStatus())
+	}
+
+	err = gi.api.Resume(wi.ID(), api.WithExpectedGeneration(wi.Generation()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Signal(ctx context.Context, req *SignalRequest) (*empty.Empty, error) {
+	wi, err := gi.invocations.GetInvocation(req.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if wi.GetStatus().Finished() {
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation cannot be signaled in state %v",
+			wi.GetStatus().GetStatus())
+	}
+	if _, ok := wi.GetStatus().GetSignals()[req.GetName()]; ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation has already received signal %s",
+			req.GetName())
+	}
+
+	// Over HTTP, a signal without a payload is sent as an empty payload.
+	payload := req.GetPayload()
+	if payload.GetValue() == nil {
+		payload = nil
+	}
+
+	err = gi.api.Signal(wi.ID(), req.GetName(), payload, api.WithExpectedGeneration(wi.Generation()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Rerun(ctx context.Context, req *RerunRequest) (*types.ObjectMetadata, error) {
+	if err := gi.ensureNotDraining(); err != nil {
+		return nil, err
+	}
+	wi, err := gi.invocations.GetInvocation(req.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	switch wi.GetStatus().GetStatus() {
+	case types.WorkflowInvocationStatus_FAILED, types.WorkflowInvocationStatus_ABORTED:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation cannot be rerun in state %v",
+			wi.GetStatus().GetStatus())
+	}
+
+	// By default, rerun the invocation against the same workflow as the original invocation
+	wf := wi.Workflow()
+	if len(req.GetWorkflowId()) > 0 {
+		wf, err = gi.workflows.GetWorkflow(req.GetWorkflowId())
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		if original := wi.Workflow(); original != nil && !wf.IsRevisionOf(original) {
+			return nil, status.Errorf(codes.InvalidArgument, "workflow %s is not a newer revision of workflow %s",
+				wf.ID(), original.ID())
+		}
+	}
+
+	invocationID, err := gi.api.Rerun(wi, wf, api.WithContext(ctx))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &types.ObjectMetadata{Id: invocationID}, nil
+}
+
 func (gi *Invocation) Get(ctx context.Context, objectMetadata *types.ObjectMetadata) (*types.WorkflowInvocation, error) {
 	wi, err := gi.invocations.GetInvocation(objectMetadata.GetId())
 	if err != nil {
@@ -156,6 +265,37 @@ func (gi *Invocation) Events(ctx context.Context, md *types.ObjectMetadata) (*Ob
 	}, nil
 }
 
+func (gi *Invocation) GetAt(ctx context.Context, req *GetAtRequest) (*types.WorkflowInvocation, error) {
+	entity, err := projectAt(gi.backend, projectors.NewWorkflowInvocation(), projectors.NewInvocationAggregate(req.GetId()),
+		req)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.WorkflowInvocation), nil
+}
+
+func (gi *Invocation) Watch(md *types.ObjectMetadata, stream WorkflowInvocationAPI_WatchServer) error {
+	updates, err := gi.invocations.WatchInvocation(stream.Context(), md.GetId())
+	if err != nil {
+		return toErrorStatus(err)
+	}
+	for wfi := range updates {
+		if err := stream.Send(wfi); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
+// ensureNotDraining returns an Unavailable error if the engine is draining, so that clients retry the request at
+// another replica.
+func (gi *Invocation) ensureNotDraining() error {
+	if gi.drainer != nil && gi.drainer.Draining() {
+		return status.Error(codes.Unavailable, "workflow engine is draining; not accepting new invocations")
+	}
+	return nil
+}
+
 func (gi *Invocation) taskEvents(taskRunID string) ([]*fes.Event, error) {
 	return gi.backend.Get(projectors.NewTaskRunAggregate(taskRunID))
 }
@@ -0,0 +1,45 @@
+package apiserver
+
+import (
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// MIMEEventStream is the media type of server-sent events.
+const MIMEEventStream = "text/event-stream"
+
+// EventStreamMarshaler is a marshaler for the HTTP gateway that writes each message of a streaming response as a
+// server-sent event (SSE). This allows the Watch APIs to be consumed by browsers using an EventSource. It is selected
+// by the gateway for requests that accept 'text/event-stream'.
+//
+// The data of each event is the JSON-encoded stream chunk, which contains either the message (in 'result') or the
+// error that ended the stream (in 'error').
+type EventStreamMarshaler struct {
+	runtime.JSONPb
+}
+
+func NewEventStreamMarshaler() *EventStreamMarshaler {
+	return &EventStreamMarshaler{
+		JSONPb: runtime.JSONPb{OrigName: true},
+	}
+}
+
+func (m *EventStreamMarshaler) ContentType() string {
+	return MIMEEventStream
+}
+
+func (m *EventStreamMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	event := make([]byte, 0, len(data)+8)
+	event = append(event, "data: "...)
+	event = append(event, data...)
+	return append(event, '\n', '\n'), nil
+}
+
+// Delimiter returns an empty delimiter, because each marshaled message already ends with the blank line that
+// terminates a server-sent event.
+func (m *EventStreamMarshaler) Delimiter() []byte {
+	return nil
+}
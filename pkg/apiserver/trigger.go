@@ -0,0 +1,91 @@
+package apiserver
+
+import (
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Trigger is responsible for all functionality related to managing triggers.
+type Trigger struct {
+	api     *api.Trigger
+	store   *store.Triggers
+	backend fes.Backend
+}
+
+func NewTrigger(api *api.Trigger, store *store.Triggers, backend fes.Backend) *Trigger {
+	return &Trigger{
+		api:     api,
+		store:   store,
+		backend: backend,
+	}
+}
+
+func (gt *Trigger) Create(ctx context.Context, spec *types.TriggerSpec) (*types.ObjectMetadata, error) {
+	id, err := gt.api.Create(spec, api.WithContext(ctx))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &types.ObjectMetadata{Id: id}, nil
+}
+
+func (gt *Trigger) Get(ctx context.Context, triggerID *types.ObjectMetadata) (*types.Trigger, error) {
+	trigger, err := gt.store.GetTrigger(triggerID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return trigger, nil
+}
+
+// List returns the ids of all triggers in the event store, since the cache only contains the triggers that have
+// been accessed before.
+func (gt *Trigger) List(ctx context.Context, req *empty.Empty) (*TriggerList, error) {
+	keys, err := gt.backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeTrigger
+	})
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	var results []string
+	for _, key := range keys {
+		results = append(results, key.Id)
+	}
+	return &TriggerList{Triggers: results}, nil
+}
+
+func (gt *Trigger) Update(ctx context.Context, req *UpdateTriggerRequest) (*empty.Empty, error) {
+	trigger, err := gt.store.GetTrigger(req.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if !trigger.GetStatus().Active() {
+		return nil, status.Errorf(codes.FailedPrecondition, "trigger cannot be updated in state %v",
+			trigger.GetStatus().GetStatus())
+	}
+	err = gt.api.Update(req.GetId(), req.GetSpec(), api.WithExpectedGeneration(trigger.Generation()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (gt *Trigger) Delete(ctx context.Context, triggerID *types.ObjectMetadata) (*empty.Empty, error) {
+	trigger, err := gt.store.GetTrigger(triggerID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if !trigger.GetStatus().Active() {
+		return nil, status.Errorf(codes.FailedPrecondition, "trigger cannot be deleted in state %v",
+			trigger.GetStatus().GetStatus())
+	}
+	err = gt.api.Delete(triggerID.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
@@ -115,6 +115,27 @@ func (ga *Workflow) Validate(ctx context.Context, spec *types.WorkflowSpec) (*em
 	return &empty.Empty{}, nil
 }
 
+func (ga *Workflow) GetAt(ctx context.Context, req *GetAtRequest) (*types.Workflow, error) {
+	entity, err := projectAt(ga.backend, projectors.NewWorkflow(), projectors.NewWorkflowAggregate(req.GetId()), req)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.Workflow), nil
+}
+
+func (ga *Workflow) Watch(md *types.ObjectMetadata, stream WorkflowAPI_WatchServer) error {
+	updates, err := ga.store.WatchWorkflow(stream.Context(), md.GetId())
+	if err != nil {
+		return toErrorStatus(err)
+	}
+	for wf := range updates {
+		if err := stream.Send(wf); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
 func (ga *Workflow) Events(ctx context.Context, md *types.ObjectMetadata) (*ObjectEvents, error) {
 	events, err := ga.backend.Get(projectors.NewWorkflowAggregate(md.Id))
 	if err != nil {
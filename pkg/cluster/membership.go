@@ -0,0 +1,417 @@
+// Package cluster divides the work among multiple replicas of the workflow engine that share an event store.
+//
+// Every replica keeps a lease in its own event stream in the event store, which it renews periodically. The replicas
+// that hold an unexpired lease form a consistent hash ring, which determines the replica that owns (and thus processes)
+// an invocation based on its id. If a replica stops, or fails to renew its lease, the ring is recomputed by the other
+// replicas, which take over its invocations. The oldest live replica acts as the leader, which marks the replicas with
+// an expired lease as having left the cluster.
+//
+// Leases are compared to the local clock of each replica, so the clocks of the replicas should be reasonably in sync
+// compared to the lease duration. While the ownership of an invocation moves to another replica, both replicas can
+// briefly evaluate the invocation; the events of the controllers are guarded against concurrent modifications, so
+// only one of the replicas is able to append the results of a task.
+//
+// Since every renewal of a lease is an event, a replica replaces its event stream with a single event once it has
+// grown large, and the leader purges the replicas that have left the cluster.
+//
+// As a result, the membership requires an event store backend that checks the expected generation of events for all
+// of its clients (fes.ConditionalAppender), and that is able to replace (fes.Replacer) and purge (fes.Purger) event
+// streams. ValidateBackend checks this before the membership is started. The NATS backend does neither, so it
+// cannot be used to divide the invocations among replicas.
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultLeaseDuration = 15 * time.Second
+
+	// maxReplicaEvents is the number of events in the event stream of a replica, after which the replica compacts its
+	// event stream.
+	maxReplicaEvents = 100
+)
+
+var (
+	members = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "cluster",
+		Name:      "members",
+		Help:      "Number of live replicas in the cluster, as observed by this replica.",
+	})
+
+	leader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "cluster",
+		Name:      "leader",
+		Help:      "Whether this replica is the leader of the cluster (1) or not (0).",
+	})
+
+	rebalances = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "cluster",
+		Name:      "rebalances_total",
+		Help:      "Number of times that the invocations were divided anew, because replicas joined or left.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(members, leader, rebalances)
+}
+
+// Config contains the user-configurable options of the membership of a replica.
+type Config struct {
+	// ID identifies the replica. A replica that restarts with the same id takes over its previous lease. If empty,
+	// the hostname is used, so multiple replicas on the same host should be configured with an id explicitly.
+	ID string
+
+	// LeaseDuration is the time after which a replica that did not renew its lease is considered to be dead. It bounds
+	// how long the invocations of a dead replica remain unprocessed. If 0 or less, DefaultLeaseDuration is used.
+	LeaseDuration time.Duration
+
+	// RenewInterval is the time between two renewals of the lease, which is also the interval at which the replica
+	// refreshes its view of the cluster. If 0 or less, a third of the lease duration is used.
+	RenewInterval time.Duration
+
+	// VirtualNodes is the number of points that each replica occupies on the hash ring. If 0 or less,
+	// DefaultVirtualNodes is used.
+	VirtualNodes int
+}
+
+// Membership tracks the live replicas in the cluster, and determines which of the entities are owned by this replica.
+// It implements ctrl.Partition.
+type Membership struct {
+	Config
+	backend    fes.Backend
+	replicas   *store.Replicas
+	replicaAPI *api.Replica
+
+	mu             sync.RWMutex
+	ring           *Ring
+	leader         bool
+	joined         bool
+	leaseExpiresAt time.Time
+
+	closeC    chan struct{}
+	closeOnce sync.Once
+}
+
+// ValidateBackend checks whether the backend supports the membership of replicas: it should guard conditional appends
+// and be able to replace and purge event streams. Without these, ownership changes could lead to conflicting events,
+// and the event streams of the replicas would grow without bound.
+func ValidateBackend(backend fes.Backend) error {
+	if !fes.AppendsConditionally(backend) {
+		return fmt.Errorf("event store %T does not guard against concurrent modifications", backend)
+	}
+	if _, ok := backend.(fes.Replacer); !ok {
+		return fmt.Errorf("event store %T does not support replacing event streams", backend)
+	}
+	if _, ok := backend.(fes.Purger); !ok {
+		return fmt.Errorf("event store %T does not support purging event streams", backend)
+	}
+	return nil
+}
+
+func NewMembership(backend fes.Backend, cfg Config, replicas *store.Replicas, replicaAPI *api.Replica) *Membership {
+	if len(cfg.ID) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil || len(hostname) == 0 {
+			hostname = fmt.Sprintf("replica-%s", util.UID())
+		}
+		cfg.ID = hostname
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = DefaultLeaseDuration
+	}
+	if cfg.RenewInterval <= 0 {
+		cfg.RenewInterval = cfg.LeaseDuration / 3
+	}
+	return &Membership{
+		Config:     cfg,
+		backend:    backend,
+		replicas:   replicas,
+		replicaAPI: replicaAPI,
+		ring:       NewRing(nil, cfg.VirtualNodes),
+		closeC:     make(chan struct{}),
+	}
+}
+
+// Run renews the lease of this replica and refreshes the view of the cluster periodically, until the membership is
+// closed. On close, the replica leaves the cluster, which hands over its invocations to the other replicas without
+// waiting for its lease to expire.
+func (m *Membership) Run() error {
+	logrus.Infof("Joining cluster as replica %s (lease: %v)", m.ID, m.LeaseDuration)
+	ticker := time.NewTicker(m.RenewInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.Refresh(time.Now()); err != nil {
+			logrus.Errorf("Failed to refresh cluster membership: %v", err)
+		}
+		select {
+		case <-m.closeC:
+			return m.leave()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh renews the lease of this replica, and recomputes the ring of live replicas at the given time. If this
+// replica is the leader, it also marks the replicas with an expired lease as having left, and purges the replicas that
+// left more than a lease duration ago.
+func (m *Membership) Refresh(now time.Time) error {
+	leaseExpiresAt := now.Add(m.LeaseDuration)
+	if err := m.renew(leaseExpiresAt); err != nil {
+		return err
+	}
+
+	replicas, err := m.list()
+	if err != nil {
+		return err
+	}
+
+	// The own replica is always live, even if the store does not reflect the renewal of its lease yet.
+	var live []*types.Replica
+	var expired []*types.Replica
+	var left []*types.Replica
+	var found bool
+	for _, replica := range replicas {
+		if replica.ID() == m.ID {
+			found = true
+			if replica.GetStatus().GetStatus() != types.ReplicaStatus_ACTIVE {
+				// Another replica considered this replica to be dead, so it has to join again.
+				logrus.Warnf("Replica %s was removed from the cluster: %v", m.ID, replica.GetStatus().GetError())
+				if err := m.replicaAPI.Join(m.ID, leaseExpiresAt); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if replica.GetStatus().Live(now) {
+			live = append(live, replica)
+		} else if replica.GetStatus().GetStatus() == types.ReplicaStatus_ACTIVE {
+			expired = append(expired, replica)
+		} else if replica.GetStatus().GetStatus() == types.ReplicaStatus_LEFT {
+			if leftAt, err := ptypes.Timestamp(replica.GetStatus().GetUpdatedAt()); err == nil &&
+				now.Sub(leftAt) > m.LeaseDuration {
+				left = append(left, replica)
+			}
+		}
+	}
+	if !found {
+		// The replica was purged by the leader after it was considered to be dead, so it has to join again.
+		logrus.Warnf("Replica %s is missing from the cluster", m.ID)
+		if err := m.replicaAPI.Join(m.ID, leaseExpiresAt); err != nil {
+			return err
+		}
+	}
+	ids := []string{m.ID}
+	for _, replica := range live {
+		ids = append(ids, replica.ID())
+	}
+	isLeader := m.isOldest(live)
+
+	m.mu.Lock()
+	if !equal(m.ring.Members(), ids) {
+		m.ring = NewRing(ids, m.VirtualNodes)
+		logrus.Infof("Cluster members changed: %v", m.ring.Members())
+		rebalances.Inc()
+	}
+	if isLeader != m.leader {
+		logrus.Infof("Replica %s is the leader of the cluster: %v", m.ID, isLeader)
+	}
+	m.leader = isLeader
+	m.leaseExpiresAt = leaseExpiresAt
+	m.mu.Unlock()
+
+	members.Set(float64(len(ids)))
+	if isLeader {
+		leader.Set(1)
+		for _, replica := range expired {
+			err := m.replicaAPI.Leave(replica.ID(), errors.New("lease expired"),
+				api.WithExpectedGeneration(replica.Generation()))
+			if fes.ErrConcurrentModification.Is(err) {
+				// The replica renewed its lease in the meantime, or the view of this replica is outdated.
+				continue
+			} else if err != nil {
+				logrus.Warnf("Failed to remove replica %s with expired lease: %v", replica.ID(), err)
+				continue
+			}
+			logrus.Infof("Removed replica %s with expired lease", replica.ID())
+		}
+		m.purge(left)
+	} else {
+		leader.Set(0)
+	}
+	return nil
+}
+
+// Owns checks if the entity with the given key is owned by this replica. Before this replica has joined, or once its
+// lease has expired, it owns no entities.
+func (m *Membership) Owns(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !time.Now().Before(m.leaseExpiresAt) {
+		return false
+	}
+	return m.ring.Owner(key) == m.ID
+}
+
+// Leader checks if this replica is the leader of the cluster.
+func (m *Membership) Leader() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.leader && time.Now().Before(m.leaseExpiresAt)
+}
+
+// Members returns the ids of the live replicas in the cluster, including this replica.
+func (m *Membership) Members() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ring.Members()
+}
+
+func (m *Membership) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.closeC)
+	})
+	return nil
+}
+
+func (m *Membership) renew(leaseExpiresAt time.Time) error {
+	m.mu.RLock()
+	joined := m.joined
+	m.mu.RUnlock()
+	if joined {
+		if compacted, err := m.compact(leaseExpiresAt); compacted || err != nil {
+			return err
+		}
+		return m.replicaAPI.Renew(m.ID, leaseExpiresAt)
+	}
+	if err := m.replicaAPI.Join(m.ID, leaseExpiresAt); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.joined = true
+	m.mu.Unlock()
+	return nil
+}
+
+// compact renews the lease of the replica by compacting its event stream, once the event stream has grown large and
+// the backend supports it. It returns whether the event stream was compacted.
+func (m *Membership) compact(leaseExpiresAt time.Time) (bool, error) {
+	if _, ok := m.backend.(fes.Replacer); !ok {
+		return false, nil
+	}
+	self, err := m.replicas.GetReplica(m.ID)
+	if err != nil || self == nil || self.Generation() < maxReplicaEvents ||
+		self.GetStatus().GetStatus() != types.ReplicaStatus_ACTIVE {
+		return false, nil
+	}
+	joinedAt, err := ptypes.Timestamp(self.GetStatus().GetJoinedAt())
+	if err != nil {
+		return false, nil
+	}
+	if err := m.replicaAPI.Compact(m.ID, joinedAt, leaseExpiresAt); err != nil {
+		return false, err
+	}
+	// Other replicas rebuild their cached entity once they observe the replaced event stream.
+	m.replicas.Invalidate(m.ID)
+	logrus.Debugf("Compacted the event stream of replica %s (%d events)", m.ID, self.Generation())
+	return true, nil
+}
+
+// purge removes the replicas that have left the cluster from the event store, if the backend supports it.
+func (m *Membership) purge(replicas []*types.Replica) {
+	purger, ok := m.backend.(fes.Purger)
+	if !ok {
+		return
+	}
+	for _, replica := range replicas {
+		if _, err := purger.Purge(projectors.NewReplicaAggregate(replica.ID())); err != nil {
+			logrus.Warnf("Failed to purge replica %s: %v", replica.ID(), err)
+			continue
+		}
+		m.replicas.Invalidate(replica.ID())
+		logrus.Infof("Purged replica %s, which left the cluster", replica.ID())
+	}
+}
+
+func (m *Membership) leave() error {
+	m.mu.Lock()
+	m.ring = NewRing(nil, m.VirtualNodes)
+	m.leaseExpiresAt = time.Time{}
+	m.leader = false
+	m.mu.Unlock()
+	logrus.Infof("Replica %s is leaving the cluster", m.ID)
+	return m.replicaAPI.Leave(m.ID, nil)
+}
+
+// list returns all replicas in the event store, since the cache only contains the replicas that have been accessed
+// before.
+func (m *Membership) list() ([]*types.Replica, error) {
+	keys, err := m.backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeReplica
+	})
+	if err != nil {
+		return nil, err
+	}
+	var replicas []*types.Replica
+	for _, key := range keys {
+		replica, err := m.replicas.GetReplica(key.Id)
+		if err != nil {
+			logrus.Warnf("Failed to get replica %s: %v", key.Id, err)
+			continue
+		}
+		if replica != nil {
+			replicas = append(replicas, replica)
+		}
+	}
+	return replicas, nil
+}
+
+// isOldest checks if this replica joined before all of the other live replicas; ties are broken by the ids.
+func (m *Membership) isOldest(others []*types.Replica) bool {
+	self, err := m.replicas.GetReplica(m.ID)
+	if err != nil || self == nil {
+		// Without a record of its own, this replica is the newest.
+		return len(others) == 0
+	}
+	joinedAt, _ := ptypes.Timestamp(self.GetStatus().GetJoinedAt())
+	for _, other := range others {
+		otherJoinedAt, _ := ptypes.Timestamp(other.GetStatus().GetJoinedAt())
+		if otherJoinedAt.Before(joinedAt) || (otherJoinedAt.Equal(joinedAt) && other.ID() < m.ID) {
+			return false
+		}
+	}
+	return true
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sorted := make([]string, len(b))
+	copy(sorted, b)
+	sort.Strings(sorted)
+	for i := range a {
+		if a[i] != sorted[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// projectingReader projects the entities directly from the backend, so that the tests do not depend on the
+// asynchronous updates of a cache.
+type projectingReader struct {
+	backend   fes.Backend
+	projector fes.Projector
+}
+
+func (r *projectingReader) List() []fes.Aggregate {
+	keys, _ := r.backend.List(func(a fes.Aggregate) bool { return true })
+	return keys
+}
+
+func (r *projectingReader) GetAggregate(key fes.Aggregate) (fes.Entity, error) {
+	events, err := r.backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fes.ErrEntityNotFound.WithAggregate(&key)
+	}
+	base, err := r.projector.NewProjection(key)
+	if err != nil {
+		return nil, err
+	}
+	return r.projector.Project(base, events...)
+}
+
+func newMembership(backend fes.Backend, id string) *Membership {
+	replicas := store.NewReplicaStore(&projectingReader{backend, projectors.NewReplica()})
+	return NewMembership(backend, Config{ID: id, LeaseDuration: time.Minute}, replicas, api.NewReplicaAPI(backend))
+}
+
+// owners returns the replica that owns each of the keys, or an empty string if none or multiple replicas own a key.
+func owners(replicas ...*Membership) []string {
+	var result []string
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("wi-%d", i)
+		var owner string
+		for _, replica := range replicas {
+			if replica.Owns(key) {
+				if owner != "" {
+					owner = ""
+					break
+				}
+				owner = replica.ID
+			}
+		}
+		result = append(result, owner)
+	}
+	return result
+}
+
+func TestMembership_Refresh(t *testing.T) {
+	backend := mem.NewBackend()
+	a := newMembership(backend, "a")
+	b := newMembership(backend, "b")
+
+	// A replica owns nothing before it has joined.
+	assert.False(t, a.Owns("wi-1"))
+
+	now := time.Now()
+	assert.NoError(t, a.Refresh(now))
+	assert.Equal(t, []string{"a"}, a.Members())
+	assert.True(t, a.Leader())
+	assert.True(t, a.Owns("wi-1"))
+
+	assert.NoError(t, b.Refresh(now.Add(time.Second)))
+	assert.NoError(t, a.Refresh(now.Add(time.Second)))
+	assert.Equal(t, []string{"a", "b"}, a.Members())
+	assert.Equal(t, []string{"a", "b"}, b.Members())
+	assert.True(t, a.Leader())
+	assert.False(t, b.Leader())
+
+	// Every key is owned by exactly one of the replicas.
+	assigned := owners(a, b)
+	assert.NotContains(t, assigned, "")
+	assert.Contains(t, assigned, "a")
+	assert.Contains(t, assigned, "b")
+}
+
+func TestMembership_RefreshExpiredLease(t *testing.T) {
+	backend := mem.NewBackend()
+	a := newMembership(backend, "a")
+	b := newMembership(backend, "b")
+	now := time.Now()
+	assert.NoError(t, a.Refresh(now))
+	assert.NoError(t, b.Refresh(now))
+
+	// Replica b stops renewing its lease; once it expires, the leader takes over its keys and removes it.
+	assert.NoError(t, a.Refresh(now.Add(2*time.Minute)))
+	assert.Equal(t, []string{"a"}, a.Members())
+	replica, err := b.replicas.GetReplica("b")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplicaStatus_LEFT, replica.GetStatus().GetStatus())
+	assert.Equal(t, "lease expired", replica.GetStatus().GetError().GetMessage())
+
+	// Once b resumes, it rejoins the cluster.
+	assert.NoError(t, b.Refresh(now.Add(2*time.Minute+time.Second)))
+	assert.NoError(t, a.Refresh(now.Add(2*time.Minute+time.Second)))
+	replica, err = b.replicas.GetReplica("b")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplicaStatus_ACTIVE, replica.GetStatus().GetStatus())
+	assert.Equal(t, []string{"a", "b"}, a.Members())
+}
+
+func TestMembership_Leave(t *testing.T) {
+	backend := mem.NewBackend()
+	a := newMembership(backend, "a")
+	b := newMembership(backend, "b")
+	now := time.Now()
+	assert.NoError(t, a.Refresh(now))
+	assert.NoError(t, b.Refresh(now))
+
+	// Replica b leaves without waiting for its lease to expire, which hands over all keys to a.
+	assert.NoError(t, b.leave())
+	assert.False(t, b.Owns("wi-1"))
+	assert.NoError(t, a.Refresh(now.Add(time.Second)))
+	assert.Equal(t, []string{"a"}, a.Members())
+	for _, owner := range owners(a, b) {
+		assert.Equal(t, "a", owner)
+	}
+}
+
+func TestMembership_CompactsEventStream(t *testing.T) {
+	backend := mem.NewBackend()
+	a := newMembership(backend, "a")
+	now := time.Now()
+	assert.NoError(t, a.Refresh(now))
+	joined, err := a.replicas.GetReplica("a")
+	assert.NoError(t, err)
+
+	// Renewing the lease over and over again should not grow the event stream of the replica indefinitely.
+	for i := 1; i <= 2*maxReplicaEvents; i++ {
+		assert.NoError(t, a.Refresh(now.Add(time.Duration(i)*time.Second)))
+	}
+	events, err := backend.Get(projectors.NewReplicaAggregate("a"))
+	assert.NoError(t, err)
+	assert.True(t, len(events) <= maxReplicaEvents, "event stream has %d events", len(events))
+
+	// The replica keeps its seniority.
+	replica, err := a.replicas.GetReplica("a")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplicaStatus_ACTIVE, replica.GetStatus().GetStatus())
+	assert.Equal(t, joined.GetStatus().GetJoinedAt(), replica.GetStatus().GetJoinedAt())
+	assert.True(t, a.Leader())
+}
+
+func TestMembership_PurgesLeftReplicas(t *testing.T) {
+	backend := mem.NewBackend()
+	a := newMembership(backend, "a")
+	b := newMembership(backend, "b")
+	now := time.Now()
+	assert.NoError(t, a.Refresh(now))
+	assert.NoError(t, b.Refresh(now))
+	assert.NoError(t, b.leave())
+
+	// Once a replica has left for longer than the lease duration, the leader removes it from the event store.
+	assert.NoError(t, a.Refresh(now.Add(time.Second)))
+	replica, err := a.replicas.GetReplica("b")
+	assert.NoError(t, err)
+	assert.NotNil(t, replica)
+	assert.NoError(t, a.Refresh(now.Add(2*time.Minute)))
+	replicas, err := a.list()
+	assert.NoError(t, err)
+	assert.Len(t, replicas, 1)
+
+	// A replica that was purged joins again once it resumes.
+	assert.NoError(t, b.Refresh(now.Add(2*time.Minute+time.Second)))
+	assert.NoError(t, a.Refresh(now.Add(2*time.Minute+time.Second)))
+	replica, err = b.replicas.GetReplica("b")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ReplicaStatus_ACTIVE, replica.GetStatus().GetStatus())
+	assert.Equal(t, []string{"a", "b"}, a.Members())
+}
+
+func TestNewMembership_DefaultID(t *testing.T) {
+	backend := mem.NewBackend()
+	replicas := store.NewReplicaStore(&projectingReader{backend, projectors.NewReplica()})
+
+	// A replica that restarts without an explicit id should reuse its previous id, rather than leaving a new replica
+	// behind in the event store on every restart.
+	first := NewMembership(backend, Config{}, replicas, api.NewReplicaAPI(backend))
+	second := NewMembership(backend, Config{}, replicas, api.NewReplicaAPI(backend))
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+// unguardedBackend does not guard conditional appends, similar to the NATS backend.
+type unguardedBackend struct {
+	fes.Backend
+}
+
+func TestValidateBackend(t *testing.T) {
+	assert.NoError(t, ValidateBackend(mem.NewBackend()))
+	assert.Error(t, ValidateBackend(&unguardedBackend{mem.NewBackend()}))
+}
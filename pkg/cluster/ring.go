@@ -0,0 +1,79 @@
+package cluster
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"sort"
+	"strconv"
+)
+
+// DefaultVirtualNodes is the number of points that each member occupies on the ring, which evens out the share of
+// the keys that each member owns.
+const DefaultVirtualNodes = 64
+
+// Ring is a consistent hash ring, which assigns each key to one of the members. If a member is added or removed,
+// only the keys of that member move to other members.
+type Ring struct {
+	members []string
+	points  []uint32
+	owners  map[uint32]string
+}
+
+// NewRing creates a ring of the members, in which every member occupies the given number of virtual nodes. If
+// virtualNodes is 0 or less, DefaultVirtualNodes is used.
+func NewRing(members []string, virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultVirtualNodes
+	}
+	sorted := make([]string, len(members))
+	copy(sorted, members)
+	sort.Strings(sorted)
+
+	r := &Ring{
+		members: sorted,
+		owners:  make(map[uint32]string, len(members)*virtualNodes),
+	}
+	for _, member := range sorted {
+		for i := 0; i < virtualNodes; i++ {
+			point := hash(member + "#" + strconv.Itoa(i))
+			// In the unlikely case of a collision, the point belongs to the member that sorts first.
+			if _, ok := r.owners[point]; ok {
+				continue
+			}
+			r.owners[point] = member
+			r.points = append(r.points, point)
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i] < r.points[j]
+	})
+	return r
+}
+
+// Owner returns the member that owns the key, which is the member of the first point on the ring at or after the
+// hash of the key. If the ring has no members, it returns an empty string.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i] >= h
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.owners[r.points[i]]
+}
+
+// Members returns the sorted members of the ring.
+func (r *Ring) Members() []string {
+	return r.members
+}
+
+// hash spreads the keys uniformly over the ring. Unlike FNV, MD5 spreads similar keys, such as the virtual nodes of a
+// member, well enough.
+func hash(s string) uint32 {
+	sum := md5.Sum([]byte(s))
+	return binary.BigEndian.Uint32(sum[:4])
+}
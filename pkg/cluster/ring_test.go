@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRing_Owner(t *testing.T) {
+	empty := NewRing(nil, 0)
+	assert.Equal(t, "", empty.Owner("wi-1"))
+
+	members := []string{"c", "a", "b"}
+	ring := NewRing(members, 0)
+	assert.Equal(t, []string{"a", "b", "c"}, ring.Members())
+	reordered := NewRing([]string{"b", "c", "a"}, 0)
+
+	// Every member owns a reasonable share of the keys.
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("wi-%d", i)
+		owner := ring.Owner(key)
+		assert.Equal(t, owner, ring.Owner(key))
+		assert.Equal(t, owner, reordered.Owner(key))
+		counts[owner]++
+	}
+	for _, member := range members {
+		assert.True(t, counts[member] > 500, "member %s owns %d of the 3000 keys", member, counts[member])
+	}
+}
+
+func TestRing_OwnerRemoveMember(t *testing.T) {
+	ring := NewRing([]string{"a", "b", "c"}, 0)
+	reduced := NewRing([]string{"a", "b"}, 0)
+
+	// Only the keys of the removed member move to another member.
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("wi-%d", i)
+		if owner := ring.Owner(key); owner != "c" {
+			assert.Equal(t, owner, reduced.Owner(key))
+		} else {
+			assert.NotEqual(t, "c", reduced.Owner(key))
+		}
+	}
+}
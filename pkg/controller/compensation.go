@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
+	"github.com/fission/fission-workflows/pkg/controller/executor"
+	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/graph"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/opentracing/opentracing-go"
+)
+
+// compensate evaluates the compensation of a failed invocation. The succeeded tasks that have a compensation are
+// compensated one at a time, in reverse dependency order. The compensation stops at the first compensation that fails.
+func (c *InvocationController) compensate(invocation *types.WorkflowInvocation,
+	expectedGeneration api.CallOption) ctrl.Result {
+	compensation := invocation.GetStatus().GetCompensation()
+
+	// Start the compensation by determining the order in which the tasks should be compensated.
+	if compensation.GetStatus() == types.CompensationStatus_UNKNOWN {
+		order, err := compensationOrder(invocation)
+		if err != nil {
+			return ctrl.Err{Err: err}
+		}
+		c.executor.Submit(&executor.Task{
+			TaskID:  invocation.ID() + ".compensation.start",
+			GroupID: invocation.ID(),
+			Apply: func() error {
+				return c.invocationAPI.StartCompensation(invocation.ID(), order, expectedGeneration)
+			},
+		})
+		return ctrl.Success{Msg: fmt.Sprintf("started the compensation of %d task(s)", len(order))}
+	}
+
+	// Complete the compensation once all tasks have been compensated, or one of the compensations has failed.
+	taskID, ok := compensation.Next()
+	if !ok {
+		var err error
+		if failedTaskID, status, failed := compensation.Failed(); failed {
+			err = fmt.Errorf("failed to compensate task %s: %s", failedTaskID, status.GetError().GetMessage())
+		}
+		c.executor.Submit(&executor.Task{
+			TaskID:  invocation.ID() + ".compensation.complete",
+			GroupID: invocation.ID(),
+			Apply: func() error {
+				return c.invocationAPI.CompleteCompensation(invocation.ID(), err, expectedGeneration)
+			},
+		})
+		return ctrl.Success{Msg: "completed the compensation of the invocation"}
+	}
+
+	// To avoid running a compensation twice, wait until the result of a submitted compensation has been recorded.
+	c.startedCompensationsMu.Lock()
+	defer c.startedCompensationsMu.Unlock()
+	if _, ok := c.startedCompensations[taskID]; ok {
+		return ctrl.Success{}
+	}
+	if c.executor.Submit(&executor.Task{
+		TaskID:  fmt.Sprintf("%s.compensate.%s", invocation.ID(), taskID),
+		GroupID: invocation.ID(),
+		Apply: func() error {
+			return c.runCompensation(invocation, taskID)
+		},
+	}) {
+		c.startedCompensations[taskID] = struct{}{}
+	}
+	return ctrl.Success{Msg: fmt.Sprintf("scheduled the compensation of task %s", taskID)}
+}
+
+// runCompensation compensates the task, ensuring that the compensation does not wait forever for a result that will
+// never be recorded. If the compensation fails without a recorded result, it is recorded as failed. If that fails as
+// well, the compensation is released to be submitted again in a later evaluation.
+func (c *InvocationController) runCompensation(invocation *types.WorkflowInvocation, taskID string) error {
+	err := c.execCompensation(invocation, taskID)
+	if err == nil {
+		return nil
+	}
+	c.logger.WithField("task", taskID).Errorf("Failed to compensate task: %v", err)
+	failErr := c.taskAPI.FailCompensation(invocation.ID(), taskID, err.Error(),
+		api.WithExpectedGeneration(invocation.GetMetadata().GetGeneration()))
+	if failErr != nil {
+		c.startedCompensationsMu.Lock()
+		delete(c.startedCompensations, taskID)
+		c.startedCompensationsMu.Unlock()
+		return failErr
+	}
+	return err
+}
+
+func (c *InvocationController) execCompensation(invocation *types.WorkflowInvocation, taskID string) error {
+	log := c.logger.WithField("task", taskID)
+	span := opentracing.StartSpan(fmt.Sprintf("/compensate/%s", taskID), opentracing.ChildOf(c.span.Context()))
+	span.SetTag("task", taskID)
+	defer span.Finish()
+	expectedGeneration := api.WithExpectedGeneration(invocation.GetMetadata().GetGeneration())
+
+	task, ok := invocation.Task(taskID)
+	if !ok {
+		err := fmt.Errorf("task '%v' could not be found", taskID)
+		span.LogKV("error", err)
+		return c.taskAPI.FailCompensation(invocation.ID(), taskID, err.Error(), expectedGeneration)
+	}
+	spec := types.NewCompensationInvocationSpec(invocation, task, time.Now())
+	span.SetTag("fnref", spec.GetFnRef())
+
+	// Check if the function of the compensation has been resolved
+	if spec.GetFnRef() == nil {
+		err := fmt.Errorf("no resolved function could be found for the compensation '%v'",
+			task.GetSpec().GetCompensate().GetFunctionRef())
+		span.LogKV("error", err)
+		return c.taskAPI.FailCompensation(invocation.ID(), taskID, err.Error(), expectedGeneration)
+	}
+
+	// Resolve expression inputs, in which the current task is the compensated task.
+	if len(spec.GetInputs()) > 0 {
+		inputs, err := c.resolveCompensationInputs(invocation, taskID, spec.GetInputs())
+		if err != nil {
+			log.Error(err)
+			span.LogKV("error", err)
+			return c.taskAPI.FailCompensation(invocation.ID(), taskID, err.Error(), expectedGeneration)
+		}
+		spec.Inputs = inputs
+	}
+
+	// Create the context with the deadline specified in the compensation spec.
+	ctx := context.Background()
+	deadline, err := ptypes.Timestamp(spec.Deadline)
+	if err == nil {
+		var cancel func()
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	log.Infof("Compensating task using %v", spec.GetFnRef().Format())
+	result, err := c.taskAPI.Compensate(spec, api.WithContext(ctx), api.AwaitWorklow(awaitWorkflowMaxRuntime),
+		expectedGeneration)
+	if err != nil {
+		span.LogKV("error", err)
+		return err
+	}
+	span.SetTag("status", result.GetStatus().String())
+	if !result.Successful() {
+		span.LogKV("error", result.GetError().String())
+	}
+	return nil
+}
+
+// resolveCompensationInputs resolves the inputs of the compensation of a task. Unlike the inputs of a task, the
+// resolved inputs are not added to the scope, to keep the inputs of the compensated task intact.
+func (c *InvocationController) resolveCompensationInputs(invocation *types.WorkflowInvocation, taskID string,
+	inputs map[string]*typedvalues.TypedValue) (map[string]*typedvalues.TypedValue, error) {
+	// Inherit scope if invocation has a parent
+	var parentScope *expr.Scope
+	if len(invocation.Spec.ParentId) != 0 {
+		var ok bool
+		parentScope, ok = c.StateStore.Get(invocation.Spec.ParentId)
+		if !ok {
+			c.logger.Warnf("Could not find parent scope (%s) of scope (%s)", invocation.Spec.ParentId, invocation.ID())
+		}
+	}
+
+	// Setup the scope for the expressions
+	scope, err := expr.NewScope(parentScope, invocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scope for the compensation of task '%v': %v", taskID, err)
+	}
+
+	resolvedInputs := map[string]*typedvalues.TypedValue{}
+	for _, input := range typedvalues.Prioritize(inputs) {
+		resolvedInput, err := expr.Resolve(scope, taskID, input.Val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve input field %v: %v", input.Key, err)
+		}
+		resolvedInputs[input.Key] = resolvedInput
+	}
+	return resolvedInputs, nil
+}
+
+// compensationOrder returns the IDs of the tasks of the invocation that should be compensated, in reverse dependency
+// order, such that a task is compensated before the tasks that it depends on.
+func compensationOrder(invocation *types.WorkflowInvocation) ([]string, error) {
+	specs := map[string]*types.TaskSpec{}
+	for id, task := range invocation.Tasks() {
+		specs[id] = task.GetSpec()
+	}
+	sorted, err := graph.ReverseTopologicalSort(graph.Parse(graph.NewTaskSpecIterator(specs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine the compensation order: %v", err)
+	}
+
+	compensable := invocation.CompensableTasks()
+	var order []string
+	for _, taskID := range sorted {
+		if _, ok := compensable[taskID]; ok {
+			order = append(order, taskID)
+		}
+	}
+	return order, nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingCompensationBackend fails the first appends of the results of compensations.
+type failingCompensationBackend struct {
+	*mem.Backend
+	failures int
+}
+
+func (b *failingCompensationBackend) Append(event *fes.Event) error {
+	if event.GetType() == string(events.EventInvocationTaskCompensated) && b.failures > 0 {
+		b.failures--
+		return errors.New("event store is unavailable")
+	}
+	return b.Backend.Append(event)
+}
+
+// setupCompensatingController creates a controller for an invocation of which the compensation of task-1 has started.
+func setupCompensatingController(t *testing.T, backend fes.Backend) (*InvocationController, string) {
+	invocationAPI := api.NewInvocationAPI(backend)
+	invocationID, err := invocationAPI.Invoke(types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	err = invocationAPI.Fail(invocationID, errors.New("task failed"))
+	assert.NoError(t, err)
+	err = invocationAPI.StartCompensation(invocationID, []string{"task-1"})
+	assert.NoError(t, err)
+
+	span := opentracing.NoopTracer{}.StartSpan("test")
+	c := NewInvocationController(invocationID, nil, invocationAPI, api.NewTaskAPI(nil, backend, nil), nil, nil, nil,
+		nil, span, logrus.WithField("wi", invocationID))
+	c.startedCompensations["task-1"] = struct{}{}
+	return c, invocationID
+}
+
+func loadTestInvocation(t *testing.T, backend fes.Backend, invocationID string) *types.WorkflowInvocation {
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	evts, err := backend.Get(aggregate)
+	assert.NoError(t, err)
+	projector := projectors.NewWorkflowInvocation()
+	base, err := projector.NewProjection(aggregate)
+	assert.NoError(t, err)
+	entity, err := projector.Project(base, evts...)
+	assert.NoError(t, err)
+	return entity.(*types.WorkflowInvocation)
+}
+
+func TestInvocationController_CompensationErrorIsRecorded(t *testing.T) {
+	backend := &failingCompensationBackend{Backend: mem.NewBackend()}
+	c, invocationID := setupCompensatingController(t, backend)
+	backend.failures = 1
+
+	// The compensation fails to record its result, after which it should be recorded as failed instead.
+	err := c.runCompensation(loadTestInvocation(t, backend, invocationID), "task-1")
+	assert.Error(t, err)
+
+	compensation := loadTestInvocation(t, backend, invocationID).GetStatus().GetCompensation()
+	failedTaskID, status, failed := compensation.Failed()
+	assert.True(t, failed)
+	assert.Equal(t, "task-1", failedTaskID)
+	assert.Contains(t, status.GetError().GetMessage(), "event store is unavailable")
+}
+
+func TestInvocationController_CompensationWithoutResultIsReleased(t *testing.T) {
+	backend := &failingCompensationBackend{Backend: mem.NewBackend()}
+	c, invocationID := setupCompensatingController(t, backend)
+	backend.failures = 2
+
+	// If no result could be recorded at all, the compensation should be submitted again in a later evaluation.
+	err := c.runCompensation(loadTestInvocation(t, backend, invocationID), "task-1")
+	assert.Error(t, err)
+	assert.NotContains(t, c.startedCompensations, "task-1")
+
+	taskID, ok := loadTestInvocation(t, backend, invocationID).GetStatus().GetCompensation().Next()
+	assert.True(t, ok)
+	assert.Equal(t, "task-1", taskID)
+}
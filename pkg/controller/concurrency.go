@@ -0,0 +1,318 @@
+package controller
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	invocationsQueued = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "invocations",
+		Name:      "queued",
+		Help:      "Number of scheduled invocations that wait for the concurrency limit of their workflow.",
+	}, []string{"workflow"})
+
+	invocationsRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "invocations",
+		Name:      "running",
+		Help:      "Number of invocations that count towards the concurrency limit of their workflow.",
+	}, []string{"workflow"})
+)
+
+func init() {
+	prometheus.MustRegister(invocationsQueued, invocationsRunning)
+}
+
+// ConcurrencyLimiter limits the number of invocations of each workflow that are in progress at the same time.
+//
+// The invocations that exceed the limit of their workflow are queued per workflow, in the order in which they were
+// created. Once an invocation finishes, the oldest queued invocations of the workflow are the first to start, which
+// keeps a burst of invocations from starving the invocations that were created before. The invocations of other
+// workflows are not affected by the queue.
+//
+// Invocations that are started by another invocation, such as the dynamic tasks of an invocation, are not limited;
+// their parent invocation already counts towards the limit, and could otherwise wait forever on its own children.
+//
+// The slots are kept in the memory of the controller, so the limit applies to each replica separately. When the
+// invocations are divided among multiple replicas, up to the limit times the number of replicas invocations of a
+// workflow can be in progress at the same time. After a restart, Recover restores the slots of the invocations that
+// were in progress.
+type ConcurrencyLimiter struct {
+	defaultLimit int
+	mu           sync.Mutex
+	workflows    map[string]*workflowSlots
+	invocations  map[string]string // invocation id -> workflow id
+
+	// onAvailable is called with the queued invocations that are allowed to start, after another invocation of
+	// their workflow has released its slot.
+	onAvailable func(invocationID string)
+}
+
+type workflowSlots struct {
+	limit   int
+	running map[string]struct{}
+	queue   []queuedInvocation
+}
+
+type queuedInvocation struct {
+	id        string
+	createdAt time.Time
+}
+
+// NewConcurrencyLimiter creates a limiter that applies the default limit to workflows that do not specify a limit of
+// their own. If defaultLimit is 0 or less, the invocations of those workflows are not limited.
+func NewConcurrencyLimiter(defaultLimit int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		defaultLimit: defaultLimit,
+		workflows:    map[string]*workflowSlots{},
+		invocations:  map[string]string{},
+	}
+}
+
+// Acquire acquires a slot for the scheduled invocation. If the limit of the workflow has been reached, or older
+// invocations of the workflow are waiting for a slot, the invocation is queued and Acquire returns false. The
+// invocation keeps its place in the queue until it acquires a slot or is released.
+func (l *ConcurrencyLimiter) Acquire(invocation *types.WorkflowInvocation) bool {
+	if l == nil || len(invocation.GetSpec().GetParentId()) > 0 {
+		return true
+	}
+	invocationID := invocation.ID()
+	workflowID := invocation.Workflow().ID()
+	limit := l.limitOf(invocation.Workflow())
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots := l.slots(workflowID)
+	slots.limit = limit
+	defer slots.updateMetrics(workflowID)
+	if _, ok := slots.running[invocationID]; ok {
+		return true
+	}
+	if limit <= 0 {
+		slots.running[invocationID] = struct{}{}
+		l.invocations[invocationID] = workflowID
+		return true
+	}
+
+	i := slots.indexOf(invocationID)
+	if i < 0 {
+		createdAt, _ := ptypes.Timestamp(invocation.GetMetadata().GetCreatedAt())
+		i = slots.enqueue(queuedInvocation{id: invocationID, createdAt: createdAt})
+		l.invocations[invocationID] = workflowID
+	}
+	if i >= limit-len(slots.running) {
+		return false
+	}
+	slots.queue = append(slots.queue[:i], slots.queue[i+1:]...)
+	slots.running[invocationID] = struct{}{}
+	return true
+}
+
+// Occupy records that the invocation is in progress, regardless of the limit of its workflow. It is used for the
+// invocations that were started before, for example by a previous instance of the controller.
+func (l *ConcurrencyLimiter) Occupy(invocation *types.WorkflowInvocation) {
+	if l == nil || len(invocation.GetSpec().GetParentId()) > 0 {
+		return
+	}
+	invocationID := invocation.ID()
+	workflowID := invocation.Workflow().ID()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	slots := l.slots(workflowID)
+	if _, ok := slots.running[invocationID]; ok {
+		return
+	}
+	if i := slots.indexOf(invocationID); i >= 0 {
+		slots.queue = append(slots.queue[:i], slots.queue[i+1:]...)
+	}
+	slots.running[invocationID] = struct{}{}
+	l.invocations[invocationID] = workflowID
+	slots.updateMetrics(workflowID)
+}
+
+// Recover occupies the slots of the invocations in the event store that have been started, but that have not finished
+// yet, such as the invocations that a previous instance of the controller was processing. Without it, the limiter only
+// learns about these invocations once they are evaluated again, which allows scheduled invocations to exceed the limit
+// in the meantime. It should be called before the controller starts evaluating invocations.
+func (l *ConcurrencyLimiter) Recover(backend fes.Backend, invocations *store.Invocations) error {
+	if l == nil {
+		return nil
+	}
+	keys, err := backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeInvocation
+	})
+	if err != nil {
+		return err
+	}
+	var recovered int
+	for _, key := range keys {
+		invocation, err := invocations.GetInvocation(key.Id)
+		if err != nil {
+			logrus.Warnf("Failed to recover the concurrency slot of invocation %s: %v", key.Id, err)
+			continue
+		}
+		status := invocation.GetStatus()
+		if status.GetStartedAt() == nil || status.Finished() {
+			continue
+		}
+		l.Occupy(invocation)
+		recovered++
+	}
+	logrus.Infof("Recovered the concurrency slots of %d running invocations", recovered)
+	return nil
+}
+
+// Release releases the slot of the invocation, or removes the invocation from the queue. It is safe to release an
+// invocation multiple times.
+func (l *ConcurrencyLimiter) Release(invocationID string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	available := l.available(l.release(invocationID))
+	l.mu.Unlock()
+	l.notify(available)
+}
+
+// Retain releases all invocations for which keep returns false, such as the invocations that are no longer evaluated
+// by the controller.
+func (l *ConcurrencyLimiter) Retain(keep func(invocationID string) bool) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	released := map[string]struct{}{}
+	for invocationID := range l.invocations {
+		if !keep(invocationID) {
+			released[l.release(invocationID)] = struct{}{}
+		}
+	}
+	var available []string
+	for workflowID := range released {
+		available = append(available, l.available(workflowID)...)
+	}
+	l.mu.Unlock()
+	l.notify(available)
+}
+
+// Queued returns the ids of the queued invocations of the workflow, in the order in which they will be started.
+func (l *ConcurrencyLimiter) Queued(workflowID string) []string {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var ids []string
+	if slots, ok := l.workflows[workflowID]; ok {
+		for _, queued := range slots.queue {
+			ids = append(ids, queued.id)
+		}
+	}
+	return ids
+}
+
+// release removes the invocation, and returns the id of its workflow.
+func (l *ConcurrencyLimiter) release(invocationID string) string {
+	workflowID, ok := l.invocations[invocationID]
+	if !ok {
+		return ""
+	}
+	delete(l.invocations, invocationID)
+	slots := l.workflows[workflowID]
+	if i := slots.indexOf(invocationID); i >= 0 {
+		slots.queue = append(slots.queue[:i], slots.queue[i+1:]...)
+	} else {
+		delete(slots.running, invocationID)
+	}
+	slots.updateMetrics(workflowID)
+	if len(slots.running) == 0 && len(slots.queue) == 0 {
+		delete(l.workflows, workflowID)
+	}
+	return workflowID
+}
+
+// available returns the queued invocations of the workflow that can be started.
+func (l *ConcurrencyLimiter) available(workflowID string) []string {
+	slots, ok := l.workflows[workflowID]
+	if !ok {
+		return nil
+	}
+	var available []string
+	for i := 0; i < slots.limit-len(slots.running) && i < len(slots.queue); i++ {
+		available = append(available, slots.queue[i].id)
+	}
+	return available
+}
+
+func (l *ConcurrencyLimiter) notify(invocationIDs []string) {
+	if l.onAvailable == nil {
+		return
+	}
+	for _, invocationID := range invocationIDs {
+		l.onAvailable(invocationID)
+	}
+}
+
+func (l *ConcurrencyLimiter) slots(workflowID string) *workflowSlots {
+	slots, ok := l.workflows[workflowID]
+	if !ok {
+		slots = &workflowSlots{
+			running: map[string]struct{}{},
+		}
+		l.workflows[workflowID] = slots
+	}
+	return slots
+}
+
+func (l *ConcurrencyLimiter) limitOf(wf *types.Workflow) int {
+	if limit := wf.GetSpec().GetMaxConcurrency(); limit > 0 {
+		return int(limit)
+	}
+	return l.defaultLimit
+}
+
+func (s *workflowSlots) indexOf(invocationID string) int {
+	for i, queued := range s.queue {
+		if queued.id == invocationID {
+			return i
+		}
+	}
+	return -1
+}
+
+// enqueue inserts the invocation into the queue, ordered by the creation time of the invocations, and returns its
+// position in the queue.
+func (s *workflowSlots) enqueue(invocation queuedInvocation) int {
+	i := sort.Search(len(s.queue), func(i int) bool {
+		queued := s.queue[i]
+		if queued.createdAt.Equal(invocation.createdAt) {
+			return queued.id > invocation.id
+		}
+		return queued.createdAt.After(invocation.createdAt)
+	})
+	s.queue = append(s.queue, queuedInvocation{})
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = invocation
+	return i
+}
+
+func (s *workflowSlots) updateMetrics(workflowID string) {
+	if len(s.running) == 0 && len(s.queue) == 0 {
+		invocationsQueued.DeleteLabelValues(workflowID)
+		invocationsRunning.DeleteLabelValues(workflowID)
+		return
+	}
+	invocationsQueued.WithLabelValues(workflowID).Set(float64(len(s.queue)))
+	invocationsRunning.WithLabelValues(workflowID).Set(float64(len(s.running)))
+}
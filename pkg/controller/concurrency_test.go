@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fes/cache"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimiter_Queue(t *testing.T) {
+	limiter := NewConcurrencyLimiter(0)
+	var available []string
+	limiter.onAvailable = func(invocationID string) {
+		available = append(available, invocationID)
+	}
+	now := time.Now()
+	a := newLimitedInvocation("a", "wf", 2, now)
+	b := newLimitedInvocation("b", "wf", 2, now.Add(time.Second))
+	c := newLimitedInvocation("c", "wf", 2, now.Add(2*time.Second))
+	d := newLimitedInvocation("d", "wf", 2, now.Add(3*time.Second))
+
+	assert.True(t, limiter.Acquire(a))
+	assert.True(t, limiter.Acquire(b))
+	// The invocations are queued in the order in which they were created, rather than the order of evaluation.
+	assert.False(t, limiter.Acquire(d))
+	assert.False(t, limiter.Acquire(c))
+	assert.Equal(t, []string{"c", "d"}, limiter.Queued("wf"))
+
+	// Other workflows are not affected by the queue.
+	assert.True(t, limiter.Acquire(newLimitedInvocation("e", "other", 2, now)))
+
+	// Once a slot is released, the oldest queued invocation is the first to start.
+	limiter.Release("a")
+	assert.Equal(t, []string{"c"}, available)
+	assert.False(t, limiter.Acquire(d))
+	assert.True(t, limiter.Acquire(c))
+	assert.True(t, limiter.Acquire(c))
+	assert.Equal(t, []string{"d"}, limiter.Queued("wf"))
+
+	// Releasing an invocation multiple times does not free up additional slots.
+	limiter.Release("a")
+	assert.False(t, limiter.Acquire(d))
+	limiter.Release("b")
+	assert.Equal(t, []string{"c", "d"}, available)
+	assert.True(t, limiter.Acquire(d))
+	assert.Empty(t, limiter.Queued("wf"))
+}
+
+func TestConcurrencyLimiter_DefaultLimit(t *testing.T) {
+	now := time.Now()
+	limiter := NewConcurrencyLimiter(1)
+	assert.True(t, limiter.Acquire(newLimitedInvocation("a", "wf", 0, now)))
+	assert.False(t, limiter.Acquire(newLimitedInvocation("b", "wf", 0, now)))
+
+	// The limit of the workflow overrides the default limit.
+	assert.True(t, limiter.Acquire(newLimitedInvocation("c", "wf2", 2, now)))
+	assert.True(t, limiter.Acquire(newLimitedInvocation("d", "wf2", 2, now)))
+
+	// Without a default limit, workflows without a limit of their own are not limited.
+	limiter = NewConcurrencyLimiter(0)
+	for _, id := range []string{"a", "b", "c"} {
+		assert.True(t, limiter.Acquire(newLimitedInvocation(id, "wf", 0, now)))
+	}
+}
+
+func TestConcurrencyLimiter_ChildInvocations(t *testing.T) {
+	now := time.Now()
+	limiter := NewConcurrencyLimiter(0)
+	assert.True(t, limiter.Acquire(newLimitedInvocation("a", "wf", 1, now)))
+
+	child := newLimitedInvocation("b", "wf", 1, now)
+	child.Spec.ParentId = "a"
+	assert.True(t, limiter.Acquire(child))
+	assert.Empty(t, limiter.Queued("wf"))
+}
+
+func TestConcurrencyLimiter_Occupy(t *testing.T) {
+	now := time.Now()
+	limiter := NewConcurrencyLimiter(0)
+
+	// Invocations that were already in progress count towards the limit, even if it is exceeded.
+	limiter.Occupy(newLimitedInvocation("a", "wf", 1, now))
+	limiter.Occupy(newLimitedInvocation("b", "wf", 1, now))
+	assert.False(t, limiter.Acquire(newLimitedInvocation("c", "wf", 1, now)))
+
+	limiter.Release("a")
+	assert.False(t, limiter.Acquire(newLimitedInvocation("c", "wf", 1, now)))
+	limiter.Release("b")
+	assert.True(t, limiter.Acquire(newLimitedInvocation("c", "wf", 1, now)))
+}
+
+func TestConcurrencyLimiter_Retain(t *testing.T) {
+	now := time.Now()
+	limiter := NewConcurrencyLimiter(0)
+	var available []string
+	limiter.onAvailable = func(invocationID string) {
+		available = append(available, invocationID)
+	}
+	assert.True(t, limiter.Acquire(newLimitedInvocation("a", "wf", 1, now)))
+	assert.False(t, limiter.Acquire(newLimitedInvocation("b", "wf", 1, now.Add(time.Second))))
+	assert.False(t, limiter.Acquire(newLimitedInvocation("c", "wf", 1, now.Add(2*time.Second))))
+
+	limiter.Retain(func(invocationID string) bool {
+		return invocationID == "c"
+	})
+	assert.Equal(t, []string{"c"}, available)
+	assert.True(t, limiter.Acquire(newLimitedInvocation("c", "wf", 1, now.Add(2*time.Second))))
+}
+
+func TestConcurrencyLimiter_Recover(t *testing.T) {
+	backend := mem.NewBackend()
+	invocationAPI := api.NewInvocationAPI(backend)
+	now := time.Now()
+	invoke := func() string {
+		spec := types.NewWorkflowInvocationSpec("wf", now.Add(time.Hour))
+		spec.Workflow = newLimitedInvocation("", "wf", 1, now).Workflow()
+		id, err := invocationAPI.Invoke(spec)
+		assert.NoError(t, err)
+		return id
+	}
+	// restart recovers a new limiter, with an empty cache of invocations.
+	restart := func() (*ConcurrencyLimiter, *store.Invocations) {
+		invocations := store.NewInvocationStore(cache.NewLoadingCache(cache.NewLRUCache(10), backend,
+			projectors.NewWorkflowInvocation()))
+		limiter := NewConcurrencyLimiter(0)
+		assert.NoError(t, limiter.Recover(backend, invocations))
+		return limiter, invocations
+	}
+	running := invoke()
+	assert.NoError(t, invocationAPI.Start(running))
+	scheduled := invoke()
+
+	// The invocation that was started before the restart still occupies the only slot of the workflow, before it has
+	// been evaluated again.
+	limiter, invocations := restart()
+	wi, err := invocations.GetInvocation(scheduled)
+	assert.NoError(t, err)
+	assert.False(t, limiter.Acquire(wi))
+
+	// Finished invocations do not occupy a slot.
+	assert.NoError(t, invocationAPI.Cancel(running))
+	limiter, _ = restart()
+	assert.True(t, limiter.Acquire(wi))
+}
+
+func newLimitedInvocation(id string, workflowID string, maxConcurrency int32,
+	createdAt time.Time) *types.WorkflowInvocation {
+	ts, _ := ptypes.TimestampProto(createdAt)
+	return &types.WorkflowInvocation{
+		Metadata: &types.ObjectMetadata{
+			Id:        id,
+			CreatedAt: ts,
+		},
+		Spec: &types.WorkflowInvocationSpec{
+			WorkflowId: workflowID,
+			Workflow: &types.Workflow{
+				Metadata: &types.ObjectMetadata{
+					Id: workflowID,
+				},
+				Spec: &types.WorkflowSpec{
+					MaxConcurrency: maxConcurrency,
+				},
+			},
+		},
+	}
+}
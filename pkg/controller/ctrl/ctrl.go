@@ -2,6 +2,7 @@ package ctrl
 
 import (
 	"context"
+	"hash/fnv"
 	"io"
 	"runtime/debug"
 	"sync"
@@ -12,6 +13,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// DefaultWorkers is the number of workers of a system if none is configured. It evaluates the controllers one at a time,
+// as the system did before it supported parallel evaluation.
+const DefaultWorkers = 1
+
 // Future: decouple from fes.
 type Event = fes.Notification
 
@@ -38,6 +43,16 @@ type Result interface {
 
 type ControllerFactory func(event *Event) (ctrl Controller, err error)
 
+// Partition determines which of the entities are processed by this system, in case the entities are divided among
+// multiple replicas.
+//
+// While the ownership of an entity moves between replicas, both replicas may briefly consider themselves its owner.
+// A partition therefore relies on an event store that rejects conflicting events (see fes.ConditionalAppender) to
+// prevent two replicas from both recording the result of the same task.
+type Partition interface {
+	Owns(key string) bool
+}
+
 // Err logs the controller error.
 type Err struct {
 	Err error
@@ -87,25 +102,59 @@ func (c ControllerStats) RecordEval() ControllerStats {
 	return c
 }
 
-// Future: support parallel executions in evaluator
+// Config contains the options of a control system.
+type Config struct {
+	// Workers is the number of controllers that are evaluated in parallel. The evaluations of a single controller are
+	// never run concurrently, and are run in the order in which the events were submitted. If 0 or less,
+	// DefaultWorkers is used.
+	Workers int
+
+	// Partition determines which entities are evaluated by the system. If nil, all entities are evaluated.
+	Partition Partition
+}
+
+// System evaluates the controllers of the entities for the events that are submitted to it.
+//
+// Each controller is assigned to one of the workers based on its key. Every worker has its own queue, which ensures
+// that a controller is evaluated by one worker at a time, while the controllers of other entities are evaluated in
+// parallel by the other workers.
 type System struct {
 	ctrls       map[string]Controller
 	ctrlsMu     *sync.RWMutex
 	ctrlStats   map[string]ControllerStats
 	ctrlStatsMu *sync.RWMutex
 	factory     ControllerFactory
-	evalQueue   workqueue.Interface
+	partition   Partition
+	evalQueues  []workqueue.Interface
+	ctx         context.Context
 	close       func()
 	runOnce     *sync.Once
 	logger      *log.Logger
 }
 
 func NewSystem(factory ControllerFactory) *System {
+	return NewSystemWithConfig(factory, Config{})
+}
+
+// NewSystemWithConfig creates a system that evaluates the controllers created by the factory with the given number of
+// workers, and only evaluates the entities that are owned by the partition.
+func NewSystemWithConfig(factory ControllerFactory, cfg Config) *System {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	evalQueues := make([]workqueue.Interface, cfg.Workers)
+	for i := range evalQueues {
+		evalQueues[i] = workqueue.NewWorkQueue(workqueue.DefaultMaxSize, true)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &System{
 		factory:     factory,
+		partition:   cfg.Partition,
 		ctrlsMu:     &sync.RWMutex{},
 		ctrls:       make(map[string]Controller),
-		evalQueue:   workqueue.NewWorkQueue(workqueue.DefaultMaxSize, true),
+		evalQueues:  evalQueues,
+		ctx:         ctx,
+		close:       cancel,
 		runOnce:     &sync.Once{},
 		logger:      log.StandardLogger(),
 		ctrlStats:   make(map[string]ControllerStats),
@@ -151,20 +200,38 @@ func (s *System) LoggerFor(entityID string) *log.Entry {
 }
 
 func (s *System) Submit(event *Event) bool {
-	return s.evalQueue.Add(event)
+	if !s.Owns(event.Aggregate.Id) {
+		return false
+	}
+	return s.evalQueueFor(event.Aggregate.Id).Add(event)
+}
+
+// evalQueueFor returns the queue of the worker that evaluates the controller with the given key.
+func (s *System) evalQueueFor(ctrlKey string) workqueue.Interface {
+	if len(s.evalQueues) == 1 {
+		return s.evalQueues[0]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(ctrlKey))
+	return s.evalQueues[h.Sum32()%uint32(len(s.evalQueues))]
+}
+
+// Owns checks if the entity with the given key is evaluated by this system, according to its partition.
+func (s *System) Owns(ctrlKey string) bool {
+	return s.partition == nil || s.partition.Owns(ctrlKey)
 }
 
 func (s *System) Run() {
 	s.runOnce.Do(func() {
-		go s.run()
+		for _, evalQueue := range s.evalQueues {
+			go s.run(s.ctx, evalQueue)
+		}
 	})
 }
 
-func (s *System) run() {
-	ctx, cancel := context.WithCancel(context.Background())
-	s.close = cancel
+func (s *System) run(ctx context.Context, evalQueue workqueue.Interface) {
 	for {
-		item, shutdown := s.evalQueue.Get()
+		item, shutdown := evalQueue.Get()
 		if shutdown {
 			return
 		}
@@ -172,10 +239,20 @@ func (s *System) run() {
 		event, ok := item.(*Event)
 		if !ok {
 			s.logger.Errorf("Ignoring workqueue item. Expected an Event but got a %T", item)
-			s.evalQueue.Done(item)
+			evalQueue.Done(item)
 			continue
 		}
 		ctrlKey := event.Aggregate.Id
+
+		// The ownership of the entity might have moved to another replica since the event was submitted.
+		if !s.Owns(ctrlKey) {
+			if _, ok := s.GetController(ctrlKey); ok {
+				s.LoggerFor(ctrlKey).Debug("Removing controller of entity owned by another replica")
+				s.DeleteController(ctrlKey)
+			}
+			evalQueue.Done(item)
+			continue
+		}
 		s.LoggerFor(ctrlKey).Debugf("starting evaluation (reason: %v)", event.Event.GetType())
 
 		// Get or create controller for item
@@ -185,7 +262,7 @@ func (s *System) run() {
 			ctrl, err = s.factory(event)
 			if err != nil {
 				s.LoggerFor(ctrlKey).Error(err)
-				s.evalQueue.Done(item)
+				evalQueue.Done(item)
 				continue
 			}
 			s.LoggerFor(ctrlKey).Debug("created new controller")
@@ -193,7 +270,7 @@ func (s *System) run() {
 		}
 
 		s.eval(ctx, ctrlKey, ctrl, event)
-		s.evalQueue.Done(item)
+		evalQueue.Done(item)
 	}
 }
 
@@ -218,10 +295,10 @@ func (s *System) eval(ctx context.Context, ctrlKey string, ctrl Controller, even
 }
 
 func (s *System) Close() error {
-	s.evalQueue.ShutDown()
-	if s.close != nil {
-		s.close()
+	for _, evalQueue := range s.evalQueues {
+		evalQueue.ShutDown()
 	}
+	s.close()
 	return nil
 }
 
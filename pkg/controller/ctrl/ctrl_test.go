@@ -0,0 +1,146 @@
+package ctrl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_Parallel(t *testing.T) {
+	keys := 16
+	eventsPerKey := 20
+	var wg sync.WaitGroup
+	wg.Add(keys * eventsPerKey)
+	var running, maxRunning int32
+	evaluated := map[string][]*Event{}
+	evaluatedMu := &sync.Mutex{}
+	system := NewSystemWithConfig(func(event *Event) (Controller, error) {
+		return &testController{
+			eval: func(ctx context.Context, event *Event) Result {
+				defer wg.Done()
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				evaluatedMu.Lock()
+				evaluated[event.Aggregate.Id] = append(evaluated[event.Aggregate.Id], event)
+				evaluatedMu.Unlock()
+				return Success{}
+			},
+		}, nil
+	}, Config{Workers: 4})
+	system.Run()
+	defer system.Close()
+
+	submitted := map[string][]*Event{}
+	for i := 0; i < eventsPerKey; i++ {
+		for k := 0; k < keys; k++ {
+			event := newTestEvent(strconv.Itoa(k))
+			submitted[event.Aggregate.Id] = append(submitted[event.Aggregate.Id], event)
+			assert.True(t, system.Submit(event))
+		}
+	}
+	wg.Wait()
+
+	// The controllers should have been evaluated in parallel, but the events of a single controller in order.
+	assert.True(t, maxRunning > 1)
+	assert.True(t, maxRunning <= 4)
+	evaluatedMu.Lock()
+	defer evaluatedMu.Unlock()
+	assert.Equal(t, submitted, evaluated)
+}
+
+func TestSystem_Serialized(t *testing.T) {
+	events := 50
+	var wg sync.WaitGroup
+	wg.Add(events)
+	var running int32
+	var overlaps int32
+	system := NewSystemWithConfig(func(event *Event) (Controller, error) {
+		return &testController{
+			eval: func(ctx context.Context, event *Event) Result {
+				defer wg.Done()
+				if atomic.AddInt32(&running, 1) > 1 {
+					atomic.AddInt32(&overlaps, 1)
+				}
+				time.Sleep(100 * time.Microsecond)
+				atomic.AddInt32(&running, -1)
+				return Success{}
+			},
+		}, nil
+	}, Config{Workers: 8})
+	system.Run()
+	defer system.Close()
+
+	for i := 0; i < events; i++ {
+		assert.True(t, system.Submit(newTestEvent("foo")))
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), atomic.LoadInt32(&overlaps))
+}
+
+// BenchmarkSystem measures the throughput of the system for evaluations that take some time, such as the evaluations
+// of invocations that look up state and append events.
+func BenchmarkSystem(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8, 16, 32} {
+		b.Run(fmt.Sprintf("workers-%d", workers), func(b *testing.B) {
+			benchmarkSystem(b, workers, 1000, 100*time.Microsecond)
+		})
+	}
+}
+
+func benchmarkSystem(b *testing.B, workers int, keys int, evalDuration time.Duration) {
+	var wg sync.WaitGroup
+	system := NewSystemWithConfig(func(event *Event) (Controller, error) {
+		return &testController{
+			eval: func(ctx context.Context, event *Event) Result {
+				time.Sleep(evalDuration)
+				wg.Done()
+				return Success{}
+			},
+		}, nil
+	}, Config{Workers: workers})
+	defer system.Close()
+	events := make([]*Event, b.N)
+	for i := range events {
+		events[i] = newTestEvent(strconv.Itoa(i % keys))
+	}
+
+	b.ResetTimer()
+	system.Run()
+	for _, event := range events {
+		wg.Add(1)
+		for !system.Submit(event) {
+			// The queue of the worker is full; wait for the worker to catch up.
+			time.Sleep(time.Millisecond)
+		}
+	}
+	wg.Wait()
+}
+
+type testController struct {
+	eval func(ctx context.Context, event *Event) Result
+}
+
+func (c *testController) Eval(ctx context.Context, event *Event) Result {
+	return c.eval(ctx, event)
+}
+
+func newTestEvent(key string) *Event {
+	return &Event{
+		Aggregate: fes.Aggregate{Type: "test", Id: key},
+		Event:     &fes.Event{},
+	}
+}
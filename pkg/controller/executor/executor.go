@@ -1,9 +1,11 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/util/workqueue"
@@ -23,6 +25,12 @@ type LocalExecutor struct {
 	workers  []*worker
 	groups   map[interface{}]int
 	groupsMu *sync.RWMutex
+
+	// running is the number of tasks that are being executed by the workers.
+	running int64
+
+	// draining is set (to 1) once the executor no longer accepts new tasks.
+	draining int32
 }
 
 // Task is the unit of execution that the executor will execute.
@@ -66,6 +74,7 @@ func (ex *LocalExecutor) Start() {
 			queue:    ex.queue,
 			groups:   ex.groups,
 			groupsMu: ex.groupsMu,
+			running:  &ex.running,
 		}
 		ex.workers = append(ex.workers, worker)
 		go worker.Run()
@@ -77,6 +86,48 @@ func (ex *LocalExecutor) Close() error {
 	return nil
 }
 
+// Drain stops the executor from accepting new tasks, while the workers continue to execute the tasks that were
+// submitted before. It blocks until all of these tasks have finished, or until the context is done, returning the
+// context error in the latter case.
+func (ex *LocalExecutor) Drain(ctx context.Context) error {
+	atomic.StoreInt32(&ex.draining, 1)
+	return ex.Wait(ctx)
+}
+
+// Wait blocks until there are no tasks pending, or until the context is done, returning the context error in the
+// latter case. Unlike Drain, it does not stop the executor from accepting new tasks in the meantime.
+func (ex *LocalExecutor) Wait(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for ex.Pending() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Pending returns the number of tasks that are queued or being executed. Tasks that were submitted with a delay are
+// only counted once their delay has passed.
+func (ex *LocalExecutor) Pending() int {
+	return ex.queue.Len() + int(atomic.LoadInt64(&ex.running))
+}
+
+// PendingGroups returns the ids of the groups that have tasks that have not finished yet.
+func (ex *LocalExecutor) PendingGroups() []interface{} {
+	ex.groupsMu.RLock()
+	defer ex.groupsMu.RUnlock()
+	var groups []interface{}
+	for groupID, count := range ex.groups {
+		if count > 0 {
+			groups = append(groups, groupID)
+		}
+	}
+	return groups
+}
+
 func (ex *LocalExecutor) GetGroupTasks(groupID interface{}) int {
 	ex.groupsMu.RLock()
 	count := ex.groups[groupID]
@@ -85,6 +136,10 @@ func (ex *LocalExecutor) GetGroupTasks(groupID interface{}) int {
 }
 
 func (ex *LocalExecutor) SubmitAfter(t *Task, after time.Duration) bool {
+	if atomic.LoadInt32(&ex.draining) != 0 {
+		return false
+	}
+
 	// Add to the queue
 	if after <= 0 {
 		accepted := ex.queue.TryAddAfter(t, after)
@@ -115,6 +170,7 @@ type worker struct {
 	queue    workqueue.Interface
 	groups   map[interface{}]int
 	groupsMu *sync.RWMutex
+	running  *int64
 }
 
 func (w *worker) Run() {
@@ -125,7 +181,9 @@ func (w *worker) Run() {
 		}
 		task := item.(*Task)
 
+		atomic.AddInt64(w.running, 1)
 		executeTask(task)
+		atomic.AddInt64(w.running, -1)
 
 		w.queue.Done(task)
 		if task.GroupID != nil {
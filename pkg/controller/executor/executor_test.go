@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -40,6 +41,70 @@ func TestLocalExecutor(t *testing.T) {
 	assert.Equal(t, int32(3), t3.n.Load())
 }
 
+func TestLocalExecutor_Drain(t *testing.T) {
+	executor := NewLocalExecutor(1, 10)
+	executor.Start()
+	defer executor.Close()
+
+	release := make(chan struct{})
+	done := atomic.NewBool(false)
+	accepted := executor.Submit(&Task{
+		TaskID:  "t1",
+		GroupID: "wi-1",
+		Apply: func() error {
+			<-release
+			done.Store(true)
+			return nil
+		},
+	})
+	assert.True(t, accepted)
+
+	// The drain times out as long as the task is running.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err := executor.Drain(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, executor.Pending())
+	assert.Equal(t, []interface{}{"wi-1"}, executor.PendingGroups())
+
+	// A draining executor does not accept new tasks.
+	accepted = executor.Submit(&Task{TaskID: "t2", Apply: func() error { return nil }})
+	assert.False(t, accepted)
+
+	close(release)
+	err = executor.Drain(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, done.Load())
+	assert.Equal(t, 0, executor.Pending())
+}
+
+func TestLocalExecutor_Wait(t *testing.T) {
+	executor := NewLocalExecutor(1, 10)
+	executor.Start()
+	defer executor.Close()
+
+	release := make(chan struct{})
+	accepted := executor.Submit(&Task{TaskID: "t1", Apply: func() error {
+		<-release
+		return nil
+	}})
+	assert.True(t, accepted)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err := executor.Wait(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Unlike a draining executor, a waiting executor still accepts new tasks.
+	accepted = executor.Submit(&Task{TaskID: "t2", Apply: func() error { return nil }})
+	assert.True(t, accepted)
+
+	close(release)
+	err = executor.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, executor.Pending())
+}
+
 type testTask struct {
 	n *atomic.Int32
 }
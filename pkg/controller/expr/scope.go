@@ -54,6 +54,8 @@ type WorkflowScope struct {
 type InvocationScope struct {
 	*ObjectMetadata
 	Inputs map[string]interface{}
+	Status string      // invocation status
+	Output interface{} // only set once the invocation has succeeded
 }
 
 // ObjectMetadata contains identity and meta-data about an object.
@@ -105,6 +107,8 @@ func (s *InvocationScope) DeepCopy() DeepCopier {
 	return &InvocationScope{
 		ObjectMetadata: s.ObjectMetadata.DeepCopy().(*ObjectMetadata),
 		Inputs:         DeepCopy(s.Inputs).(map[string]interface{}),
+		Status:         s.Status,
+		Output:         DeepCopy(s.Output),
 	}
 }
 
@@ -153,9 +157,15 @@ func NewScope(base *Scope, wfi *types.WorkflowInvocation) (*Scope, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to format invocation inputs")
 		}
+		output, err := typedvalues.Unwrap(wfi.GetStatus().GetOutput())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to format invocation output")
+		}
 		updated.Invocation = &InvocationScope{
 			ObjectMetadata: formatMetadata(wfi.Metadata),
 			Inputs:         invocationParams,
+			Status:         wfi.GetStatus().GetStatus().String(),
+			Output:         output,
 		}
 	}
 
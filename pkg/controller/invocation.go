@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
@@ -13,11 +15,13 @@ import (
 	"github.com/fission/fission-workflows/pkg/controller/executor"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
 	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/scheduler"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
@@ -35,17 +39,40 @@ type InvocationController struct {
 	invocationAPI *api.Invocation
 	taskAPI       *api.Task
 	scheduler     *scheduler.InvocationScheduler
+	limiter       *ConcurrencyLimiter
+	results       *ResultCache
 	StateStore    *expr.Store // Future: just grab the initial state of the parent, instead of constantly rebuilding it.
 	span          opentracing.Span
 	logger        *logrus.Entry
 	startedTasks  map[string]struct{}
 
+	// preparedTasks contains the tasks that have been prepared while other tasks of the invocation were running.
+	preparedTasks map[string]struct{}
+
+	// runningTasks contains the cancel functions of the tasks that are being run, which are used to cancel the tasks
+	// of which the result is no longer needed.
+	runningTasks   map[string]context.CancelFunc
+	runningTasksMu sync.Mutex
+
+	// parkedTasks contains the started tasks that are waiting for an external event, such as a signal, without
+	// occupying a worker. They are run again on each evaluation, until they have finished.
+	parkedTasks   map[string]struct{}
+	parkedTasksMu sync.Mutex
+
+	// startedCompensations contains the tasks of which the compensation has been submitted, and of which the result
+	// has not been recorded yet. A compensation that fails without a result is released to be submitted again.
+	startedCompensations   map[string]struct{}
+	startedCompensationsMu sync.Mutex
+
 	errorCount int
+
+	// draining checks if the workflow engine is draining, in which case only nested invocations are progressed.
+	draining func() bool
 }
 
 func NewInvocationController(invocationID string, executor *executor.LocalExecutor, invocationAPI *api.Invocation,
-	taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, stateStore *expr.Store,
-	span opentracing.Span, logger *logrus.Entry) *InvocationController {
+	taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, limiter *ConcurrencyLimiter, results *ResultCache,
+	stateStore *expr.Store, span opentracing.Span, logger *logrus.Entry) *InvocationController {
 
 	return &InvocationController{
 		invocationID:  invocationID,
@@ -53,10 +80,17 @@ func NewInvocationController(invocationID string, executor *executor.LocalExecut
 		invocationAPI: invocationAPI,
 		taskAPI:       taskAPI,
 		scheduler:     scheduler,
+		limiter:       limiter,
+		results:       results,
 		StateStore:    stateStore,
 		span:          span,
 		logger:        logger,
 		startedTasks:  map[string]struct{}{},
+		preparedTasks: map[string]struct{}{},
+		runningTasks:  map[string]context.CancelFunc{},
+		parkedTasks:   map[string]struct{}{},
+
+		startedCompensations: map[string]struct{}{},
 	}
 }
 
@@ -73,6 +107,15 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		return ctrl.Err{Err: fmt.Errorf("invocation ID expected %v, but was %v", c.invocationID, invocation.ID())}
 	}
 
+	// While the workflow engine is draining, only nested invocations are progressed, because the tasks that are still
+	// running wait for them to finish. Other invocations are left as they are, to be continued after a restart.
+	if c.draining != nil && c.draining() && len(invocation.GetSpec().GetParentId()) == 0 {
+		return ctrl.Success{Msg: "workflow engine is draining"}
+	}
+
+	// Guard the events appended as a result of this evaluation against concurrent modifications of the invocation.
+	expectedGeneration := api.WithExpectedGeneration(invocation.GetMetadata().GetGeneration())
+
 	// Ensure that the workflow is present in the invocation
 	if invocation.Workflow() == nil {
 		err := errors.New("workflow is not present in the invocation")
@@ -80,27 +123,31 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
 			Apply: func() error {
-				return c.invocationAPI.Fail(invocation.ID(), err)
+				return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 			},
 		})
 		return ctrl.Err{Err: err}
 	}
 
-	// Do not evaluate as long as there still tasks to be executed
+	// Do not evaluate as long as there still tasks to be executed, except for starting the tasks that only await some
+	// of the tasks that are still running, and preparing the tasks that the scheduler expects to run soon.
 	if activeTaskCount := c.executor.GetGroupTasks(invocation.ID()); activeTaskCount > 0 {
-		return ctrl.Err{Err: fmt.Errorf("invocation still has %d open task(s) to be executed", activeTaskCount)}
-	}
-
-	// To avoid scheduling tasks that are being processed, ensure that all tasks that were successfully submitted have
-	// finished before reevaluating.
-	for taskID := range c.startedTasks {
-		if taskRun, ok := invocation.TaskInvocation(taskID); !ok || !taskRun.GetStatus().Finished() {
-			return ctrl.Success{}
+		if started := c.startAwaitingTasks(invocation); started > 0 {
+			return ctrl.Success{Msg: fmt.Sprintf("scheduled execution of %d awaiting task(s)", started)}
+		}
+		if prepared := c.prepareUpcomingTasks(invocation); prepared > 0 {
+			return ctrl.Success{Msg: fmt.Sprintf("scheduled preparation of %d upcoming task(s)", prepared)}
 		}
+		return ctrl.Err{Err: fmt.Errorf("invocation still has %d open task(s) to be executed", activeTaskCount)}
 	}
 
-	// Check if the invocation is not in a terminal state
+	// Check if the invocation is not in a terminal state. A failed invocation is only done once its tasks have been
+	// compensated.
 	if invocation.GetStatus().Finished() {
+		c.limiter.Release(invocation.ID())
+		if invocation.CompensationPending() {
+			return c.compensate(invocation, expectedGeneration)
+		}
 		return ctrl.Done{Msg: fmt.Sprintf("invocation is in a terminal state (%v)",
 			invocation.GetStatus().GetStatus().String())}
 	}
@@ -115,7 +162,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 				TaskID:  invocation.ID() + ".fail",
 				GroupID: invocation.ID(),
 				Apply: func() error {
-					return c.invocationAPI.Fail(invocation.ID(), err)
+					return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 				},
 			})
 			return ctrl.Err{Err: err}
@@ -128,12 +175,25 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
 			Apply: func() error {
-				return c.invocationAPI.Fail(invocation.ID(), err)
+				return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 			},
 		})
 		return ctrl.Err{Err: err}
 	}
 
+	// To avoid scheduling tasks that are being processed, ensure that all tasks that were successfully submitted have
+	// finished before reevaluating. This is checked after the deadline, to ensure that an invocation of which the
+	// result of a task got lost still fails once its deadline has been exceeded. Parked tasks are not being processed.
+	parkedTasks := c.parkedTaskIDs()
+	for taskID := range c.startedTasks {
+		if _, ok := parkedTasks[taskID]; ok {
+			continue
+		}
+		if taskRun, ok := invocation.TaskInvocation(taskID); !ok || !taskRun.GetStatus().Finished() {
+			return ctrl.Success{}
+		}
+	}
+
 	// Check if we did not exceed the error count
 	if c.errorCount > 0 {
 		err := errors.New("error count exceeded")
@@ -141,12 +201,55 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
 			Apply: func() error {
-				return c.invocationAPI.Fail(invocation.ID(), err)
+				return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 			},
 		})
 		return ctrl.Err{Err: err}
 	}
 
+	// Start a scheduled invocation once the concurrency limit of its workflow allows it to.
+	if invocation.GetStatus().Scheduled() {
+		return c.start(invocation, expectedGeneration)
+	}
+
+	// Do not progress the invocation while it is paused. Tasks that are already running still record their results,
+	// which are picked up once the invocation has been resumed. An invocation that was paused before it started gives
+	// up its place in the queue.
+	if invocation.GetStatus().Paused() {
+		if invocation.GetStatus().GetStartedAt() == nil {
+			c.limiter.Release(invocation.ID())
+		} else {
+			c.limiter.Occupy(invocation)
+		}
+		return ctrl.Success{Msg: "invocation is paused"}
+	}
+	c.limiter.Occupy(invocation)
+
+	// Add the fallbacks of the failed tasks that have one, which will run in place of the failed tasks.
+	var fallbacks int
+	for id, task := range invocation.Tasks() {
+		taskRun, ok := invocation.TaskInvocation(id)
+		if !ok || !task.GetSpec().FailureHandled(taskRun.GetStatus()) || !task.GetSpec().GetOnError().HasFallback() {
+			continue
+		}
+		if _, ok := invocation.DynamicChild(id); ok {
+			continue
+		}
+		task := task
+		if c.executor.Submit(&executor.Task{
+			TaskID:  fmt.Sprintf("%s.fallback.%s", invocation.ID(), id),
+			GroupID: invocation.ID(),
+			Apply: func() error {
+				return c.taskAPI.Fallback(invocation.ID(), task)
+			},
+		}) {
+			fallbacks++
+		}
+	}
+	if fallbacks > 0 {
+		return ctrl.Success{Msg: fmt.Sprintf("added the fallbacks of %d failed task(s)", fallbacks)}
+	}
+
 	// Check if all tasks have finished
 	if allTasksFinished(invocation) {
 		output, outputHeaders, err := determineTaskOutput(invocation)
@@ -155,7 +258,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 				TaskID:  invocation.ID() + ".fail",
 				GroupID: invocation.ID(),
 				Apply: func() error {
-					return c.invocationAPI.Fail(invocation.ID(), err)
+					return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 				},
 			})
 			return ctrl.Err{Err: err}
@@ -164,7 +267,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 				TaskID:  invocation.ID() + ".success",
 				GroupID: invocation.ID(),
 				Apply: func() error {
-					return c.invocationAPI.Complete(invocation.ID(), output, outputHeaders)
+					return c.invocationAPI.Complete(invocation.ID(), output, outputHeaders, expectedGeneration)
 				},
 			})
 			return ctrl.Success{Msg: "all tasks of the invocation have completed"}
@@ -184,7 +287,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
 			Apply: func() error {
-				return c.invocationAPI.Fail(invocation.ID(), err)
+				return c.invocationAPI.Fail(invocation.ID(), err, expectedGeneration)
 			},
 		})
 		return ctrl.Err{Err: err}
@@ -192,23 +295,18 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 
 	// Prepare (prewarm) the tasks listed in the schedule.
 	for _, action := range schedule.GetPrepareTasks() {
-		c.executor.Submit(&executor.Task{
-			TaskID:  fmt.Sprintf("%s.prewarm.%s", invocation.ID(), action.TaskID),
-			GroupID: invocation.ID(),
-			Apply: func() error {
-				task, ok := invocation.Task(action.TaskID)
-				if !ok || task == nil {
-					return fmt.Errorf("no task in workflow with ID: %s", action.TaskID)
-				}
-				taskRunSpec := types.NewTaskInvocationSpec(invocation, task, time.Now())
-				return c.taskAPI.Prepare(taskRunSpec, action.GetExpectedAtTime())
-			},
-		})
+		c.submitPrepareTask(invocation, action)
 	}
 
+	// Run the parked tasks again, to check whether they can finish.
+	rechecked := c.recheckParkedTasks(invocation, parkedTasks)
+
 	// Execute the tasks listed in the schedule.
 	for _, action := range schedule.GetRunTasks() {
 		taskID := action.TaskID
+		if _, ok := rechecked[taskID]; ok {
+			continue
+		}
 		if c.executor.Submit(&executor.Task{
 			TaskID:  fmt.Sprintf("%s.run.%s", invocation.ID(), taskID),
 			GroupID: invocation.ID(),
@@ -226,6 +324,160 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 	}
 }
 
+// parkedTaskIDs returns the IDs of the tasks that are currently parked.
+func (c *InvocationController) parkedTaskIDs() map[string]struct{} {
+	c.parkedTasksMu.Lock()
+	defer c.parkedTasksMu.Unlock()
+	parked := make(map[string]struct{}, len(c.parkedTasks))
+	for taskID := range c.parkedTasks {
+		parked[taskID] = struct{}{}
+	}
+	return parked
+}
+
+// recheckParkedTasks submits the parked tasks to be run again. A task that is still waiting parks itself again. It
+// returns the IDs of the tasks that were submitted.
+func (c *InvocationController) recheckParkedTasks(invocation *types.WorkflowInvocation,
+	parkedTasks map[string]struct{}) map[string]struct{} {
+	rechecked := map[string]struct{}{}
+	for taskID := range parkedTasks {
+		taskID := taskID
+		c.parkedTasksMu.Lock()
+		delete(c.parkedTasks, taskID)
+		c.parkedTasksMu.Unlock()
+		if taskRun, ok := invocation.TaskInvocation(taskID); ok && taskRun.GetStatus().Finished() {
+			continue
+		}
+		if c.executor.Submit(&executor.Task{
+			TaskID:  fmt.Sprintf("%s.run.%s", invocation.ID(), taskID),
+			GroupID: invocation.ID(),
+			Apply: func() error {
+				return c.execTask(invocation, taskID)
+			},
+		}) {
+			rechecked[taskID] = struct{}{}
+		} else {
+			c.parkedTasksMu.Lock()
+			c.parkedTasks[taskID] = struct{}{}
+			c.parkedTasksMu.Unlock()
+		}
+	}
+	return rechecked
+}
+
+// startAwaitingTasks starts the tasks that await only some of their dependencies, once enough of those have
+// succeeded, without waiting for the other dependencies to finish. It returns the number of tasks that were started.
+func (c *InvocationController) startAwaitingTasks(invocation *types.WorkflowInvocation) int {
+	if invocation.GetStatus().Finished() || invocation.GetStatus().GetStatus() == types.WorkflowInvocationStatus_PAUSED {
+		return 0
+	}
+	var started int
+	for taskID, task := range invocation.Tasks() {
+		if _, ok := c.startedTasks[taskID]; ok {
+			continue
+		}
+		if _, ok := invocation.TaskInvocation(taskID); ok || !invocation.AwaitReached(task) {
+			continue
+		}
+		taskID := taskID
+		if c.executor.Submit(&executor.Task{
+			TaskID:  fmt.Sprintf("%s.run.%s", invocation.ID(), taskID),
+			GroupID: invocation.ID(),
+			Apply: func() error {
+				return c.execTask(invocation, taskID)
+			},
+		}) {
+			c.startedTasks[taskID] = struct{}{}
+			started++
+		}
+	}
+	return started
+}
+
+// prepareUpcomingTasks prepares the tasks that the scheduler expects to run soon, while other tasks of the invocation
+// are still running. Only the prepare actions of the schedule are considered, and each task is prepared at most once
+// in the meantime.
+func (c *InvocationController) prepareUpcomingTasks(invocation *types.WorkflowInvocation) int {
+	if invocation.GetStatus().Finished() || invocation.GetStatus().GetStatus() == types.WorkflowInvocationStatus_PAUSED {
+		return 0
+	}
+	schedule, err := c.scheduler.Evaluate(invocation)
+	if err != nil {
+		c.logger.Debugf("Failed to determine the tasks to prepare: %v", err)
+		return 0
+	}
+	var prepared int
+	for _, action := range schedule.GetPrepareTasks() {
+		if _, ok := c.startedTasks[action.TaskID]; ok {
+			continue
+		}
+		if _, ok := c.preparedTasks[action.TaskID]; ok {
+			continue
+		}
+		if c.submitPrepareTask(invocation, action) {
+			c.preparedTasks[action.TaskID] = struct{}{}
+			prepared++
+		}
+	}
+	return prepared
+}
+
+func (c *InvocationController) submitPrepareTask(invocation *types.WorkflowInvocation,
+	action *scheduler.PrepareTaskAction) bool {
+	return c.executor.Submit(&executor.Task{
+		TaskID:  fmt.Sprintf("%s.prewarm.%s", invocation.ID(), action.TaskID),
+		GroupID: invocation.ID(),
+		Apply: func() error {
+			task, ok := invocation.Task(action.TaskID)
+			if !ok || task == nil {
+				return fmt.Errorf("no task in workflow with ID: %s", action.TaskID)
+			}
+			taskRunSpec := types.NewTaskInvocationSpec(invocation, task, time.Now())
+			return c.taskAPI.Prepare(taskRunSpec, action.GetExpectedAtTime())
+		},
+	})
+}
+
+// cancelPendingDependencies aborts the dependencies of the task that have not finished yet, and cancels those that
+// are running, because the task no longer needs their results. It returns the generation of the invocation after the
+// dependencies have been aborted.
+func (c *InvocationController) cancelPendingDependencies(invocation *types.WorkflowInvocation, task *types.Task,
+	expectedGeneration int64) int64 {
+	for _, depID := range invocation.PendingDependencies(task) {
+		reason := fmt.Sprintf("canceled, because task '%s' no longer awaits it", task.ID())
+		err := c.taskAPI.Abort(invocation.ID(), depID, reason, api.WithExpectedGeneration(expectedGeneration))
+		if err != nil {
+			// The dependency might have finished in the meantime.
+			c.logger.Debugf("Failed to abort task '%s': %v", depID, err)
+			continue
+		}
+		c.logger.Infof("Aborted task '%s', because task '%s' no longer awaits it", depID, task.ID())
+		expectedGeneration++
+		c.runningTasksMu.Lock()
+		if cancel, ok := c.runningTasks[depID]; ok {
+			cancel()
+		}
+		c.runningTasksMu.Unlock()
+	}
+	return expectedGeneration
+}
+
+// start starts the scheduled invocation, unless the concurrency limit of its workflow has been reached.
+func (c *InvocationController) start(invocation *types.WorkflowInvocation,
+	expectedGeneration api.CallOption) ctrl.Result {
+	if !c.limiter.Acquire(invocation) {
+		return ctrl.Success{Msg: "invocation is queued until the workflow is below its concurrency limit"}
+	}
+	c.executor.Submit(&executor.Task{
+		TaskID:  invocation.ID() + ".start",
+		GroupID: invocation.ID(),
+		Apply: func() error {
+			return c.invocationAPI.Start(invocation.ID(), expectedGeneration)
+		},
+	})
+	return ctrl.Success{Msg: "starting the invocation"}
+}
+
 func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, taskID string) error {
 	log := c.logger
 	span := opentracing.StartSpan(fmt.Sprintf("/task/%s", taskID), opentracing.ChildOf(c.span.Context()))
@@ -239,6 +491,37 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 		span.LogKV("error", err)
 		return err
 	}
+	expectedGeneration := invocation.GetMetadata().GetGeneration()
+
+	// Skip the task if it depends on the output of a skipped task, or if its condition does not hold. Both are only
+	// checked before the first attempt of the task.
+	if _, ok := invocation.TaskInvocation(taskID); !ok {
+		if depID, ok := skippedDependency(invocation, task); ok {
+			log.Infof("Skipping task, because its dependency '%s' was skipped", depID)
+			span.SetTag("status", types.TaskInvocationStatus_SKIPPED.String())
+			return c.taskAPI.Skip(invocation.ID(), taskID, api.WithExpectedGeneration(expectedGeneration))
+		}
+		if condition := task.GetSpec().GetWhen(); condition != nil {
+			run, err := c.resolveCondition(invocation, taskID, condition)
+			if err != nil {
+				// The condition cannot be evaluated in any attempt of the task, so fail the invocation rather than
+				// the task, which could otherwise be retried without its condition.
+				err = fmt.Errorf("failed to evaluate the condition of task '%s': %v", taskID, err)
+				span.LogKV("error", err)
+				return c.invocationAPI.Fail(invocation.ID(), err, api.WithExpectedGeneration(expectedGeneration))
+			}
+			if !run {
+				log.Info("Skipping task, because its condition is false")
+				span.SetTag("status", types.TaskInvocationStatus_SKIPPED.String())
+				return c.taskAPI.Skip(invocation.ID(), taskID, api.WithExpectedGeneration(expectedGeneration))
+			}
+		}
+	}
+
+	// Race the dependencies of the task: once the task has its quorum, the other dependencies are no longer needed.
+	if task.GetSpec().GetAwaitMode() == types.TaskSpec_CANCEL && invocation.AwaitReached(task) {
+		expectedGeneration = c.cancelPendingDependencies(invocation, task, expectedGeneration)
+	}
 
 	span.SetTag("fnref", task.GetStatus().GetFnRef())
 	if log.Level == logrus.DebugLevel {
@@ -292,8 +575,32 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 		}
 	}
 
-	// Create the context with the deadline specified in the task run spec.
-	ctx := context.Background()
+	// If the task has failed before, start a new attempt of the task. The failed attempt is kept in its history.
+	if taskRun, ok := invocation.TaskInvocation(taskID); ok &&
+		taskRun.GetStatus().GetStatus() == types.TaskInvocationStatus_FAILED {
+		attempt := taskRun.GetStatus().Attempt() + 1
+		log.Infof("Retrying task (attempt %d): %v", attempt, taskRun.GetStatus().GetError().GetMessage())
+		span.SetTag("attempt", attempt)
+		err := c.taskAPI.Retry(invocation.ID(), taskID, attempt, api.WithExpectedGeneration(expectedGeneration))
+		if err != nil {
+			span.LogKV("error", err)
+			return err
+		}
+		expectedGeneration++
+	}
+
+	// Create the context with the deadline specified in the task run spec, which can be canceled if the result of the
+	// task is no longer needed.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.runningTasksMu.Lock()
+	c.runningTasks[taskID] = cancel
+	c.runningTasksMu.Unlock()
+	defer func() {
+		c.runningTasksMu.Lock()
+		delete(c.runningTasks, taskID)
+		c.runningTasksMu.Unlock()
+	}()
 	deadline, err := ptypes.Timestamp(taskRunSpec.Deadline)
 	if err == nil {
 		var cancel func()
@@ -302,15 +609,52 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 	}
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
+	// Reuse the cached result of the task, if there is one.
+	var cacheKey string
+	cachePolicy := task.GetSpec().GetCache()
+	if cachePolicy != nil {
+		cacheKey, err = c.resultKey(invocation, taskRunSpec)
+		if err != nil {
+			log.Warnf("Not caching the result of the task: %v", err)
+			cachePolicy = nil
+		} else if result, ok := c.results.Get(cacheKey); ok {
+			log.Info("Reusing the cached result of the task")
+			span.SetTag("cached", true)
+			_, err := c.taskAPI.Reuse(taskRunSpec, result, api.PostTransformer(func(ti *types.TaskInvocation) error {
+				return c.transformTaskRunOutputs(invocation, ti)
+			}), api.WithExpectedGeneration(expectedGeneration))
+			if err != nil {
+				span.LogKV("error", err)
+			}
+			return err
+		}
+	}
+
 	// Invoke the task
+	var result *types.TaskInvocationStatus
 	updated, err := c.taskAPI.Invoke(taskRunSpec, api.WithContext(ctx), api.AwaitWorklow(awaitWorkflowMaxRuntime),
 		api.PostTransformer(func(ti *types.TaskInvocation) error {
+			// Cache the result of the function itself, as the outputs of the task depend on the invocation.
+			result = proto.Clone(ti.GetStatus()).(*types.TaskInvocationStatus)
 			return c.transformTaskRunOutputs(invocation, ti)
-		}))
+		}),
+		api.WithExpectedGeneration(expectedGeneration))
+	if err == fnenv.ErrTaskParked {
+		log.WithField("task", taskID).Debug("Parked task")
+		span.SetTag("parked", true)
+		c.parkedTasksMu.Lock()
+		c.parkedTasks[taskID] = struct{}{}
+		c.parkedTasksMu.Unlock()
+		return nil
+	}
 	if err != nil {
 		span.LogKV("error", err)
 		return err
 	}
+	if cachePolicy != nil {
+		ttl, _ := ptypes.Duration(cachePolicy.GetTtl())
+		c.results.Put(cacheKey, result, ttl)
+	}
 
 	// Post-execution debugging
 	span.SetTag("status", updated.GetStatus().GetStatus().String())
@@ -329,25 +673,93 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 	return nil
 }
 
-func (c *InvocationController) resolveInputs(invocation *types.WorkflowInvocation, taskID string,
-	inputs map[string]*typedvalues.TypedValue) (map[string]*typedvalues.TypedValue, error) {
-	// Inherit scope if invocation has a parent
-	log := c.logger
+// resultKey determines the key of the task run in the result cache, which is based on the cache key expression of the
+// task, or on the resolved inputs of the task if it has none.
+func (c *InvocationController) resultKey(invocation *types.WorkflowInvocation,
+	taskRunSpec *types.TaskInvocationSpec) (string, error) {
+	taskID := taskRunSpec.GetTaskId()
+	inputs := taskRunSpec.GetInputs()
+	if key := taskRunSpec.GetTask().GetSpec().GetCache().GetKey(); key != nil {
+		scope, err := c.newScope(invocation, taskID)
+		if err != nil {
+			return "", err
+		}
+		resolved, err := expr.Resolve(scope, taskID, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve cache key: %v", err)
+		}
+		inputs = map[string]*typedvalues.TypedValue{"key": resolved}
+	}
+	return ResultKey(taskRunSpec.GetFnRef(), inputs)
+}
+
+// newScope sets up the scope for the expressions of the task, which inherits the scope of the parent invocation, if
+// the invocation has one.
+func (c *InvocationController) newScope(invocation *types.WorkflowInvocation, taskID string) (*expr.Scope, error) {
 	var parentScope *expr.Scope
 	if len(invocation.Spec.ParentId) != 0 {
 		var ok bool
 		parentScope, ok = c.StateStore.Get(invocation.Spec.ParentId)
 		if !ok {
-			log.Warnf("Could not find parent scope (%s) of scope (%s)", invocation.Spec.ParentId, invocation.ID())
+			c.logger.Warnf("Could not find parent scope (%s) of scope (%s)", invocation.Spec.ParentId, invocation.ID())
 		}
 	}
 
-	// Setup the scope for the expressions
 	scope, err := expr.NewScope(parentScope, invocation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope for task '%v': %v", taskID, err)
 	}
 	c.StateStore.Set(invocation.ID(), scope)
+	return scope, nil
+}
+
+// resolveCondition evaluates the condition of the task, which determines whether the task should be run.
+func (c *InvocationController) resolveCondition(invocation *types.WorkflowInvocation, taskID string,
+	condition *typedvalues.TypedValue) (bool, error) {
+	scope, err := c.newScope(invocation, taskID)
+	if err != nil {
+		return false, err
+	}
+	resolved, err := expr.Resolve(scope, taskID, condition)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve condition: %v", err)
+	}
+	value, err := typedvalues.Unwrap(resolved)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve condition: %v", err)
+	}
+	run, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition should evaluate to a boolean, but was %T (%v)", value, value)
+	}
+	return run, nil
+}
+
+// skippedDependency returns the id of the skipped (or aborted) task of which the task uses the output, if any. Control
+// dependencies only determine the order of the tasks, so the dependents of a skipped task through those are still run.
+// Neither are the tasks that await only some of their dependencies skipped.
+func skippedDependency(invocation *types.WorkflowInvocation, task *types.Task) (string, bool) {
+	if task.GetSpec().AwaitsQuorum() {
+		return "", false
+	}
+	for depID, params := range task.GetSpec().GetRequires() {
+		if params.GetType() != types.TaskDependencyParameters_DATA {
+			continue
+		}
+		if dep, ok := invocation.TaskInvocation(depID); ok && (dep.GetStatus().Skipped() || dep.GetStatus().Aborted()) {
+			return depID, true
+		}
+	}
+	return "", false
+}
+
+func (c *InvocationController) resolveInputs(invocation *types.WorkflowInvocation, taskID string,
+	inputs map[string]*typedvalues.TypedValue) (map[string]*typedvalues.TypedValue, error) {
+	log := c.logger
+	scope, err := c.newScope(invocation, taskID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Resolve each of the inputs (based on priority)
 	resolvedInputs := map[string]*typedvalues.TypedValue{}
@@ -374,25 +786,11 @@ func (c *InvocationController) resolveInputs(invocation *types.WorkflowInvocatio
 
 func (c *InvocationController) resolveOutput(invocation *types.WorkflowInvocation, ti *types.TaskInvocation,
 	outputExpr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
-	log := c.logger
-
-	// Inherit scope if invocation has a parent
 	taskID := ti.GetSpec().GetTask().GetMetadata().GetId()
-	var parentScope *expr.Scope
-	if len(invocation.Spec.ParentId) != 0 {
-		var ok bool
-		parentScope, ok = c.StateStore.Get(invocation.Spec.ParentId)
-		if !ok {
-			log.Warnf("Could not find parent scope (%s) of scope (%s)", invocation.Spec.ParentId, invocation.ID())
-		}
-	}
-
-	// Setup the scope for the expressions
-	scope, err := expr.NewScope(parentScope, invocation)
+	scope, err := c.newScope(invocation, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create scope for task '%v': %v", taskID, err)
+		return nil, err
 	}
-	c.StateStore.Set(invocation.ID(), scope)
 
 	// Add the current output
 	scope.Tasks[taskID].Output = typedvalues.MustUnwrap(ti.GetStatus().GetOutput())
@@ -449,24 +847,11 @@ func (c *InvocationController) transformTaskRunOutputs(invocation *types.Workflo
 
 func (c *InvocationController) resolveOutputHeaders(invocation *types.WorkflowInvocation, ti *types.TaskInvocation,
 	outputHeadersExpr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
-
 	taskID := ti.GetSpec().GetTask().GetMetadata().GetId()
-	// Inherit scope if invocation has a parent
-	var parentScope *expr.Scope
-	if len(invocation.Spec.ParentId) != 0 {
-		var ok bool
-		parentScope, ok = c.StateStore.Get(invocation.Spec.ParentId)
-		if !ok {
-			c.logger.Warnf("Could not find parent scope (%s) of scope (%s)", invocation.Spec.ParentId, invocation.ID())
-		}
-	}
-
-	// Setup the scope for the expressions
-	scope, err := expr.NewScope(parentScope, invocation)
+	scope, err := c.newScope(invocation, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create scope for task '%v': %v", taskID, err)
+		return nil, err
 	}
-	c.StateStore.Set(invocation.ID(), scope)
 
 	// Add the current outputHeaders
 	scope.Tasks[taskID].OutputHeaders = typedvalues.MustUnwrap(ti.GetStatus().GetOutputHeaders())
@@ -484,9 +869,10 @@ func determineTaskOutput(invocation *types.WorkflowInvocation) (output *typedval
 
 	success := true
 	wf := invocation.GetSpec().GetWorkflow()
-	for id := range invocation.Tasks() {
-		task := invocation.Status.Tasks[id]
-		if !task.GetStatus().Successful() {
+	for id, task := range invocation.Tasks() {
+		taskRun := invocation.Status.Tasks[id]
+		if !taskRun.GetStatus().Successful() && !taskRun.GetStatus().Skipped() && !taskRun.GetStatus().Aborted() &&
+			!task.GetSpec().FailureHandled(taskRun.GetStatus()) {
 			success = false
 			break
 		}
@@ -506,11 +892,12 @@ func determineTaskOutput(invocation *types.WorkflowInvocation) (output *typedval
 	}
 }
 
+// allTasksFinished checks if all tasks of the invocation have finished, ignoring the failed tasks that will be retried.
 func allTasksFinished(invocation *types.WorkflowInvocation) bool {
 	finished := true
-	for id := range invocation.Tasks() {
-		task, ok := invocation.Status.Tasks[id]
-		if !ok || !task.GetStatus().Finished() {
+	for id, task := range invocation.Tasks() {
+		taskRun, ok := invocation.Status.Tasks[id]
+		if !ok || !taskRun.GetStatus().Finished() || task.GetSpec().GetRetry().Retryable(taskRun.GetStatus()) {
 			finished = false
 			break
 		}
@@ -530,34 +917,41 @@ type InvocationMetaController struct {
 	runOnce     *sync.Once
 	invocations *store.Invocations
 	system      *ctrl.System
+	limiter     *ConcurrencyLimiter
+	draining    int32
+	drainOnce   sync.Once
 }
 
 func NewInvocationMetaController(executor *executor.LocalExecutor, invocations *store.Invocations,
 	invocationAPI *api.Invocation, taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, stateStore *expr.Store,
-	cachePollInterval time.Duration) *InvocationMetaController {
+	limiter *ConcurrencyLimiter, results *ResultCache, cachePollInterval time.Duration,
+	cfg ctrl.Config) *InvocationMetaController {
 	c := &InvocationMetaController{
 		executor:    executor,
 		runOnce:     &sync.Once{},
 		invocations: invocations,
-		system: ctrl.NewSystem(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
-			spanCtx, err := fes.ExtractTracingFromEventMetadata(event.Event.GetMetadata())
-			if err != nil {
-				logrus.Debugf("Could not extract span from event metadata: %v", err)
-			}
-			var span opentracing.Span
-			if spanCtx != nil {
-				span = opentracing.StartSpan("/controller/eval", opentracing.FollowsFrom(spanCtx))
-			} else {
-				span = opentracing.StartSpan("/controller/eval")
-			}
-			invocationID := event.Aggregate.Id
-			if len(invocationID) == 0 {
-				return nil, fmt.Errorf("invocation ID missing in event: %v %v", event.Aggregate, event.Event.GetType())
-			}
-			return NewInvocationController(invocationID, executor, invocationAPI, taskAPI, scheduler,
-				stateStore, span, logrus.WithField("key", invocationID)), nil
-		}),
+		limiter:     limiter,
 	}
+	c.system = ctrl.NewSystemWithConfig(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
+		spanCtx, err := fes.ExtractTracingFromEventMetadata(event.Event.GetMetadata())
+		if err != nil {
+			logrus.Debugf("Could not extract span from event metadata: %v", err)
+		}
+		var span opentracing.Span
+		if spanCtx != nil {
+			span = opentracing.StartSpan("/controller/eval", opentracing.FollowsFrom(spanCtx))
+		} else {
+			span = opentracing.StartSpan("/controller/eval")
+		}
+		invocationID := event.Aggregate.Id
+		if len(invocationID) == 0 {
+			return nil, fmt.Errorf("invocation ID missing in event: %v %v", event.Aggregate, event.Event.GetType())
+		}
+		invocationCtrl := NewInvocationController(invocationID, executor, invocationAPI, taskAPI, scheduler, limiter,
+			results, stateStore, span, logrus.WithField("key", invocationID))
+		invocationCtrl.draining = c.Draining
+		return invocationCtrl, nil
+	}, cfg)
 	c.sensors = []ctrl.Sensor{
 		NewInvocationNotificationSensor(invocations),
 		NewInvocationStorePollSensor(invocations, cachePollInterval),
@@ -573,9 +967,39 @@ func NewInvocationMetaController(executor *executor.LocalExecutor, invocations *
 			return aggregate, invocation, nil
 		}, 100*time.Millisecond, time.Second),
 	}
+	if limiter != nil {
+		// Start the queued invocations as soon as a slot is available, rather than waiting for the next poll.
+		limiter.onAvailable = c.refresh
+		// Release the slots of the invocations that are no longer evaluated by this controller, such as invocations
+		// that are now owned by another replica. Recovered invocations keep their slots while their controllers are
+		// being created.
+		c.sensors = append(c.sensors, ctrl.NewPollSensor(cachePollInterval, func(queue ctrl.EvalQueue) {
+			limiter.Retain(c.system.Owns)
+		}))
+	}
 	return c
 }
 
+// refresh submits the current state of the invocation for evaluation.
+func (c *InvocationMetaController) refresh(invocationID string) {
+	invocation, err := c.invocations.GetInvocation(invocationID)
+	if err != nil {
+		logrus.Debugf("Failed to fetch invocation %s: %v", invocationID, err)
+		return
+	}
+	aggregate := fes.GetAggregate(invocation)
+	c.system.Submit(&ctrl.Event{
+		Old:     invocation,
+		Updated: invocation,
+		Event: &fes.Event{
+			Type:      EventRefresh,
+			Aggregate: &aggregate,
+			Timestamp: ptypes.TimestampNow(),
+		},
+		Aggregate: aggregate,
+	})
+}
+
 func (c *InvocationMetaController) Run() {
 	c.runOnce.Do(func() {
 		go c.run()
@@ -599,6 +1023,46 @@ func (c *InvocationMetaController) run() {
 
 }
 
+// Drain stops the controller from progressing invocations, which prevents it from scheduling new tasks, and waits
+// until the tasks that it scheduled before have finished or the context is done. Nested invocations are still
+// progressed in the meantime, because the tasks that invoked them wait for them to finish. The other invocations are
+// left as they are, to be continued by another replica or after a restart.
+//
+// Draining is terminal; once all tasks have finished, the controller stops. If the context is done before that, Drain
+// can be called again to wait for the remaining tasks.
+func (c *InvocationMetaController) Drain(ctx context.Context) error {
+	atomic.StoreInt32(&c.draining, 1)
+	if err := c.executor.Wait(ctx); err != nil {
+		return err
+	}
+	c.drainOnce.Do(func() {
+		for _, sensor := range c.sensors {
+			if err := sensor.Close(); err != nil {
+				logrus.Warnf("Failed to stop sensor: %v", err)
+			}
+		}
+		if err := c.system.Close(); err != nil {
+			logrus.Warnf("Failed to stop control system: %v", err)
+		}
+	})
+	return c.executor.Drain(ctx)
+}
+
+// Draining checks if the controller has started draining.
+func (c *InvocationMetaController) Draining() bool {
+	return atomic.LoadInt32(&c.draining) != 0
+}
+
+// Pending returns the number of tasks that have not finished yet, along with the ids of their invocations.
+func (c *InvocationMetaController) Pending() (int, []string) {
+	var invocationIDs []string
+	for _, groupID := range c.executor.PendingGroups() {
+		invocationIDs = append(invocationIDs, fmt.Sprintf("%v", groupID))
+	}
+	sort.Strings(invocationIDs)
+	return c.executor.Pending(), invocationIDs
+}
+
 func (c *InvocationMetaController) Close() error {
 	err := c.executor.Close()
 	err = c.system.Close()
@@ -697,10 +1161,12 @@ func (s *InvocationStorePollSensor) Poll(evalQueue ctrl.EvalQueue) {
 			continue
 		}
 
-		// Check if the status is not in a terminal state
+		// Check if the status is not in a terminal state, unless the failed invocation still needs to be compensated.
 		switch wf.GetStatus().GetStatus() {
 		case types.WorkflowInvocationStatus_ABORTED, types.WorkflowInvocationStatus_FAILED, types.WorkflowInvocationStatus_SUCCEEDED:
-			continue
+			if !wf.CompensationPending() {
+				continue
+			}
 		default:
 			// nop
 		}
@@ -760,7 +1226,7 @@ func (s *StalenessPollSensor) Poll(queue ctrl.EvalQueue) {
 		// do not refresh
 		invocation, ok := entity.(*types.WorkflowInvocation)
 		if ok {
-			if invocation.GetStatus().Finished() {
+			if invocation.GetStatus().Finished() && !invocation.CompensationPending() {
 				return true
 			}
 		}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
+	"github.com/fission/fission-workflows/pkg/controller/executor"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocationController_EvalWhileDraining(t *testing.T) {
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Hour))
+	exec := executor.NewLocalExecutor(1, 10)
+	span := opentracing.NoopTracer{}.StartSpan("test")
+	c := NewInvocationController(invocation.ID(), exec, nil, nil, nil, nil, nil, nil, span,
+		logrus.WithField("wi", invocation.ID()))
+	c.draining = func() bool { return true }
+
+	// A top-level invocation is left as it is while draining.
+	result := c.Eval(context.Background(), &ctrl.Event{Updated: invocation})
+	assert.IsType(t, ctrl.Success{}, result)
+	assert.Equal(t, 0, exec.Pending())
+
+	// A nested invocation is still progressed, which in this case means failing it because its workflow is missing.
+	invocation.Spec.ParentId = "wi-0"
+	result = c.Eval(context.Background(), &ctrl.Event{Updated: invocation})
+	assert.IsType(t, ctrl.Err{}, result)
+	assert.Equal(t, 1, exec.Pending())
+}
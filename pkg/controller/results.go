@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"sort"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/golang-lru"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var taskResultsCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "workflows",
+	Subsystem: "tasks",
+	Name:      "result_cache",
+	Help:      "Number of lookups of cached task results, by whether the result was found (hit or miss).",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(taskResultsCache)
+}
+
+// ResultCache memoizes the successful results of the tasks that have a cache policy, so that subsequent runs of the
+// same function with the same inputs can reuse the result instead of running the function again.
+//
+// The results are kept in memory, up to a maximum number of results, after which the least recently used results are
+// evicted. Results expire after the TTL of the cache policy of the task that produced them.
+type ResultCache struct {
+	contents *lru.Cache
+	now      func() time.Time
+}
+
+type cachedResult struct {
+	result    *types.TaskInvocationStatus
+	expiresAt time.Time
+}
+
+// NewResultCache creates a result cache that holds at most size results.
+func NewResultCache(size int) *ResultCache {
+	c, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &ResultCache{
+		contents: c,
+		now:      time.Now,
+	}
+}
+
+// Get returns the result that is cached for the key, if it has not expired yet.
+func (c *ResultCache) Get(key string) (*types.TaskInvocationStatus, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.contents.Get(key)
+	if !ok {
+		taskResultsCache.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	cached := entry.(*cachedResult)
+	if !c.now().Before(cached.expiresAt) {
+		c.contents.Remove(key)
+		taskResultsCache.WithLabelValues("miss").Inc()
+		return nil, false
+	}
+	taskResultsCache.WithLabelValues("hit").Inc()
+	return proto.Clone(cached.result).(*types.TaskInvocationStatus), true
+}
+
+// Put caches the successful result for the key, for the duration of the TTL. Other results, as well as results of
+// functions that returned a dynamic task or workflow, are not cached.
+func (c *ResultCache) Put(key string, result *types.TaskInvocationStatus, ttl time.Duration) {
+	if c == nil || ttl <= 0 || !result.Successful() || controlflow.IsControlFlow(result.GetOutput()) {
+		return
+	}
+	c.contents.Add(key, &cachedResult{
+		result:    proto.Clone(result).(*types.TaskInvocationStatus),
+		expiresAt: c.now().Add(ttl),
+	})
+}
+
+// ResultKey computes the key of the result of the function for the given inputs. The key is a hash of the function
+// and the inputs, which does not depend on the order of the inputs.
+func ResultKey(fnRef *types.FnRef, inputs map[string]*typedvalues.TypedValue) (string, error) {
+	h := sha256.New()
+	writeHashField(h, []byte(fnRef.Format()))
+	keys := make([]string, 0, len(inputs))
+	for k := range inputs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	for _, k := range keys {
+		buf.Reset()
+		if err := buf.Marshal(inputs[k]); err != nil {
+			return "", err
+		}
+		writeHashField(h, []byte(k))
+		writeHashField(h, buf.Bytes())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashField writes the field to the hash, prefixed with its length so that the boundaries between the fields
+// are unambiguous.
+func writeHashField(h hash.Hash, field []byte) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(field)))
+	h.Write(size[:])
+	h.Write(field)
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCache(t *testing.T) {
+	now := time.Now()
+	cache := NewResultCache(10)
+	cache.now = func() time.Time {
+		return now
+	}
+	result := &types.TaskInvocationStatus{
+		Status: types.TaskInvocationStatus_SUCCEEDED,
+		Output: typedvalues.MustWrap("foo"),
+	}
+
+	_, ok := cache.Get("key")
+	assert.False(t, ok)
+	cache.Put("key", result, time.Minute)
+	cached, ok := cache.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, result, cached)
+
+	// Modifying the cached result should not affect the result in the cache.
+	cached.Output = typedvalues.MustWrap("bar")
+	cached, _ = cache.Get("key")
+	assert.Equal(t, "foo", typedvalues.MustUnwrap(cached.Output))
+
+	// The result expires after the TTL.
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("key")
+	assert.False(t, ok)
+}
+
+func TestResultCache_OnlySuccessfulResults(t *testing.T) {
+	cache := NewResultCache(10)
+	cache.Put("failed", &types.TaskInvocationStatus{
+		Status: types.TaskInvocationStatus_FAILED,
+	}, time.Minute)
+	_, ok := cache.Get("failed")
+	assert.False(t, ok)
+
+	// Dynamic tasks are added to the invocation by running the function, so those results cannot be reused.
+	cache.Put("dynamic", &types.TaskInvocationStatus{
+		Status: types.TaskInvocationStatus_SUCCEEDED,
+		Output: typedvalues.MustWrap(&types.TaskSpec{FunctionRef: "noop"}),
+	}, time.Minute)
+	_, ok = cache.Get("dynamic")
+	assert.False(t, ok)
+}
+
+func TestResultKey(t *testing.T) {
+	fn := &types.FnRef{Runtime: "fission", ID: "geocode"}
+	key, err := ResultKey(fn, map[string]*typedvalues.TypedValue{
+		"city":    typedvalues.MustWrap("Amsterdam"),
+		"country": typedvalues.MustWrap("NL"),
+	})
+	assert.NoError(t, err)
+
+	// The key should not depend on the order of the inputs.
+	for i := 0; i < 10; i++ {
+		other, err := ResultKey(fn, map[string]*typedvalues.TypedValue{
+			"country": typedvalues.MustWrap("NL"),
+			"city":    typedvalues.MustWrap("Amsterdam"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, key, other)
+	}
+
+	// The key should be specific to the inputs and the function.
+	other, err := ResultKey(fn, map[string]*typedvalues.TypedValue{
+		"city": typedvalues.MustWrap("Amsterdam"),
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+	other, err = ResultKey(&types.FnRef{Runtime: "fission", ID: "reverse-geocode"}, map[string]*typedvalues.TypedValue{
+		"city":    typedvalues.MustWrap("Amsterdam"),
+		"country": typedvalues.MustWrap("NL"),
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestResultKey_FieldBoundaries(t *testing.T) {
+	fn := &types.FnRef{Runtime: "fission", ID: "geocode"}
+	city := typedvalues.MustWrap("Amsterdam")
+	country := typedvalues.MustWrap("NL")
+	key, err := ResultKey(fn, map[string]*typedvalues.TypedValue{
+		"city":    city,
+		"country": country,
+	})
+	assert.NoError(t, err)
+
+	// An input name that contains the other input should not result in the same key.
+	cityBytes, err := proto.Marshal(city)
+	assert.NoError(t, err)
+	other, err := ResultKey(fn, map[string]*typedvalues.TypedValue{
+		"city\x00" + string(cityBytes) + "\x00country": country,
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
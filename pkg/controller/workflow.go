@@ -100,7 +100,7 @@ type WorkflowMetaController struct {
 }
 
 func NewWorkflowMetaController(api *api.Workflow, workflows *store.Workflows, executor *executor.LocalExecutor,
-	storePollInterval time.Duration) *WorkflowMetaController {
+	storePollInterval time.Duration, cfg ctrl.Config) *WorkflowMetaController {
 
 	return &WorkflowMetaController{
 		api:       api,
@@ -111,9 +111,9 @@ func NewWorkflowMetaController(api *api.Workflow, workflows *store.Workflows, ex
 			NewWorkflowNotificationSensor(workflows),
 			NewWorkflowStorePollSensor(workflows, storePollInterval),
 		},
-		system: ctrl.NewSystem(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
+		system: ctrl.NewSystemWithConfig(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
 			return NewWorkflowController(api, executor, event.Aggregate.Id), nil
-		}),
+		}, cfg),
 	}
 }
 
@@ -135,6 +135,20 @@ func (c *WorkflowMetaController) Run() {
 	})
 }
 
+// Drain stops the controller from evaluating workflows, and waits until the workflows that are being parsed have been
+// parsed or the context is done.
+func (c *WorkflowMetaController) Drain(ctx context.Context) error {
+	for _, sensor := range c.sensors {
+		if err := sensor.Close(); err != nil {
+			log.Warnf("Failed to stop sensor: %v", err)
+		}
+	}
+	if err := c.system.Close(); err != nil {
+		log.Warnf("Failed to stop control system: %v", err)
+	}
+	return c.executor.Drain(ctx)
+}
+
 func (c *WorkflowMetaController) Close() error {
 	err := c.executor.Close()
 	err = c.system.Close()
@@ -0,0 +1,369 @@
+// package bolt contains an implementation of the fes backend using an embedded, file-based BoltDB database.
+//
+// This implementation is intended for single-node deployments, such as edge or development setups, that need the
+// event streams to survive a restart without having to operate a separate event store, such as NATS Streaming.
+// Because the database file is locked by a single process, the backend cannot be shared between multiple bundles.
+package bolt
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	defaultFileMode    = 0600
+	defaultOpenTimeout = 10 * time.Second
+)
+
+var (
+	// bucketEvents is the root bucket, containing a nested bucket with the event stream for each aggregate.
+	bucketEvents = []byte("events")
+
+	// bucketSnapshots contains the latest snapshot for each aggregate, keyed by the aggregate.
+	bucketSnapshots = []byte("snapshots")
+
+	dbSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "fes",
+		Subsystem: "bolt",
+		Name:      "size_bytes",
+		Help:      "Size of the database file in bytes.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(dbSize)
+}
+
+// Config contains the user-configurable options of the BoltDB backend.
+type Config struct {
+	// Path is the location of the database file. If the file does not exist, it will be created.
+	Path string
+
+	// OpenTimeout is the time to wait for the lock on the database file. If set to 0, a default of 10 seconds is used.
+	OpenTimeout time.Duration
+}
+
+// Backend is a persistent, fes-compatible backend that stores the event streams in an embedded BoltDB database.
+//
+// Each aggregate (or parent aggregate) is stored as a separate bucket in which the events are ordered by an
+// auto-incrementing sequence. Similar to the NATS backend, this sequence is used as the ID of the event, unless the
+// event already has an ID.
+type Backend struct {
+	pubsub.Publisher
+	Config
+	db *bolt.DB
+}
+
+// Open opens (or creates) the database file specified in the config, returning a backend on top of it.
+func Open(cfg Config) (*Backend, error) {
+	if len(cfg.Path) == 0 {
+		return nil, errors.New("no path provided for the database file")
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+
+	db, err := bolt.Open(cfg.Path, defaultFileMode, &bolt.Options{
+		Timeout: cfg.OpenTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database file %s: %v", cfg.Path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{bucketEvents, bucketSnapshots} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Backend{
+		Publisher: pubsub.NewPublisher(),
+		Config:    cfg,
+		db:        db,
+	}, nil
+}
+
+// Append persists the event in the database and publishes it to the subscribers of the backend.
+func (b *Backend) Append(event *fes.Event) error {
+	if err := fes.ValidateEvent(event); err != nil {
+		return err
+	}
+	key := *event.Aggregate
+	if event.Parent != nil {
+		key = *event.Parent
+	}
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.Bucket(bucketEvents).CreateBucketIfNotExists(toBucketKey(key))
+		if err != nil {
+			return err
+		}
+		// The sequence is equal to the number of events in the bucket, since events are never removed from a bucket;
+		// only the bucket as a whole can be purged.
+		if err := fes.ValidateExpectedGeneration(event, int64(bucket.Sequence())); err != nil {
+			return err
+		}
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		// Preserve the ID of events that already have one, such as imported events.
+		if len(event.Id) == 0 {
+			event.Id = fmt.Sprintf("%d", seq)
+		}
+		event.Sequence = int64(seq)
+		data, err := proto.Marshal(event)
+		if err != nil {
+			return fes.ErrCorruptedEventPayload.WithEvent(event).WithError(err)
+		}
+		return bucket.Put(toSeqKey(seq), data)
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Event appended: %s - %v", event.Aggregate.Format(), event.Type)
+
+	err = b.Publish(event)
+
+	// Record the time it took for the event to be propagated from publisher to subscriber.
+	ts, _ := ptypes.Timestamp(event.Timestamp)
+	backend.EventDelay.Observe(float64(time.Now().Sub(ts).Nanoseconds()))
+	backend.EventsAppended.WithLabelValues(event.Type).Inc()
+	b.updateSizeGauge()
+	return err
+}
+
+// AppendsConditionally reports that the backend guards conditional appends, which are checked within the transaction
+// of the append.
+func (b *Backend) AppendsConditionally() bool {
+	return true
+}
+
+// Get returns all events related to a specific aggregate in the order in which they were appended.
+func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	events := []*fes.Event{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketEvents).Bucket(toBucketKey(key))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			event := &fes.Event{}
+			if err := proto.Unmarshal(v, event); err != nil {
+				return fes.ErrCorruptedEventPayload.WithAggregate(&key).WithError(err)
+			}
+			events = append(events, event)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// List returns all aggregates in the database that match the matcher. A nil matcher is considered a 'match-all'.
+func (b *Backend) List(matcher fes.AggregateMatcher) ([]fes.Aggregate, error) {
+	var results []fes.Aggregate
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketEvents).ForEach(func(k, v []byte) error {
+			// Nested buckets have a nil value
+			if v != nil {
+				return nil
+			}
+			key, ok := toAggregate(k)
+			if !ok {
+				logrus.Warnf("Ignoring invalid aggregate key in database: %s", string(k))
+				return nil
+			}
+			if matcher == nil || matcher(key) {
+				results = append(results, key)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SaveSnapshot stores the snapshot in the database, replacing the existing snapshot of the aggregate.
+func (b *Backend) SaveSnapshot(snapshot *fes.Snapshot) error {
+	if err := fes.ValidateSnapshot(snapshot); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return fes.ErrInvalidSnapshot.WithAggregate(snapshot.Aggregate).WithError(err)
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSnapshots).Put(toBucketKey(*snapshot.Aggregate), data)
+	})
+}
+
+// GetSnapshot returns the latest snapshot of the aggregate, or nil if there is none.
+func (b *Backend) GetSnapshot(key fes.Aggregate) (*fes.Snapshot, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	var snapshot *fes.Snapshot
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketSnapshots).Get(toBucketKey(key))
+		if data == nil {
+			return nil
+		}
+		snapshot = &fes.Snapshot{}
+		if err := proto.Unmarshal(data, snapshot); err != nil {
+			return fes.ErrInvalidSnapshot.WithAggregate(&key).WithError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Purge removes the event stream and the snapshot of the aggregate from the database.
+func (b *Backend) Purge(key fes.Aggregate) (int, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return 0, err
+	}
+	var count int
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucketKey := toBucketKey(key)
+		if err := tx.Bucket(bucketSnapshots).Delete(bucketKey); err != nil {
+			return err
+		}
+		events := tx.Bucket(bucketEvents)
+		bucket := events.Bucket(bucketKey)
+		if bucket == nil {
+			return nil
+		}
+		count = bucket.Stats().KeyN
+		return events.DeleteBucket(bucketKey)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Replace atomically replaces the event stream of the aggregate with the events in a single transaction, removing its
+// snapshot. The bucket of the aggregate is recreated, so the sequences of the events restart at 1.
+func (b *Backend) Replace(key fes.Aggregate, events ...*fes.Event) error {
+	if err := fes.ValidateReplacement(key, events); err != nil {
+		return err
+	}
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucketKey := toBucketKey(key)
+		if err := tx.Bucket(bucketSnapshots).Delete(bucketKey); err != nil {
+			return err
+		}
+		root := tx.Bucket(bucketEvents)
+		if root.Bucket(bucketKey) != nil {
+			if err := root.DeleteBucket(bucketKey); err != nil {
+				return err
+			}
+		}
+		bucket, err := root.CreateBucket(bucketKey)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			seq, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			if len(event.Id) == 0 {
+				event.Id = fmt.Sprintf("%d", seq)
+			}
+			event.Sequence = int64(seq)
+			data, err := proto.Marshal(event)
+			if err != nil {
+				return fes.ErrCorruptedEventPayload.WithEvent(event).WithError(err)
+			}
+			if err := bucket.Put(toSeqKey(seq), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Event stream replaced: %s - %d events", key.Format(), len(events))
+
+	for _, event := range events {
+		if pubErr := b.Publish(event); pubErr != nil {
+			err = pubErr
+		}
+		backend.EventsAppended.WithLabelValues(event.Type).Inc()
+	}
+	b.updateSizeGauge()
+	return err
+}
+
+// Close closes the publisher and releases the lock on the database file.
+func (b *Backend) Close() error {
+	err := b.Publisher.Close()
+	if dbErr := b.db.Close(); dbErr != nil {
+		return dbErr
+	}
+	return err
+}
+
+func (b *Backend) updateSizeGauge() {
+	fi, err := os.Stat(b.db.Path())
+	if err != nil {
+		return
+	}
+	dbSize.Set(float64(fi.Size()))
+}
+
+func toBucketKey(key fes.Aggregate) []byte {
+	return []byte(key.Format())
+}
+
+func toAggregate(bucketKey []byte) (fes.Aggregate, bool) {
+	parts := strings.SplitN(string(bucketKey), "/", 2)
+	if len(parts) < 2 {
+		return fes.Aggregate{}, false
+	}
+	return fes.Aggregate{
+		Type: parts[0],
+		Id:   parts[1],
+	}, true
+}
+
+// toSeqKey encodes the sequence as big-endian bytes to ensure that the keys are ordered by sequence in the bucket.
+func toSeqKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
@@ -0,0 +1,352 @@
+package bolt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(a fes.Aggregate, data []byte) *fes.Event {
+	event, err := fes.NewEvent(a, &wrappers.BytesValue{
+		Value: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func setupBackend(t testing.TB) (*Backend, func()) {
+	dir, err := ioutil.TempDir("", "fes-bolt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Open(Config{Path: filepath.Join(dir, "events.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, func() {
+		b.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func BenchmarkRoundtripSingleKey(b *testing.B) {
+	store, teardown := setupBackend(b)
+	defer teardown()
+	sub := store.Subscribe()
+
+	// Generate test data
+	events := make([]*fes.Event, b.N)
+	for i := 0; i < b.N; i++ {
+		key := fes.Aggregate{Type: "type", Id: "id"}
+		events[i] = newEvent(key, []byte(fmt.Sprintf("event-%d", i)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := store.Append(events[i])
+		if err != nil {
+			panic(err)
+		}
+		<-sub.Ch
+	}
+}
+
+func TestBackend_Append(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	err := store.Append(newEvent(key, []byte("event 1")))
+	assert.NoError(t, err)
+
+	// Test if invalid event is rejected by the event store
+	event2 := newEvent(key, []byte("event 2"))
+	event2.Aggregate = &fes.Aggregate{}
+	err = store.Append(event2)
+	assert.Equal(t, err.(fes.EventStoreErr).S, fes.ErrInvalidEvent.Error())
+
+	// Event under a parent aggregate
+	event3 := newEvent(fes.Aggregate{Type: "child", Id: "id"}, []byte("event 3"))
+	event3.Parent = &key
+	err = store.Append(event3)
+	assert.NoError(t, err)
+
+	events, err := store.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "1", events[0].Id)
+	assert.Equal(t, "2", events[1].Id)
+	assert.EqualValues(t, 1, events[0].Sequence)
+	assert.EqualValues(t, 2, events[1].Sequence)
+}
+
+func TestBackend_AppendExpectedGeneration(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	assert.NoError(t, store.Append(newEvent(key, []byte("event 1"))))
+
+	// Event with the correct expected generation
+	event2 := newEvent(key, []byte("event 2"))
+	event2.ExpectedGeneration = 1
+	assert.NoError(t, store.Append(event2))
+
+	// Event with an outdated expected generation
+	event3 := newEvent(key, []byte("event 3"))
+	event3.ExpectedGeneration = 1
+	err := store.Append(event3)
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	events, err := store.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestBackend_AppendExpectNoEvents(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	event1 := newEvent(key, []byte("event 1"))
+	event1.ExpectedGeneration = fes.ExpectNoEvents
+	assert.NoError(t, store.Append(event1))
+
+	// The stream already exists, so a concurrent creation should be rejected.
+	event2 := newEvent(key, []byte("event 2"))
+	event2.ExpectedGeneration = fes.ExpectNoEvents
+	err := store.Append(event2)
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	events, err := store.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestBackend_GetMultiple(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+
+	for k := range events {
+		err := store.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	getEvents, err := store.Get(key)
+	assert.NoError(t, err)
+	assertEventsEqual(t, events, getEvents)
+}
+
+func TestBackend_GetNonexistent(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	getEvents, err := store.Get(fes.Aggregate{Type: "type", Id: "id"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*fes.Event{}, getEvents)
+}
+
+func TestBackend_List(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	keys := []fes.Aggregate{
+		{Type: "a", Id: "1"},
+		{Type: "a", Id: "2"},
+		{Type: "b", Id: "with/slash"},
+	}
+	for _, key := range keys {
+		err := store.Append(newEvent(key, []byte("event")))
+		assert.NoError(t, err)
+	}
+
+	all, err := store.List(nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, keys, all)
+
+	filtered, err := store.List(func(a fes.Aggregate) bool {
+		return a.Type == "b"
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{keys[2]}, filtered)
+}
+
+func TestBackend_Reopen(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		err := store.Append(events[k])
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, store.Close())
+
+	reopened, err := Open(store.Config)
+	assert.NoError(t, err)
+	defer reopened.Close()
+	getEvents, err := reopened.Get(key)
+	assert.NoError(t, err)
+	assertEventsEqual(t, events, getEvents)
+
+	// The sequence should continue where it left off.
+	err = reopened.Append(newEvent(key, []byte("event 3")))
+	assert.NoError(t, err)
+	getEvents, err = reopened.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, getEvents, 3)
+	assert.Equal(t, "3", getEvents[2].Id)
+}
+
+func TestBackend_Snapshot(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+
+	snapshot, err := store.GetSnapshot(key)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	for i := int64(1); i <= 2; i++ {
+		data, err := ptypes.MarshalAny(&wrappers.Int64Value{Value: i})
+		assert.NoError(t, err)
+		expected := &fes.Snapshot{
+			Aggregate: &key,
+			Data:      data,
+			Offset:    i,
+			Timestamp: ptypes.TimestampNow(),
+		}
+		assert.NoError(t, store.SaveSnapshot(expected))
+
+		snapshot, err = store.GetSnapshot(key)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expected, snapshot))
+	}
+}
+
+func TestBackend_Subscribe(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	sub := store.Subscribe(pubsub.SubscriptionOptions{
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, key.Type),
+	})
+
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := store.Append(events[k])
+		assert.NoError(t, err)
+	}
+	store.Unsubscribe(sub)
+
+	var receivedEvents []*fes.Event
+	for msg := range sub.Ch {
+		event, ok := msg.(*fes.Event)
+		assert.True(t, ok)
+		receivedEvents = append(receivedEvents, event)
+	}
+	assert.EqualValues(t, events, receivedEvents)
+}
+
+func assertEventsEqual(t *testing.T, expected []*fes.Event, actual []*fes.Event) {
+	assert.Len(t, actual, len(expected))
+	for i := range expected {
+		if i < len(actual) {
+			assert.True(t, proto.Equal(expected[i], actual[i]), "expected %v, got %v", expected[i], actual[i])
+		}
+	}
+}
+
+func TestBackend_Purge(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, store.Append(newEvent(key, []byte(fmt.Sprintf("event %d", i)))))
+	}
+	data, err := ptypes.MarshalAny(&wrappers.Int64Value{Value: 1})
+	assert.NoError(t, err)
+	assert.NoError(t, store.SaveSnapshot(&fes.Snapshot{
+		Aggregate: &key,
+		Data:      data,
+		Offset:    1,
+		Timestamp: ptypes.TimestampNow(),
+	}))
+
+	n, err := store.Purge(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	events, err := store.Get(key)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	snapshot, err := store.GetSnapshot(key)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+	keys, err := store.List(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// Purging a nonexistent aggregate is a no-op
+	n, err = store.Purge(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestBackend_Replace(t *testing.T) {
+	store, teardown := setupBackend(t)
+	defer teardown()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, store.Append(newEvent(key, []byte(fmt.Sprintf("event %d", i)))))
+	}
+	data, err := ptypes.MarshalAny(&wrappers.Int64Value{Value: 1})
+	assert.NoError(t, err)
+	assert.NoError(t, store.SaveSnapshot(&fes.Snapshot{
+		Aggregate: &key,
+		Data:      data,
+		Offset:    1,
+		Timestamp: ptypes.TimestampNow(),
+	}))
+
+	replacement := newEvent(key, []byte("replacement"))
+	assert.NoError(t, store.Replace(key, replacement))
+	events, err := store.Get(key)
+	assert.NoError(t, err)
+	assertEventsEqual(t, []*fes.Event{replacement}, events)
+	assert.EqualValues(t, 1, events[0].GetSequence())
+	assert.True(t, events[0].GetHints().GetReplaces())
+	snapshot, err := store.GetSnapshot(key)
+	assert.NoError(t, err)
+	assert.Nil(t, snapshot)
+
+	// Appending continues the replaced stream.
+	assert.NoError(t, store.Append(newEvent(key, []byte("next"))))
+	events, err = store.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.EqualValues(t, 2, events[1].GetSequence())
+
+	// Events of other aggregates cannot be part of the replacement.
+	other := fes.Aggregate{Type: "type", Id: "other"}
+	assert.Error(t, store.Replace(key, newEvent(other, []byte("other"))))
+}
@@ -66,6 +66,7 @@ type Backend struct {
 	Config
 	buf       *lru.Cache // map[fes.Aggregate][]*fes.Event
 	store     map[fes.Aggregate][]*fes.Event
+	snapshots map[fes.Aggregate]*fes.Snapshot
 	storeLock sync.RWMutex
 	entries   *int32
 }
@@ -91,6 +92,7 @@ func NewBackend(cfgs ...Config) *Backend {
 		Publisher: pubsub.NewPublisher(),
 		Config:    cfg,
 		store:     map[fes.Aggregate][]*fes.Event{},
+		snapshots: map[fes.Aggregate]*fes.Snapshot{},
 		entries:   &e,
 	}
 
@@ -131,10 +133,15 @@ func (b *Backend) Append(event *fes.Event) error {
 		return ErrEventLimitExceeded.WithAggregate(&key)
 	}
 
+	if err := fes.ValidateExpectedGeneration(event, int64(len(events))); err != nil {
+		return err
+	}
+
 	if !fromStore {
 		b.promote(key)
 	}
 
+	event.Sequence = int64(len(events) + 1)
 	b.store[key] = append(events, event)
 	logrus.Infof("Event appended: %s - %v", event.Aggregate.Format(), event.Type)
 
@@ -154,6 +161,12 @@ func (b *Backend) Append(event *fes.Event) error {
 	return err
 }
 
+// AppendsConditionally reports that the backend guards conditional appends, since all of its clients share the same
+// process.
+func (b *Backend) AppendsConditionally() bool {
+	return true
+}
+
 func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
 	if err := fes.ValidateAggregate(&key); err != nil {
 		return nil, err
@@ -167,11 +180,37 @@ func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
 	return events, nil
 }
 
+// SaveSnapshot stores the snapshot of an aggregate in the backend. The snapshot is removed along with the events of the
+// aggregate once these are evicted.
+func (b *Backend) SaveSnapshot(snapshot *fes.Snapshot) error {
+	if err := fes.ValidateSnapshot(snapshot); err != nil {
+		return err
+	}
+	key := *snapshot.Aggregate
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+	if _, ok, _ := b.get(key); !ok {
+		return fes.ErrEntityNotFound.WithAggregate(&key)
+	}
+	b.snapshots[key] = snapshot
+	return nil
+}
+
+// GetSnapshot returns the latest snapshot of the aggregate, or nil if there is none.
+func (b *Backend) GetSnapshot(key fes.Aggregate) (*fes.Snapshot, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	b.storeLock.RLock()
+	defer b.storeLock.RUnlock()
+	return b.snapshots[key], nil
+}
+
 func (b *Backend) Len() int {
 	return int(atomic.LoadInt32(b.entries))
 }
 
-// Note: list does not return the cache contents
+// List returns the keys of both the active and the completed (buffered) event streams that match the matcher.
 func (b *Backend) List(matcher fes.AggregateMatcher) ([]fes.Aggregate, error) {
 	var results []fes.Aggregate
 	b.storeLock.RLock()
@@ -180,10 +219,87 @@ func (b *Backend) List(matcher fes.AggregateMatcher) ([]fes.Aggregate, error) {
 			results = append(results, key)
 		}
 	}
+	for _, k := range b.buf.Keys() {
+		key := assertAggregate(k)
+		if matcher == nil || matcher(key) {
+			results = append(results, key)
+		}
+	}
 	b.storeLock.RUnlock()
 	return results, nil
 }
 
+// Purge removes the event stream of the aggregate from the backend, regardless of whether it is active or completed.
+func (b *Backend) Purge(key fes.Aggregate) (int, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return 0, err
+	}
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+	events, ok, fromStore := b.get(key)
+	if !ok {
+		return 0, nil
+	}
+	if fromStore {
+		delete(b.store, key)
+		delete(b.snapshots, key)
+		// Entries in the store are not tracked by the buffer, so update the counters here.
+		atomic.AddInt32(b.entries, -1)
+		cacheKeys.WithLabelValues(key.Type).Dec()
+	} else {
+		// Removing the entry from the buffer triggers the eviction callback, which updates the counters.
+		b.buf.Remove(key)
+	}
+	return len(events), nil
+}
+
+// Replace atomically replaces the event stream of the aggregate with the events, removing its snapshot.
+func (b *Backend) Replace(key fes.Aggregate, events ...*fes.Event) error {
+	if err := fes.ValidateReplacement(key, events); err != nil {
+		return err
+	}
+	if b.MaxEventsPerKey > 0 && len(events) > b.MaxEventsPerKey {
+		return ErrEventLimitExceeded.WithAggregate(&key)
+	}
+
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+
+	_, ok, fromStore := b.get(key)
+	if !ok && !b.fitBuffer() {
+		return fes.ErrEventStoreOverflow.WithAggregate(&key)
+	}
+	if ok && !fromStore {
+		// Removing the entry from the buffer triggers the eviction callback, which updates the counters.
+		b.buf.Remove(key)
+	}
+	if !fromStore {
+		atomic.AddInt32(b.entries, 1)
+		cacheKeys.WithLabelValues(key.Type).Inc()
+	}
+
+	stream := make([]*fes.Event, len(events))
+	for i, event := range events {
+		event.Sequence = int64(i + 1)
+		stream[i] = event
+	}
+	b.store[key] = stream
+	delete(b.snapshots, key)
+	logrus.Infof("Event stream replaced: %s - %d events", key.Format(), len(events))
+
+	if events[len(events)-1].GetHints().GetCompleted() {
+		b.demote(key)
+	}
+	var err error
+	for _, event := range events {
+		if pubErr := b.Publish(event); pubErr != nil {
+			err = pubErr
+		}
+		backend.EventsAppended.WithLabelValues(event.Type).Inc()
+	}
+	return err
+}
+
 func (b *Backend) get(key fes.Aggregate) (events []*fes.Event, ok bool, fromStore bool) {
 	// First check the store
 	i, ok := b.store[key]
@@ -233,6 +349,8 @@ func (b *Backend) fitBuffer() bool {
 func (b *Backend) evict(k, v interface{}) {
 	logrus.Debugf("Evicted: %v", k)
 
+	delete(b.snapshots, assertAggregate(k))
+
 	// Update gauges
 	t := assertAggregate(k).Type
 	events := assertEventList(v)
@@ -162,6 +162,7 @@ func TestBackend_Append(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mem.Len(), 1)
 	assert.Equal(t, len(mem.mustGet(fes.Aggregate{Type: "type", Id: "id"})), 2)
+	assert.EqualValues(t, 2, event3.Sequence)
 
 	// Event under new aggregate
 	event4, err := fes.NewEvent(fes.Aggregate{Type: "Type", Id: "other"}, &wrappers.BytesValue{
@@ -175,6 +176,39 @@ func TestBackend_Append(t *testing.T) {
 	assert.Equal(t, len(mem.mustGet(fes.Aggregate{Type: "type", Id: "id"})), 2)
 }
 
+func TestBackend_AppendExpectedGeneration(t *testing.T) {
+	mem := setupBackend()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	assert.NoError(t, mem.Append(newEvent(key, []byte("event 1"))))
+
+	// Event with the correct expected generation
+	event2 := newEvent(key, []byte("event 2"))
+	event2.ExpectedGeneration = 1
+	assert.NoError(t, mem.Append(event2))
+
+	// Event with an outdated expected generation
+	event3 := newEvent(key, []byte("event 3"))
+	event3.ExpectedGeneration = 1
+	err := mem.Append(event3)
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	assert.Equal(t, 2, len(mem.mustGet(key)))
+}
+
+func TestBackend_AppendExpectNoEvents(t *testing.T) {
+	mem := setupBackend()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	event1 := newEvent(key, []byte("event 1"))
+	event1.ExpectedGeneration = fes.ExpectNoEvents
+	assert.NoError(t, mem.Append(event1))
+
+	// The stream already exists, so a concurrent creation should be rejected.
+	event2 := newEvent(key, []byte("event 2"))
+	event2.ExpectedGeneration = fes.ExpectNoEvents
+	err := mem.Append(event2)
+	assert.True(t, fes.ErrConcurrentModification.Is(err))
+	assert.Equal(t, 1, len(mem.mustGet(key)))
+}
+
 func TestBackend_GetMultiple(t *testing.T) {
 	mem := setupBackend()
 	key := fes.Aggregate{Type: "type", Id: "id"}
@@ -236,3 +270,69 @@ func (b *Backend) mustGet(key fes.Aggregate) []*fes.Event {
 	}
 	return val
 }
+
+func TestBackend_Purge(t *testing.T) {
+	mem := setupBackend()
+	active := fes.Aggregate{Type: "type", Id: "active"}
+	completed := fes.Aggregate{Type: "type", Id: "completed"}
+	assert.NoError(t, mem.Append(newEvent(active, []byte("event 1"))))
+	completedEvent := newEvent(completed, []byte("event 1"))
+	completedEvent.Hints = &fes.EventHints{
+		Completed: true,
+	}
+	assert.NoError(t, mem.Append(completedEvent))
+
+	// Both the active and the completed stream should be listed
+	keys, err := mem.List(nil)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []fes.Aggregate{active, completed}, keys)
+
+	for _, key := range keys {
+		n, err := mem.Purge(key)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	assert.Equal(t, 0, mem.Len())
+	keys, err = mem.List(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// Purging a nonexistent aggregate is a no-op
+	n, err := mem.Purge(active)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestBackend_Replace(t *testing.T) {
+	mem := setupBackend()
+	active := fes.Aggregate{Type: "type", Id: "active"}
+	completed := fes.Aggregate{Type: "type", Id: "completed"}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, mem.Append(newEvent(active, []byte(fmt.Sprintf("event %d", i)))))
+	}
+	completedEvent := newEvent(completed, []byte("event 1"))
+	completedEvent.Hints = &fes.EventHints{
+		Completed: true,
+	}
+	assert.NoError(t, mem.Append(completedEvent))
+
+	for _, key := range []fes.Aggregate{active, completed, {Type: "type", Id: "new"}} {
+		replacement := newEvent(key, []byte("replacement"))
+		assert.NoError(t, mem.Replace(key, replacement))
+		events := mem.mustGet(key)
+		assert.Len(t, events, 1)
+		assert.EqualValues(t, 1, events[0].GetSequence())
+		assert.True(t, events[0].GetHints().GetReplaces())
+
+		// Appending continues the replaced stream.
+		assert.NoError(t, mem.Append(newEvent(key, []byte("next"))))
+		events = mem.mustGet(key)
+		assert.Len(t, events, 2)
+		assert.EqualValues(t, 2, events[1].GetSequence())
+	}
+	assert.Equal(t, 3, mem.Len())
+
+	// Events of other aggregates cannot be part of the replacement.
+	assert.Error(t, mem.Replace(active, newEvent(completed, []byte("other"))))
+	assert.Error(t, mem.Replace(active))
+}
@@ -1,3 +1,10 @@
+// Package nats provides an event store backend on top of NATS Streaming.
+//
+// NATS Streaming does not support conditional publishes, so the backend ignores the expected generation of events and
+// appends them unconditionally; it does not implement fes.ConditionalAppender. Checking the expected generation on the
+// client would not guard against the appends of other clients, while it would add a round-trip to every append.
+// Deployments that rely on the optimistic concurrency control of the event store, such as multiple replicas of the
+// engine, need a backend that guards appends on the server.
 package nats
 
 import (
@@ -221,7 +228,8 @@ func (es *EventStore) Close() error {
 	return nil
 }
 
-// Append publishes (and persists) an event on the NATS message queue
+// Append publishes (and persists) an event on the NATS message queue. The expected generation of the event is ignored;
+// see the package documentation.
 func (es *EventStore) Append(event *fes.Event) error {
 	if err := fes.ValidateEvent(event); err != nil {
 		return err
@@ -314,6 +322,8 @@ func toEvent(msg *stan.Msg) (*fes.Event, error) {
 		return nil, err
 	}
 
+	// Each aggregate has its own channel, so the sequence of the message is the sequence of the event in the stream.
 	e.Id = fmt.Sprintf("%d", msg.Sequence)
+	e.Sequence = int64(msg.Sequence)
 	return e, nil
 }
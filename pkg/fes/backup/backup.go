@@ -0,0 +1,198 @@
+// Package backup provides the export and import of event streams, which allows the history of workflows and
+// invocations to be backed up, restored, or moved between environments.
+//
+// Events are exported with their original IDs, timestamps and parent links. When imported, the events are appended
+// to the target backend in the order in which they were exported. Because the events of an aggregate are exported
+// in order, the entities projected from the imported event streams are equal to the original entities.
+//
+// Note that an export that is filtered on time contains only part of the event streams, which cannot be projected to
+// entities on their own; they should be imported into a backend that contains the preceding events of the streams.
+//
+// Events that are already present in the target backend are skipped, so an import can safely be repeated, for
+// example after it was aborted halfway.
+package backup
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Filter selects the events that should be exported. The zero value matches all events.
+//
+// If Since or Until is set, the export contains only the events of the streams that occurred within that range,
+// rather than complete event streams.
+type Filter struct {
+	// Types limits the export to the aggregates of these types. If empty, all aggregates are exported.
+	Types []string
+
+	// Since, if not zero, excludes events that occurred before this time.
+	Since time.Time
+
+	// Until, if not zero, excludes events that occurred at or after this time.
+	Until time.Time
+}
+
+// MatchAggregate checks whether the events of the aggregate should be exported.
+func (f Filter) MatchAggregate(aggregate fes.Aggregate) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == aggregate.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchEvent checks whether the event falls within the time range of the filter.
+func (f Filter) MatchEvent(event *fes.Event) bool {
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	ts, err := ptypes.Timestamp(event.GetTimestamp())
+	if err != nil {
+		return false
+	}
+	if !f.Since.IsZero() && ts.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !ts.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Export passes all events in the backend that match the filter to the provided function, aggregate by aggregate.
+// If the function returns an error, the export is aborted. It returns the number of exported events.
+func Export(backend fes.Backend, filter Filter, fn func(event *fes.Event) error) (int, error) {
+	aggregates, err := backend.List(filter.MatchAggregate)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	for _, aggregate := range aggregates {
+		events, err := backend.Get(aggregate)
+		if err != nil {
+			return count, err
+		}
+		for _, event := range events {
+			if !filter.MatchEvent(event) {
+				continue
+			}
+			if err := fn(event); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Import appends the events returned by next to the backend until next returns io.EOF. It returns the number of
+// imported events, and the number of events that were skipped because they were already present in the backend.
+//
+// The events are appended unconditionally, ignoring the expected generation of the original event. An event is
+// considered to be present if the event stream in the backend contains an event with the same ID, type and timestamp,
+// or, if the imported event has no ID, an event of the same aggregate with the same type and timestamp. If the event
+// stream contains an event with the same ID, but a different type or timestamp, the event stream in the backend is not
+// the same as the imported one, and the import is aborted.
+func Import(backend fes.Backend, next func() (*fes.Event, error)) (imported int, skipped int, err error) {
+	// Only the event streams that already exist in the backend have to be checked for events that are present.
+	aggregates, err := backend.List(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	existing := make(map[fes.Aggregate]map[string]*fes.Event, len(aggregates))
+	for _, aggregate := range aggregates {
+		existing[aggregate] = nil
+	}
+	for {
+		event, err := next()
+		if err == io.EOF {
+			return imported, skipped, nil
+		}
+		if err != nil {
+			return imported, skipped, err
+		}
+		if err := fes.ValidateEvent(event); err != nil {
+			return imported, skipped, err
+		}
+
+		// Load the events that are already present in the event stream once, if the event stream exists.
+		stream := *event.Aggregate
+		if event.Parent != nil {
+			stream = *event.Parent
+		}
+		events, ok := existing[stream]
+		if events == nil {
+			events = map[string]*fes.Event{}
+			if ok {
+				events, err = loadEvents(backend, stream)
+				if err != nil {
+					return imported, skipped, err
+				}
+			}
+			existing[stream] = events
+		}
+		var present *fes.Event
+		if len(event.Id) > 0 {
+			present, ok = events[idKey(event)]
+		} else {
+			present, ok = events[timestampKey(event)]
+		}
+		if ok {
+			if present.Type != event.Type || !proto.Equal(present.Timestamp, event.Timestamp) {
+				return imported, skipped, fmt.Errorf("event stream %s already contains a different event with id %s",
+					stream.Format(), event.Id)
+			}
+			skipped++
+			continue
+		}
+
+		event.ExpectedGeneration = 0
+		if err := backend.Append(event); err != nil {
+			return imported, skipped, err
+		}
+		indexEvent(events, event)
+		imported++
+	}
+}
+
+// loadEvents returns the events of the event stream in the backend, indexed by indexEvent.
+func loadEvents(backend fes.Backend, stream fes.Aggregate) (map[string]*fes.Event, error) {
+	events, err := backend.Get(stream)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]*fes.Event, 2*len(events))
+	for _, event := range events {
+		indexEvent(index, event)
+	}
+	return index, nil
+}
+
+// indexEvent adds the event to the index, both by its ID and by its aggregate, type and timestamp. The latter is used to
+// find the events that were exported without an ID, which the backend might have assigned its own ID when they were
+// imported. The aggregate is part of the key, because the events of different tasks in the event stream of an
+// invocation can share the type and timestamp.
+func indexEvent(index map[string]*fes.Event, event *fes.Event) {
+	if len(event.Id) > 0 {
+		index[idKey(event)] = event
+	}
+	index[timestampKey(event)] = event
+}
+
+func idKey(event *fes.Event) string {
+	return "id:" + event.Id
+}
+
+func timestampKey(event *fes.Event) string {
+	return fmt.Sprintf("event:%s/%s@%d.%09d", event.GetAggregate().Format(), event.Type,
+		event.GetTimestamp().GetSeconds(), event.GetTimestamp().GetNanos())
+}
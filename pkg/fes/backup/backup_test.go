@@ -0,0 +1,222 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/bolt"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(a fes.Aggregate, data string, ts time.Time) *fes.Event {
+	event, err := fes.NewEvent(a, &wrappers.StringValue{
+		Value: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	event.Timestamp, err = ptypes.TimestampProto(ts)
+	if err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func setupBackend(t *testing.T) (*mem.Backend, []*fes.Event) {
+	backend := mem.NewBackend()
+	now := time.Now()
+	workflow := fes.Aggregate{Type: "workflow", Id: "wf-1"}
+	invocation := fes.Aggregate{Type: "invocation", Id: "wfi-1"}
+	task := newEvent(fes.Aggregate{Type: "task", Id: "task-1"}, "task", now.Add(-time.Minute))
+	task.Parent = &invocation
+	events := []*fes.Event{
+		newEvent(workflow, "created", now.Add(-time.Hour)),
+		newEvent(invocation, "invoked", now.Add(-2*time.Minute)),
+		task,
+		newEvent(invocation, "completed", now),
+	}
+	for i, event := range events {
+		event.Id = fmt.Sprintf("event-%d", i)
+		assert.NoError(t, backend.Append(event))
+	}
+	return backend, events
+}
+
+func export(t *testing.T, backend fes.Backend, filter Filter) []*fes.Event {
+	var exported []*fes.Event
+	_, err := Export(backend, filter, func(event *fes.Event) error {
+		exported = append(exported, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	return exported
+}
+
+func TestExport(t *testing.T) {
+	backend, events := setupBackend(t)
+
+	exported := export(t, backend, Filter{})
+	assert.ElementsMatch(t, events, exported)
+
+	exported = export(t, backend, Filter{Types: []string{"invocation"}})
+	assert.EqualValues(t, events[1:], exported)
+
+	exported = export(t, backend, Filter{
+		Since: time.Now().Add(-90 * time.Minute),
+		Until: time.Now().Add(-30 * time.Minute),
+	})
+	assert.EqualValues(t, events[:1], exported)
+}
+
+func TestImportPreservesEvents(t *testing.T) {
+	source, events := setupBackend(t)
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	target, err := bolt.Open(bolt.Config{Path: filepath.Join(dir, "events.db")})
+	assert.NoError(t, err)
+	defer target.Close()
+
+	exported := export(t, source, Filter{})
+	n, skipped, err := Import(target, sliceIterator(exported))
+	assert.NoError(t, err)
+	assert.Equal(t, len(events), n)
+	assert.Equal(t, 0, skipped)
+
+	// The order of the aggregates depends on the backend, so compare the events by their (preserved) IDs.
+	imported := export(t, target, Filter{})
+	sortByID(exported)
+	sortByID(imported)
+	assert.Len(t, imported, len(exported))
+	for i := range exported {
+		assert.True(t, proto.Equal(exported[i], imported[i]), "expected %v, got %v", exported[i], imported[i])
+	}
+}
+
+func TestImportSkipsPresentEvents(t *testing.T) {
+	source, events := setupBackend(t)
+	exported := export(t, source, Filter{})
+
+	// Importing the same events again, for example after an aborted import, should not duplicate the events.
+	target := mem.NewBackend()
+	n, skipped, err := Import(target, sliceIterator(exported[:2]))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 0, skipped)
+	n, skipped, err = Import(target, sliceIterator(exported))
+	assert.NoError(t, err)
+	assert.Equal(t, len(events)-2, n)
+	assert.Equal(t, 2, skipped)
+	assert.Len(t, export(t, target, Filter{}), len(events))
+
+	// Events without an ID are identified by their type and timestamp instead.
+	var anonymous []*fes.Event
+	for _, event := range exported {
+		event = proto.Clone(event).(*fes.Event)
+		event.Id = ""
+		anonymous = append(anonymous, event)
+	}
+	target = mem.NewBackend()
+	for i := 0; i < 2; i++ {
+		_, _, err = Import(target, sliceIterator(anonymous))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, export(t, target, Filter{}), len(events))
+}
+
+func TestImportDistinguishesAggregatesWithoutID(t *testing.T) {
+	// The events of different tasks in the event stream of an invocation can share their type and timestamp.
+	invocation := fes.Aggregate{Type: "invocation", Id: "wfi-1"}
+	now := time.Now()
+	var events []*fes.Event
+	for _, taskID := range []string{"task-1", "task-2"} {
+		event := newEvent(fes.Aggregate{Type: "task", Id: taskID}, "succeeded", now)
+		event.Parent = &invocation
+		events = append(events, event)
+	}
+
+	target := mem.NewBackend()
+	for i := 0; i < 2; i++ {
+		_, _, err := Import(target, sliceIterator(events))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, export(t, target, Filter{}), len(events))
+}
+
+func TestImportRefusesDifferentEventStream(t *testing.T) {
+	source, _ := setupBackend(t)
+	target := mem.NewBackend()
+	other := newEvent(fes.Aggregate{Type: "invocation", Id: "wfi-1"}, "other", time.Now().Add(-time.Hour))
+	other.Id = "event-1"
+	assert.NoError(t, target.Append(other))
+
+	_, _, err := Import(target, sliceIterator(export(t, source, Filter{})))
+	assert.Error(t, err)
+}
+
+func TestCodecRoundtrip(t *testing.T) {
+	_, events := setupBackend(t)
+	for _, format := range []Format{FormatProto, FormatJSONL} {
+		buf := bytes.NewBuffer(nil)
+		enc, err := NewEncoder(buf, format)
+		assert.NoError(t, err)
+		for _, event := range events {
+			assert.NoError(t, enc.Encode(event))
+		}
+		assert.NoError(t, enc.Flush())
+
+		dec, err := NewDecoder(buf, format)
+		assert.NoError(t, err)
+		var decoded []*fes.Event
+		_, _, err = Import(mem.NewBackend(), func() (*fes.Event, error) {
+			event, err := dec.Decode()
+			if err == nil {
+				decoded = append(decoded, event)
+			}
+			return event, err
+		})
+		assert.NoError(t, err, format)
+		assert.Len(t, decoded, len(events), format)
+		for i := range decoded {
+			assert.True(t, proto.Equal(events[i], decoded[i]), "%s: expected %v, got %v", format, events[i],
+				decoded[i])
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("jsonl")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSONL, format)
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func sliceIterator(events []*fes.Event) func() (*fes.Event, error) {
+	var i int
+	return func() (*fes.Event, error) {
+		if i >= len(events) {
+			return nil, io.EOF
+		}
+		i++
+		return events[i-1], nil
+	}
+}
+
+func sortByID(events []*fes.Event) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Id < events[j].Id
+	})
+}
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Format is the file format in which the events are encoded.
+type Format string
+
+const (
+	// FormatProto encodes each event as a protobuf message, prefixed with its length as a varint.
+	FormatProto Format = "proto"
+
+	// FormatJSONL encodes each event as a JSON object on a separate line.
+	FormatJSONL Format = "jsonl"
+
+	// maxEventSize limits the size of a single length-delimited event to guard against corrupt input.
+	maxEventSize = 64 * 1024 * 1024
+)
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatProto, FormatJSONL:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown format '%s' (expected '%s' or '%s')", s, FormatProto, FormatJSONL)
+	}
+}
+
+// Encoder writes events to an underlying writer in a specific format.
+type Encoder struct {
+	w         *bufio.Writer
+	format    Format
+	marshaler *jsonpb.Marshaler
+}
+
+func NewEncoder(w io.Writer, format Format) (*Encoder, error) {
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		w:         bufio.NewWriter(w),
+		format:    format,
+		marshaler: &jsonpb.Marshaler{},
+	}, nil
+}
+
+func (e *Encoder) Encode(event *fes.Event) error {
+	switch e.format {
+	case FormatJSONL:
+		if err := e.marshaler.Marshal(e.w, event); err != nil {
+			return err
+		}
+		return e.w.WriteByte('\n')
+	default:
+		data, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := e.w.Write(proto.EncodeVarint(uint64(len(data)))); err != nil {
+			return err
+		}
+		_, err = e.w.Write(data)
+		return err
+	}
+}
+
+// Flush writes any buffered events to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
+
+// Decoder reads events from an underlying reader in a specific format.
+type Decoder struct {
+	r      *bufio.Reader
+	format Format
+}
+
+func NewDecoder(r io.Reader, format Format) (*Decoder, error) {
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+	return &Decoder{
+		r:      bufio.NewReader(r),
+		format: format,
+	}, nil
+}
+
+// Decode reads the next event. It returns io.EOF if there are no more events.
+func (d *Decoder) Decode() (*fes.Event, error) {
+	event := &fes.Event{}
+	switch d.format {
+	case FormatJSONL:
+		line, err := d.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if err := jsonpb.UnmarshalString(line, event); err != nil {
+			return nil, fmt.Errorf("failed to parse event: %v", err)
+		}
+	default:
+		size, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxEventSize {
+			return nil, fmt.Errorf("event size %d exceeds the maximum of %d bytes", size, maxEventSize)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(d.r, data); err != nil {
+			return nil, fmt.Errorf("failed to read event: %v", err)
+		}
+		if err := proto.Unmarshal(data, event); err != nil {
+			return nil, fmt.Errorf("failed to parse event: %v", err)
+		}
+	}
+	return event, nil
+}
+
+// readLine returns the next non-empty line.
+func (d *Decoder) readLine() (string, error) {
+	for {
+		line, err := d.r.ReadString('\n')
+		if len(line) > 0 && line != "\n" {
+			return line, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
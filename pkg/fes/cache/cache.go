@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/fes"
@@ -18,10 +19,38 @@ var (
 		Name:      "current_cache_counts",
 		Help:      "The current number of entries in the caches",
 	}, []string{"name"})
+
+	snapshotsSaved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "snapshots_saved_total",
+		Help:      "The number of snapshots saved by the loading caches",
+	}, []string{"type"})
+
+	eventsReplayed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "events_replayed_total",
+		Help:      "The number of events replayed by the loading caches to project entities",
+	}, []string{"type"})
+
+	entityRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "repairs_total",
+		Help:      "The number of cached entities that were rebuilt from the event store after a gap in the event sequence",
+	}, []string{"type"})
+
+	eventsSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "events_skipped_total",
+		Help:      "The number of received events that were skipped, because they were already applied to the entity",
+	}, []string{"type"})
 )
 
 func init() {
-	prometheus.MustRegister(cacheCount)
+	prometheus.MustRegister(cacheCount, snapshotsSaved, eventsReplayed, entityRepairs, eventsSkipped)
 }
 
 type LRUCache struct {
@@ -132,7 +161,6 @@ func (uc *SubscribedCache) applyEvent(event *fes.Event) error {
 		fes.PubSubLabelAggregateType: event.Aggregate.Type,
 	}).Debug("Applying event to subscribed cache.")
 
-	// TODO check sequence and replay model if the sequence number is incorrect
 	if err := fes.ValidateEvent(event); err != nil {
 		return err
 	}
@@ -143,16 +171,37 @@ func (uc *SubscribedCache) applyEvent(event *fes.Event) error {
 		return err
 	}
 
-	// Apply the event on to the new copy of the entity
-	updated, err := uc.projector.Project(old, event)
-	if err != nil {
-		return err
-	}
+	var updated fes.Entity
+	switch uc.checkSequence(old, event) {
+	case sequenceNext:
+		// Apply the event on to the new copy of the entity
+		updated, err = uc.projector.Project(old, event)
+		if err != nil {
+			return err
+		}
 
-	// Replace the old entity in the cache with the new (copied) entity
-	err = uc.Put(updated)
-	if err != nil {
-		return err
+		// Replace the old entity in the cache with the new (copied) entity
+		err = uc.Put(updated)
+		if err != nil {
+			return err
+		}
+	case sequenceApplied:
+		// The entity already contains the event, typically because it was loaded from the event store after the event
+		// was appended. Only the notification is still needed.
+		updated = old
+	case sequenceDuplicate:
+		// The event was delivered again or out of order. Since the entity already contains the event and later ones,
+		// there is nothing left to do.
+		eventsSkipped.WithLabelValues(getKey(event).Type).Inc()
+		logrus.WithField(fes.PubSubLabelEventID, event.Id).Debug("SubscribedCache: skipping already applied event.")
+		return nil
+	case sequenceGap, sequenceReplaced:
+		// One or more events have been missed, or the event stream has been replaced, so the cached entity cannot be
+		// trusted. Rebuild it from the event store, which also contains the current event.
+		updated, err = uc.repair(getKey(event))
+		if err != nil {
+			return err
+		}
 	}
 
 	// Do not publish replayed events as notifications.
@@ -172,6 +221,65 @@ func (uc *SubscribedCache) applyEvent(event *fes.Event) error {
 	return nil
 }
 
+type sequenceCheck int
+
+const (
+	sequenceNext sequenceCheck = iota
+	sequenceApplied
+	sequenceDuplicate
+	sequenceGap
+	sequenceReplaced
+)
+
+// checkSequence compares the sequence of the event with the generation of the entity to determine whether the event
+// is the next event in the event stream of the entity. Events or entities without sequence information are assumed
+// to be the next event.
+//
+// An event that replaced the event stream (see fes.Replacer) restarts the sequence, so it cannot be compared with the
+// generation of an existing entity.
+func (uc *SubscribedCache) checkSequence(entity fes.Entity, event *fes.Event) sequenceCheck {
+	seq := event.GetSequence()
+	sequenced, ok := entity.(fes.SequencedEntity)
+	if seq <= 0 || !ok {
+		return sequenceNext
+	}
+	generation := sequenced.Generation()
+	switch {
+	case event.GetHints().GetReplaces() && generation > 0:
+		key := getKey(event)
+		logrus.Infof("SubscribedCache: event stream of %v has been replaced", key.Format())
+		return sequenceReplaced
+	case seq == generation+1:
+		return sequenceNext
+	case seq == generation:
+		return sequenceApplied
+	case seq < generation:
+		return sequenceDuplicate
+	default:
+		key := getKey(event)
+		logrus.Warnf("SubscribedCache: detected gap in the events of %v (expected sequence %d, but was %d)",
+			key.Format(), generation+1, seq)
+		return sequenceGap
+	}
+}
+
+// repair rebuilds the entity from the event stream in the event store and replaces the cached entity with it.
+func (uc *SubscribedCache) repair(key fes.Aggregate) (fes.Entity, error) {
+	loader, ok := uc.CacheReaderWriter.(entityLoader)
+	if !ok {
+		// Without access to the event store, the best we can do is to evict the corrupted entity.
+		uc.Invalidate(key)
+		return nil, fmt.Errorf("failed to repair %v: cache cannot load entities from the event store", key.Format())
+	}
+	entity, err := loader.getFromEventStore(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to repair %v: %v", key.Format(), err)
+	}
+	entityRepairs.WithLabelValues(key.Type).Inc()
+	logrus.Infof("SubscribedCache: repaired %v from the event store", key.Format())
+	return entity, nil
+}
+
 func (uc *SubscribedCache) Close() error {
 	close(uc.closeC)
 	return nil
@@ -203,11 +311,22 @@ func (uc *SubscribedCache) getOrCreateAggregateForEvent(event *fes.Event) (fes.E
 	return uc.projector.NewProjection(key)
 }
 
+// entityLoader is implemented by caches that are able to (re)build entities from the event store, such as the
+// LoadingCache.
+type entityLoader interface {
+	getFromEventStore(aggregate fes.Aggregate) (fes.Entity, error)
+}
+
 // LoadingCache looks into a backing data store in case there is a cache miss
+//
+// Optionally, the LoadingCache can use snapshots to avoid having to replay the entire event stream of an aggregate.
+// In that case the projection starts at the latest snapshot of the aggregate, only applying the events after it.
 type LoadingCache struct {
 	fes.CacheReaderWriter
-	client    fes.Backend
-	projector fes.Projector
+	client         fes.Backend
+	projector      fes.Projector
+	snapshots      fes.SnapshotStore
+	snapshotPolicy SnapshotPolicy
 }
 
 func NewLoadingCache(cache fes.CacheReaderWriter, client fes.Backend, projector fes.Projector) *LoadingCache {
@@ -218,6 +337,16 @@ func NewLoadingCache(cache fes.CacheReaderWriter, client fes.Backend, projector
 	}
 }
 
+// NewLoadingCacheWithSnapshots returns a LoadingCache that stores snapshots of the entities that it projects in the
+// snapshot store, according to the snapshot policy.
+func NewLoadingCacheWithSnapshots(cache fes.CacheReaderWriter, client fes.Backend, projector fes.Projector,
+	snapshots fes.SnapshotStore, policy SnapshotPolicy) *LoadingCache {
+	c := NewLoadingCache(cache, client, projector)
+	c.snapshots = snapshots
+	c.snapshotPolicy = policy
+	return c
+}
+
 // List for a LoadingCache returns the keys of all entities in the cache.
 //
 // TODO provide option to force fallback or only do quick cache lookup.
@@ -265,16 +394,23 @@ func (c *LoadingCache) getFromEventStore(aggregate fes.Aggregate) (fes.Entity, e
 		return nil, fes.ErrEntityNotFound.WithAggregate(&aggregate)
 	}
 
-	base, err := c.projector.NewProjection(aggregate)
-	if err != nil {
-		return nil, err
+	// Start from the latest snapshot if there is one, otherwise start from a new projection.
+	base, offset := c.getSnapshot(aggregate, len(events))
+	if base == nil {
+		base, err = c.projector.NewProjection(aggregate)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Reconstruct entity by replaying all events
-	entity, err := c.projector.Project(base, events...)
+	// Reconstruct entity by replaying all events after the snapshot
+	tail := events[offset:]
+	entity, err := c.projector.Project(base, tail...)
 	if err != nil {
 		return nil, err
 	}
+	eventsReplayed.WithLabelValues(aggregate.Type).Add(float64(len(tail)))
+	c.saveSnapshot(entity, len(events), len(tail))
 
 	// Cache retrieved entity
 	if err := c.Put(entity); err != nil {
@@ -283,6 +419,54 @@ func (c *LoadingCache) getFromEventStore(aggregate fes.Aggregate) (fes.Entity, e
 	return entity, nil
 }
 
+// getSnapshot returns the entity of the latest snapshot of the aggregate along with the offset of the snapshot in the
+// event stream. If there is no (usable) snapshot, it returns a nil entity.
+func (c *LoadingCache) getSnapshot(aggregate fes.Aggregate, eventCount int) (fes.Entity, int) {
+	if c.snapshots == nil {
+		return nil, 0
+	}
+	snapshot, err := c.snapshots.GetSnapshot(aggregate)
+	if err != nil {
+		logrus.Warnf("Failed to get snapshot of %v: %v", aggregate.Format(), err)
+		return nil, 0
+	}
+	if snapshot == nil {
+		return nil, 0
+	}
+	// The snapshot cannot be ahead of the event stream; the events might have been removed from the backend.
+	if snapshot.Offset > int64(eventCount) {
+		logrus.Warnf("Ignoring snapshot of %v: snapshot offset %d exceeds the number of events %d",
+			aggregate.Format(), snapshot.Offset, eventCount)
+		return nil, 0
+	}
+	entity, err := fes.ParseSnapshotData(snapshot)
+	if err != nil {
+		logrus.Warnf("Ignoring corrupt snapshot of %v: %v", aggregate.Format(), err)
+		return nil, 0
+	}
+	return entity, int(snapshot.Offset)
+}
+
+// saveSnapshot stores a snapshot of the entity if the snapshot policy requires it.
+func (c *LoadingCache) saveSnapshot(entity fes.Entity, offset int, eventsSinceSnapshot int) {
+	if c.snapshots == nil || c.snapshotPolicy == nil {
+		return
+	}
+	if !c.snapshotPolicy.ShouldSnapshot(entity, eventsSinceSnapshot) {
+		return
+	}
+	snapshot, err := fes.NewSnapshot(entity, offset)
+	if err != nil {
+		logrus.Warnf("Failed to create snapshot: %v", err)
+		return
+	}
+	if err := c.snapshots.SaveSnapshot(snapshot); err != nil {
+		logrus.Warnf("Failed to save snapshot of %v: %v", snapshot.Aggregate.Format(), err)
+		return
+	}
+	snapshotsSaved.WithLabelValues(snapshot.Aggregate.Type).Inc()
+}
+
 func (c *LoadingCache) Refresh(key fes.Aggregate) {
 	logrus.Debug("refreshing key: ", key)
 	entity, err := c.getFromEventStore(key)
@@ -2,9 +2,15 @@ package cache
 
 import (
 	"testing"
+	"time"
 
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
 	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
 	"github.com/fission/fission-workflows/pkg/fes/testutil"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,3 +67,76 @@ func TestLRUCache(t *testing.T) {
 	c3, err := cache.GetAggregate(fes.GetAggregate(e3))
 	assert.EqualValues(t, e3, c3)
 }
+
+func TestSubscribedCache_VerifySequence(t *testing.T) {
+	backend := mem.NewBackend()
+	projector := projectors.NewWorkflowInvocation()
+	key := fes.Aggregate{Type: types.TypeInvocation, Id: "wi-1"}
+	appendInvocationEvents(t, backend, key, 2)
+	c := NewSubscribedCache(NewLoadingCache(NewLRUCache(10), backend, projector), projector,
+		pubsub.NewPublisher().Subscribe())
+	defer c.Close()
+	assertGeneration := func(expected int64) {
+		entity, err := c.GetAggregate(key)
+		assert.NoError(t, err)
+		assert.EqualValues(t, expected, entity.(*types.WorkflowInvocation).Generation())
+		assert.Len(t, entity.(*types.WorkflowInvocation).GetStatus().GetDynamicTasks(), int(expected-1))
+	}
+
+	// The entity is loaded from the event store, so it already contains the events.
+	events := mustGet(t, backend, key)
+	assert.NoError(t, c.applyEvent(events[2]))
+	assertGeneration(3)
+	assert.NoError(t, c.applyEvent(events[1]))
+	assertGeneration(3)
+
+	// Missing an event should result in the entity being rebuilt from the event store.
+	appendTask(t, backend, key, "task-a")
+	appendTask(t, backend, key, "task-b")
+	events = mustGet(t, backend, key)
+	assert.Len(t, events, 5)
+	assert.NoError(t, c.applyEvent(events[4]))
+	assertGeneration(5)
+
+	// The next event in the sequence is applied as usual.
+	appendTask(t, backend, key, "task-c")
+	events = mustGet(t, backend, key)
+	assert.NoError(t, c.applyEvent(events[5]))
+	assertGeneration(6)
+}
+
+func TestSubscribedCache_ReplacedStream(t *testing.T) {
+	backend := mem.NewBackend()
+	projector := projectors.NewWorkflowInvocation()
+	key := fes.Aggregate{Type: types.TypeInvocation, Id: "wi-1"}
+	appendInvocationEvents(t, backend, key, 3)
+	c := NewSubscribedCache(NewLoadingCache(NewLRUCache(10), backend, projector), projector,
+		pubsub.NewPublisher().Subscribe())
+	defer c.Close()
+	entity, err := c.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, entity.(*types.WorkflowInvocation).Generation())
+
+	// The replacement restarts the sequence at 1, which would otherwise be skipped as a duplicate.
+	event, err := fes.NewEvent(key, &events.InvocationCreated{
+		Spec: types.NewWorkflowInvocationSpec("wf-2", time.Now().Add(time.Hour)),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Replace(key, event))
+	assert.NoError(t, c.applyEvent(event))
+
+	entity, err = c.GetAggregate(key)
+	assert.NoError(t, err)
+	wi := entity.(*types.WorkflowInvocation)
+	assert.EqualValues(t, 1, wi.Generation())
+	assert.Equal(t, "wf-2", wi.GetSpec().GetWorkflowId())
+	assert.Empty(t, wi.GetStatus().GetDynamicTasks())
+}
+
+func appendTask(t *testing.T, backend fes.Backend, key fes.Aggregate, taskID string) {
+	event, err := fes.NewEvent(key, &events.InvocationTaskAdded{
+		Task: types.NewTask(taskID, "noop"),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Append(event))
+}
@@ -0,0 +1,20 @@
+package cache
+
+import (
+	"github.com/fission/fission-workflows/pkg/fes"
+)
+
+// SnapshotPolicy decides when a LoadingCache should store a snapshot of an entity that it projected.
+type SnapshotPolicy interface {
+	// ShouldSnapshot is called after an entity has been projected from the event store, with the number of events
+	// that had to be replayed since the previous snapshot.
+	ShouldSnapshot(entity fes.Entity, eventsSinceSnapshot int) bool
+}
+
+// EveryNEvents is a SnapshotPolicy that stores a snapshot once N or more events have been replayed since the previous
+// snapshot. A value of 0 or less disables snapshots.
+type EveryNEvents int
+
+func (n EveryNEvents) ShouldSnapshot(entity fes.Entity, eventsSinceSnapshot int) bool {
+	return n > 0 && eventsSinceSnapshot >= int(n)
+}
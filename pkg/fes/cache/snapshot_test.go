@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingProjector keeps track of the number of events that have been projected.
+type countingProjector struct {
+	fes.Projector
+	count int
+}
+
+func (p *countingProjector) Project(entity fes.Entity, events ...*fes.Event) (fes.Entity, error) {
+	p.count += len(events)
+	return p.Projector.Project(entity, events...)
+}
+
+func appendInvocationEvents(t *testing.T, backend fes.Backend, key fes.Aggregate, tasks int) {
+	event, err := fes.NewEvent(key, &events.InvocationCreated{
+		Spec: types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Hour)),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Append(event))
+	for i := 0; i < tasks; i++ {
+		event, err := fes.NewEvent(key, &events.InvocationTaskAdded{
+			Task: types.NewTask(fmt.Sprintf("task-%d", i), "noop"),
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, backend.Append(event))
+	}
+}
+
+func TestLoadingCache_Snapshots(t *testing.T) {
+	backend := mem.NewBackend()
+	projector := &countingProjector{Projector: projectors.NewWorkflowInvocation()}
+	key := fes.Aggregate{Type: types.TypeInvocation, Id: "wi-1"}
+	appendInvocationEvents(t, backend, key, 9)
+
+	// Initial load replays all events and stores a snapshot.
+	cache := NewLoadingCacheWithSnapshots(NewLRUCache(10), backend, projector, backend, EveryNEvents(5))
+	_, err := cache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, projector.count)
+	snapshot, err := backend.GetSnapshot(key)
+	assert.NoError(t, err)
+	assert.NotNil(t, snapshot)
+	assert.EqualValues(t, 10, snapshot.Offset)
+
+	// Subsequent loads only replay the events after the snapshot.
+	appendInvocationEvents(t, backend, key, 0)
+	projector.count = 0
+	cache.Invalidate(key)
+	entity, err := cache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, projector.count)
+
+	// The result should be equal to a full replay of the events.
+	fullReplay := NewLoadingCache(NewLRUCache(10), backend, projectors.NewWorkflowInvocation())
+	expected, err := fullReplay.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(expected.(proto.Message), entity.(proto.Message)))
+}
+
+func TestLoadingCache_SnapshotAheadOfEvents(t *testing.T) {
+	backend := mem.NewBackend()
+	projector := &countingProjector{Projector: projectors.NewWorkflowInvocation()}
+	key := fes.Aggregate{Type: types.TypeInvocation, Id: "wi-1"}
+	appendInvocationEvents(t, backend, key, 2)
+
+	entity, err := projector.Project(nil, mustGet(t, backend, key)...)
+	assert.NoError(t, err)
+	snapshot, err := fes.NewSnapshot(entity, 100)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.SaveSnapshot(snapshot))
+
+	// A snapshot with an offset beyond the event stream should be ignored.
+	projector.count = 0
+	cache := NewLoadingCacheWithSnapshots(NewLRUCache(10), backend, projector, backend, EveryNEvents(0))
+	_, err = cache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, projector.count)
+}
+
+func TestEveryNEvents(t *testing.T) {
+	assert.False(t, EveryNEvents(0).ShouldSnapshot(nil, 100))
+	assert.False(t, EveryNEvents(10).ShouldSnapshot(nil, 9))
+	assert.True(t, EveryNEvents(10).ShouldSnapshot(nil, 10))
+}
+
+func mustGet(t *testing.T, backend fes.Backend, key fes.Aggregate) []*fes.Event {
+	events, err := backend.Get(key)
+	assert.NoError(t, err)
+	return events
+}
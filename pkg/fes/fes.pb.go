@@ -11,6 +11,7 @@ It has these top-level messages:
 	Aggregate
 	Event
 	EventHints
+	Snapshot
 */
 package fes
 
@@ -64,6 +65,15 @@ type Event struct {
 	Parent    *Aggregate                 `protobuf:"bytes,6,opt,name=parent" json:"parent,omitempty"`
 	Hints     *EventHints                `protobuf:"bytes,7,opt,name=hints" json:"hints,omitempty"`
 	Metadata  map[string]string          `protobuf:"bytes,8,rep,name=metadata" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// ExpectedGeneration is an optional precondition for appending the event. If set, the backend only appends the
+	// event if the number of events in the stream of the aggregate (or parent, if set) is equal to it. Otherwise, the
+	// backend rejects the event with an ErrConcurrentModification. If 0, the event is appended unconditionally. If
+	// negative (ExpectNoEvents), the event is only appended if the stream does not exist yet. Only backends that
+	// implement ConditionalAppender guarantee the precondition.
+	ExpectedGeneration int64 `protobuf:"varint,9,opt,name=expectedGeneration" json:"expectedGeneration,omitempty"`
+	// Sequence is the position of the event in the event stream of the aggregate (or parent, if set), starting at 1.
+	// It is assigned by the backend when the event is appended. If 0, the sequence of the event is unknown.
+	Sequence int64 `protobuf:"varint,10,opt,name=sequence" json:"sequence,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -127,9 +137,27 @@ func (m *Event) GetMetadata() map[string]string {
 	return nil
 }
 
+func (m *Event) GetExpectedGeneration() int64 {
+	if m != nil {
+		return m.ExpectedGeneration
+	}
+	return 0
+}
+
+func (m *Event) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
 // EventHints is a collection of optional metadata that help components in the event store to improve performance.
 type EventHints struct {
 	Completed bool `protobuf:"varint,1,opt,name=completed" json:"completed,omitempty"`
+	// Replaces indicates that the event is the first event of a stream that replaced the previous stream of the
+	// aggregate (see fes.Replacer). Its sequence restarts at 1, so caches should rebuild the entity rather than treat
+	// the event as a duplicate.
+	Replaces bool `protobuf:"varint,2,opt,name=replaces" json:"replaces,omitempty"`
 }
 
 func (m *EventHints) Reset()                    { *m = EventHints{} }
@@ -144,37 +172,95 @@ func (m *EventHints) GetCompleted() bool {
 	return false
 }
 
+func (m *EventHints) GetReplaces() bool {
+	if m != nil {
+		return m.Replaces
+	}
+	return false
+}
+
+// Snapshot is a projection of an aggregate at a specific point in its event stream.
+//
+// It allows the projection of an entity to start from the snapshot, only applying the events after the snapshot.
+type Snapshot struct {
+	Aggregate *Aggregate `protobuf:"bytes,1,opt,name=aggregate" json:"aggregate,omitempty"`
+	// Data is the projected entity.
+	Data *google_protobuf1.Any `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	// Offset is the number of events of the aggregate's event stream that have been applied to the entity.
+	Offset    int64                      `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Snapshot) GetAggregate() *Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return nil
+}
+
+func (m *Snapshot) GetData() *google_protobuf1.Any {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Snapshot) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *Snapshot) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Aggregate)(nil), "fission.workflows.eventstore.Aggregate")
 	proto.RegisterType((*Event)(nil), "fission.workflows.eventstore.Event")
 	proto.RegisterType((*EventHints)(nil), "fission.workflows.eventstore.EventHints")
+	proto.RegisterType((*Snapshot)(nil), "fission.workflows.eventstore.Snapshot")
 }
 
 func init() { proto.RegisterFile("pkg/fes/fes.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 353 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x41, 0x4b, 0xfb, 0x30,
-	0x18, 0xc6, 0x69, 0xbb, 0xee, 0xbf, 0xbe, 0xe3, 0x2f, 0x1a, 0x76, 0xa8, 0x65, 0xe0, 0xd8, 0xc5,
-	0xe2, 0x21, 0xc5, 0x79, 0x19, 0x0a, 0xca, 0x84, 0x81, 0x97, 0x5d, 0x8a, 0x27, 0x6f, 0x99, 0x7b,
-	0x5b, 0xcb, 0xda, 0xa4, 0x34, 0xd9, 0x46, 0x3f, 0xaf, 0x5f, 0x44, 0x9a, 0xae, 0x2b, 0x2a, 0x8c,
-	0x79, 0x28, 0xa4, 0xc9, 0xf3, 0x7b, 0xf2, 0x3c, 0x79, 0xe1, 0x22, 0x5f, 0xc7, 0x41, 0x84, 0xb2,
-	0xfa, 0x68, 0x5e, 0x08, 0x25, 0xc8, 0x30, 0x4a, 0xa4, 0x4c, 0x04, 0xa7, 0x3b, 0x51, 0xac, 0xa3,
-	0x54, 0xec, 0x24, 0xc5, 0x2d, 0x72, 0x25, 0x95, 0x28, 0xd0, 0xbb, 0x8a, 0x85, 0x88, 0x53, 0x0c,
-	0xb4, 0x76, 0xb9, 0x89, 0x02, 0x95, 0x64, 0x28, 0x15, 0xcb, 0xf2, 0x1a, 0xf7, 0x2e, 0x7f, 0x0a,
-	0x18, 0x2f, 0xeb, 0xa3, 0x71, 0x00, 0xce, 0x2c, 0x8e, 0x0b, 0x8c, 0x99, 0x42, 0x72, 0x06, 0x66,
-	0xb2, 0x72, 0x8d, 0x91, 0xe1, 0x3b, 0xa1, 0x99, 0xac, 0x08, 0x81, 0x8e, 0x2a, 0x73, 0x74, 0x4d,
-	0xbd, 0xa3, 0xd7, 0xe3, 0x4f, 0x0b, 0xec, 0x79, 0x75, 0xf7, 0x29, 0x6a, 0x32, 0x07, 0x87, 0x35,
-	0xf6, 0xae, 0x35, 0x32, 0xfc, 0xfe, 0xe4, 0x9a, 0x1e, 0x2b, 0x43, 0x0f, 0x69, 0xc2, 0x96, 0x24,
-	0x53, 0x70, 0x0e, 0x9d, 0xdc, 0x8e, 0xb6, 0xf1, 0x68, 0x5d, 0x8a, 0x36, 0xa5, 0xe8, 0x6b, 0xa3,
-	0x08, 0x5b, 0x31, 0xf1, 0xa1, 0xb3, 0x62, 0x8a, 0xb9, 0xb6, 0x86, 0x06, 0xbf, 0xa0, 0x19, 0x2f,
-	0x43, 0xad, 0x20, 0x4f, 0xd0, 0xcd, 0x59, 0x81, 0x5c, 0xb9, 0xdd, 0xbf, 0xe5, 0xdc, 0x63, 0xe4,
-	0x11, 0xec, 0x8f, 0x84, 0x2b, 0xe9, 0xfe, 0xd3, 0xbc, 0x7f, 0x9c, 0xd7, 0x6f, 0xf8, 0x52, 0xe9,
-	0xc3, 0x1a, 0x23, 0x0b, 0xe8, 0x65, 0xa8, 0x98, 0x8e, 0xdb, 0x1b, 0x59, 0x7e, 0x7f, 0x72, 0x7b,
-	0x82, 0x05, 0x5d, 0xec, 0x99, 0x39, 0x57, 0x45, 0x19, 0x1e, 0x2c, 0xbc, 0x07, 0xf8, 0xff, 0xed,
-	0x88, 0x9c, 0x83, 0xb5, 0xc6, 0x72, 0x3f, 0xb0, 0x6a, 0x49, 0x06, 0x60, 0x6f, 0x59, 0xba, 0x69,
-	0x46, 0x56, 0xff, 0xdc, 0x9b, 0x53, 0x63, 0x7c, 0x03, 0xd0, 0x06, 0x24, 0x43, 0x70, 0xde, 0x45,
-	0x96, 0xa7, 0xa8, 0xb0, 0x1e, 0x78, 0x2f, 0x6c, 0x37, 0x9e, 0xed, 0x37, 0x2b, 0x42, 0xb9, 0xec,
-	0xea, 0x37, 0xbd, 0xfb, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xee, 0x2c, 0xf3, 0xbf, 0x02, 0x00,
-	0x00,
+	// 439 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xc1, 0x6e, 0xd4, 0x30,
+	0x10, 0x86, 0x95, 0x64, 0xb3, 0x24, 0x53, 0x81, 0xc0, 0xaa, 0x90, 0x89, 0x2a, 0xb1, 0xda, 0x0b,
+	0x39, 0x79, 0x45, 0xb9, 0x54, 0x20, 0x81, 0x8a, 0x54, 0xe0, 0xd2, 0x8b, 0xe1, 0xc4, 0xcd, 0xdd,
+	0x9d, 0xa4, 0xd1, 0x66, 0x6d, 0x13, 0xcf, 0xb6, 0xe4, 0xa9, 0x78, 0x12, 0xde, 0x09, 0xc5, 0xd9,
+	0x64, 0x55, 0x40, 0xa5, 0xf4, 0x10, 0xc9, 0x63, 0xff, 0xdf, 0x78, 0xfe, 0xf8, 0x87, 0x27, 0x76,
+	0x5d, 0x2e, 0x0a, 0x74, 0xdd, 0x27, 0x6c, 0x63, 0xc8, 0xb0, 0xa3, 0xa2, 0x72, 0xae, 0x32, 0x5a,
+	0x5c, 0x9b, 0x66, 0x5d, 0xd4, 0xe6, 0xda, 0x09, 0xbc, 0x42, 0x4d, 0x8e, 0x4c, 0x83, 0xd9, 0xf3,
+	0xd2, 0x98, 0xb2, 0xc6, 0x85, 0xd7, 0x5e, 0x6c, 0x8b, 0x05, 0x55, 0x1b, 0x74, 0xa4, 0x36, 0xb6,
+	0xc7, 0xb3, 0x67, 0xbf, 0x0b, 0x94, 0x6e, 0xfb, 0xa3, 0xf9, 0x02, 0xd2, 0xd3, 0xb2, 0x6c, 0xb0,
+	0x54, 0x84, 0xec, 0x11, 0x84, 0xd5, 0x8a, 0x07, 0xb3, 0x20, 0x4f, 0x65, 0x58, 0xad, 0x18, 0x83,
+	0x09, 0xb5, 0x16, 0x79, 0xe8, 0x77, 0xfc, 0x7a, 0xfe, 0x63, 0x02, 0xf1, 0x59, 0x77, 0xf7, 0x5d,
+	0xd4, 0xec, 0x0c, 0x52, 0x35, 0xb4, 0xe7, 0xd1, 0x2c, 0xc8, 0x0f, 0x8e, 0x5f, 0x88, 0xdb, 0xcc,
+	0x88, 0x71, 0x1a, 0xb9, 0x27, 0xd9, 0x09, 0xa4, 0xa3, 0x27, 0x3e, 0xf1, 0x6d, 0x32, 0xd1, 0x9b,
+	0x12, 0x83, 0x29, 0xf1, 0x65, 0x50, 0xc8, 0xbd, 0x98, 0xe5, 0x30, 0x59, 0x29, 0x52, 0x3c, 0xf6,
+	0xd0, 0xe1, 0x1f, 0xd0, 0xa9, 0x6e, 0xa5, 0x57, 0xb0, 0x77, 0x30, 0xb5, 0xaa, 0x41, 0x4d, 0x7c,
+	0xfa, 0x7f, 0x73, 0xee, 0x30, 0xf6, 0x16, 0xe2, 0xcb, 0x4a, 0x93, 0xe3, 0x0f, 0x3c, 0x9f, 0xdf,
+	0xce, 0xfb, 0x7f, 0xf8, 0xa9, 0xd3, 0xcb, 0x1e, 0x63, 0xe7, 0x90, 0x6c, 0x90, 0x94, 0x1f, 0x37,
+	0x99, 0x45, 0xf9, 0xc1, 0xf1, 0xcb, 0x3b, 0xb4, 0x10, 0xe7, 0x3b, 0xe6, 0x4c, 0x53, 0xd3, 0xca,
+	0xb1, 0x05, 0x13, 0xc0, 0xf0, 0xbb, 0xc5, 0x25, 0xe1, 0xea, 0x23, 0x6a, 0x6c, 0x14, 0x55, 0x46,
+	0xf3, 0x74, 0x16, 0xe4, 0x91, 0xfc, 0xcb, 0x09, 0xcb, 0x20, 0x71, 0xf8, 0x6d, 0x8b, 0x7a, 0x89,
+	0x1c, 0xbc, 0x6a, 0xac, 0xb3, 0x37, 0xf0, 0xf0, 0xc6, 0x35, 0xec, 0x31, 0x44, 0x6b, 0x6c, 0x77,
+	0x8f, 0xdf, 0x2d, 0xd9, 0x21, 0xc4, 0x57, 0xaa, 0xde, 0x0e, 0xcf, 0xdf, 0x17, 0xaf, 0xc3, 0x93,
+	0x60, 0xfe, 0x01, 0x60, 0x6f, 0x96, 0x1d, 0x41, 0xba, 0x34, 0x1b, 0x5b, 0x23, 0x61, 0x1f, 0x9e,
+	0x44, 0xee, 0x37, 0xba, 0x21, 0x1a, 0xb4, 0xb5, 0x5a, 0xa2, 0xf3, 0x8d, 0x12, 0x39, 0xd6, 0xf3,
+	0x9f, 0x01, 0x24, 0x9f, 0xb5, 0xb2, 0xee, 0xd2, 0xd0, 0xcd, 0x60, 0x05, 0xf7, 0x0e, 0xd6, 0x10,
+	0x8f, 0xf0, 0x9f, 0xf1, 0x78, 0x0a, 0x53, 0x53, 0x14, 0x0e, 0xc9, 0xc7, 0x38, 0x92, 0xbb, 0xea,
+	0xfe, 0xd1, 0x7c, 0x1f, 0x7f, 0x8d, 0x0a, 0x74, 0x17, 0x53, 0xaf, 0x7a, 0xf5, 0x6b, 0x00, 0x13,
+	0x48, 0x20, 0x1d, 0xf7, 0x03, 0x00, 0x00,
 }
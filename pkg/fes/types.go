@@ -12,6 +12,15 @@ type Entity interface {
 	ID() string
 }
 
+// SequencedEntity is an entity that keeps track of the number of events that have been applied to it.
+//
+// Since events are applied in order, the generation of the entity is equal to the sequence of the last applied event.
+// This allows caches to verify that an incoming event is the next event in the event stream of the entity.
+type SequencedEntity interface {
+	Entity
+	Generation() int64
+}
+
 type CustomType interface {
 	Type() string
 }
@@ -35,6 +44,45 @@ type Backend interface {
 	List(matcher AggregateMatcher) ([]Aggregate, error)
 }
 
+// SnapshotStore is an optional extension of a Backend, which stores snapshots of aggregates alongside their events.
+type SnapshotStore interface {
+	// SaveSnapshot stores the snapshot, replacing the existing snapshot of the aggregate if there is one.
+	SaveSnapshot(snapshot *Snapshot) error
+
+	// GetSnapshot returns the latest snapshot of the aggregate, or nil if there is no snapshot of the aggregate.
+	GetSnapshot(aggregate Aggregate) (*Snapshot, error)
+}
+
+// ConditionalAppender is an optional extension of a Backend, implemented by backends that check the expected
+// generation of an event (see Event.ExpectedGeneration) atomically with appending it, for all clients of the backend.
+// Backends that do not implement it may append conditional events unconditionally.
+type ConditionalAppender interface {
+	// AppendsConditionally reports whether the backend rejects events of which the expected generation does not match.
+	AppendsConditionally() bool
+}
+
+// AppendsConditionally checks whether the backend guards conditional appends against concurrent modifications.
+func AppendsConditionally(backend Backend) bool {
+	appender, ok := backend.(ConditionalAppender)
+	return ok && appender.AppendsConditionally()
+}
+
+// Purger is an optional extension of a Backend, which allows aggregates to be removed from the backend.
+type Purger interface {
+	// Purge removes the event stream (and snapshot) of the aggregate from the backend, returning the number of events
+	// that were removed. Purging an aggregate that does not exist is not an error.
+	Purge(aggregate Aggregate) (int, error)
+}
+
+// Replacer is an optional extension of a Backend, which allows the event stream of an aggregate to be replaced, for
+// example to compact it.
+type Replacer interface {
+	// Replace atomically replaces the event stream (and removes the snapshot) of the aggregate with the events. The
+	// sequences of the events restart at 1, and the first event is marked with the Replaces hint. Other clients never
+	// observe the aggregate as missing or partially replaced.
+	Replace(aggregate Aggregate, events ...*Event) error
+}
+
 type CacheReader interface {
 	//Get(entity Entity) error
 	List() []Aggregate
@@ -134,4 +182,6 @@ var (
 	ErrUnsupportedEntityEvent = EventStoreErr{S: "event not supported"}
 	ErrCorruptedEventPayload  = EventStoreErr{S: "failed to parse event payload"}
 	ErrEntityNotFound         = EventStoreErr{S: "entity not found"}
+	ErrInvalidSnapshot        = EventStoreErr{S: "invalid snapshot"}
+	ErrConcurrentModification = EventStoreErr{S: "concurrent modification"}
 )
@@ -59,6 +59,51 @@ func ParseEventData(event *Event) (proto.Message, error) {
 	return d.Message, nil
 }
 
+// NewSnapshot returns a snapshot of the entity, which is the projection of the first offset events of its aggregate.
+//
+// It returns an ErrInvalidSnapshot if the entity is invalid or cannot be marshaled to bytes.
+func NewSnapshot(entity Entity, offset int) (*Snapshot, error) {
+	if err := ValidateEntity(entity); err != nil {
+		return nil, ErrInvalidSnapshot.WithEntity(entity).WithError(err)
+	}
+	msg, ok := entity.(proto.Message)
+	if !ok {
+		return nil, ErrInvalidSnapshot.WithEntity(entity).WithError(fmt.Errorf("entity %T is not a proto message",
+			entity))
+	}
+	data, err := ptypes.MarshalAny(msg)
+	if err != nil {
+		return nil, ErrInvalidSnapshot.WithEntity(entity).WithError(err)
+	}
+	aggregate := GetAggregate(entity)
+	return &Snapshot{
+		Aggregate: &aggregate,
+		Data:      data,
+		Offset:    int64(offset),
+		Timestamp: ptypes.TimestampNow(),
+	}, nil
+}
+
+// ParseSnapshotData parses the payload of the snapshot, returning the entity contained in the snapshot.
+//
+// In case it fails to parse the payload it returns an ErrInvalidSnapshot
+func ParseSnapshotData(snapshot *Snapshot) (Entity, error) {
+	if err := ValidateSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+	d := &ptypes.DynamicAny{}
+	err := ptypes.UnmarshalAny(snapshot.Data, d)
+	if err != nil {
+		return nil, ErrInvalidSnapshot.WithAggregate(snapshot.Aggregate).WithError(err)
+	}
+	entity, ok := d.Message.(Entity)
+	if !ok {
+		return nil, ErrInvalidSnapshot.WithAggregate(snapshot.Aggregate).WithError(
+			fmt.Errorf("snapshot data %T is not an entity", d.Message))
+	}
+	return entity, nil
+}
+
 func ExtractTracingFromEventMetadata(metadata map[string]string) (opentracing.SpanContext, error) {
 	if metadata == nil {
 		return nil, errors.New("event does not have metadata")
@@ -1,6 +1,9 @@
 package fes
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 func ValidateAggregate(aggregate *Aggregate) error {
 	if aggregate == nil {
@@ -44,6 +47,60 @@ func ValidateEvent(event *Event) error {
 	return nil
 }
 
+// ExpectNoEvents is the expected generation of an event that may only be appended if the event stream does not
+// exist yet, such as the event that creates an aggregate.
+const ExpectNoEvents int64 = -1
+
+// ValidateExpectedGeneration checks the optional precondition of the event against the current generation (the
+// number of events) of the event stream that the event will be appended to.
+//
+// It returns an ErrConcurrentModification if the event expects another generation.
+func ValidateExpectedGeneration(event *Event, generation int64) error {
+	expected := event.GetExpectedGeneration()
+	if expected == 0 {
+		return nil
+	}
+	if expected < 0 {
+		expected = 0
+	}
+	if expected == generation {
+		return nil
+	}
+	return ErrConcurrentModification.WithEvent(event).WithError(fmt.Errorf("expected generation %d, but was %d",
+		expected, generation))
+}
+
+// ValidateReplacement validates the events that replace the event stream of the aggregate (see Replacer). The stream
+// needs to contain at least one event, and all events need to belong to the stream of the aggregate.
+//
+// It marks the first event with the Replaces hint, so that caches are able to detect the replacement.
+func ValidateReplacement(aggregate Aggregate, events []*Event) error {
+	if err := ValidateAggregate(&aggregate); err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return ErrInvalidEvent.WithAggregate(&aggregate).WithError(errors.New("replacement contains no events"))
+	}
+	for _, event := range events {
+		if err := ValidateEvent(event); err != nil {
+			return err
+		}
+		key := *event.Aggregate
+		if event.Parent != nil {
+			key = *event.Parent
+		}
+		if key != aggregate {
+			return ErrInvalidEvent.WithEvent(event).WithError(fmt.Errorf("event does not belong to the stream of %s",
+				aggregate.Format()))
+		}
+	}
+	if events[0].Hints == nil {
+		events[0].Hints = &EventHints{}
+	}
+	events[0].Hints.Replaces = true
+	return nil
+}
+
 func ValidateEntity(entity Entity) error {
 	if entity == nil {
 		return ErrInvalidEntity.WithEntity(entity).WithError(errors.New("entity is nil"))
@@ -55,3 +112,19 @@ func ValidateEntity(entity Entity) error {
 	}
 	return nil
 }
+
+func ValidateSnapshot(snapshot *Snapshot) error {
+	if snapshot == nil {
+		return ErrInvalidSnapshot.WithError(errors.New("snapshot is nil"))
+	}
+	if err := ValidateAggregate(snapshot.Aggregate); err != nil {
+		return ErrInvalidSnapshot.WithError(err)
+	}
+	if snapshot.Data == nil {
+		return ErrInvalidSnapshot.WithAggregate(snapshot.Aggregate).WithError(errors.New("snapshot has no data"))
+	}
+	if snapshot.Offset < 0 {
+		return ErrInvalidSnapshot.WithAggregate(snapshot.Aggregate).WithError(errors.New("snapshot has a negative offset"))
+	}
+	return nil
+}
@@ -172,7 +172,7 @@ func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.Invo
 		return &types.TaskInvocationStatus{
 			Status: types.TaskInvocationStatus_FAILED,
 			Error: &types.Error{
-				Message: fmt.Sprintf("fission function error: %v", msg),
+				Message: fmt.Sprintf("fission function error (%d): %v", resp.StatusCode, msg),
 			},
 		}, nil
 	}
@@ -26,6 +26,11 @@ import (
 var (
 	ErrInvalidRuntime = errors.New("invalid runtime")
 
+	// ErrTaskParked is returned by a runtime for a task that is waiting for an external event, such as a signal, without
+	// occupying the workflow engine in the meantime. The task has not finished; the workflow engine runs the task again
+	// in later evaluations of the invocation until it does.
+	ErrTaskParked = errors.New("task is parked")
+
 	FnActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "workflows",
 		Subsystem: "fnenv",
@@ -129,7 +129,7 @@ func (r *Runtime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOpt
 		return &types.TaskInvocationStatus{
 			Status: types.TaskInvocationStatus_FAILED,
 			Error: &types.Error{
-				Message: fmt.Sprintf("HTTP runtime request error: %v", msg),
+				Message: fmt.Sprintf("HTTP runtime request error (%d): %v", resp.StatusCode, msg),
 			},
 		}, nil
 	}
@@ -0,0 +1,165 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	Await             = "await"
+	AwaitInputSignal  = types.InputMain
+	AwaitInputTimeout = "timeout"
+)
+
+/*
+FunctionAwait waits for an external signal to be sent to the invocation, such as a manual approval or a webhook call.
+Signals are sent to an invocation using the Signal API (`fission-workflows invocation signal`). The payload of the signal
+becomes the output of the task.
+
+Signals are stored in the invocation. So, a signal that was sent before the task started waiting for it is received
+immediately, and a signal is not lost when the workflow engine restarts while the task is waiting for it. While the task
+is waiting, it is parked; it does not occupy the workflow engine, which checks for the signal whenever it evaluates the
+invocation.
+
+**Specification**
+
+**input**       | required | types             | description
+----------------|----------|-------------------|--------------------------------------------------------
+default         | yes      | string            | The name of the signal to wait for.
+timeout         | no       | string/number     | The maximum duration to wait for the signal. (default: deadline of the task)
+
+Note: the timeout is parsed in the same way as the input of the `sleep` function. It starts once the task is able to
+start, which is derived from the invocation: once its dependencies have finished, or once the invocation has started if
+the task has no dependencies. Hence, the timeout does not start over if the workflow engine restarts in the meantime.
+
+**output** (*) the payload of the signal.
+
+**Example**
+
+```yaml
+# ...
+approval:
+  run: await
+  inputs:
+    default: approved
+    timeout: 24h
+# ...
+```
+*/
+type FunctionAwait struct {
+	invocations *store.Invocations
+}
+
+func NewFunctionAwait(invocations *store.Invocations) *FunctionAwait {
+	return &FunctionAwait{
+		invocations: invocations,
+	}
+}
+
+// Invoke checks if the signal has been received by the invocation. Rather than blocking until the signal is received,
+// it parks the task, after which the task is run again on later evaluations of the invocation, such as the evaluation
+// triggered by the signal.
+func (fn *FunctionAwait) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+	signalInput, err := ensureInput(spec.GetInputs(), AwaitInputSignal, typedvalues.TypeString)
+	if err != nil {
+		return nil, err
+	}
+	name, err := typedvalues.UnwrapString(signalInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(name) == 0 {
+		return nil, errors.New("signal name should not be empty")
+	}
+	var timeout time.Duration
+	if timeoutInput, ok := spec.GetInputs()[AwaitInputTimeout]; ok {
+		timeout, err = parseDuration(timeoutInput)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	invocation, err := fn.invocations.GetInvocation(spec.GetInvocationId())
+	if err != nil {
+		return nil, err
+	}
+	if signal, ok := invocation.GetStatus().GetSignals()[name]; ok {
+		return signal.GetPayload(), nil
+	}
+	if invocation.GetStatus().Finished() {
+		return nil, fmt.Errorf("invocation finished before receiving signal '%s'", name)
+	}
+
+	// The task should not wait any longer than its deadline or the timeout, whichever comes first.
+	now := time.Now()
+	deadline, err := ptypes.Timestamp(spec.GetDeadline())
+	if (err == nil && now.After(deadline)) ||
+		(timeout > 0 && now.Sub(waitingSince(invocation, spec.GetTaskId())) >= timeout) {
+		return nil, fmt.Errorf("timed out waiting for signal '%s'", name)
+	}
+	return nil, fnenv.ErrTaskParked
+}
+
+// waitingSince determines the time at which the task started waiting from the persisted state of the invocation, so
+// that it is the same after a restart or on another replica. A task starts once its dependencies have finished (or
+// enough of them, if it awaits only some), or once the invocation started if it has no dependencies. A task that was
+// retried starts again at the retry.
+func waitingSince(invocation *types.WorkflowInvocation, taskID string) time.Time {
+	since, err := ptypes.Timestamp(invocation.GetStatus().GetStartedAt())
+	if err != nil {
+		since, _ = ptypes.Timestamp(invocation.GetMetadata().GetCreatedAt())
+	}
+	if taskRun, ok := invocation.TaskInvocation(taskID); ok {
+		if retriedAt, err := ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt()); err == nil && retriedAt.After(since) {
+			since = retriedAt
+		}
+	}
+	task, ok := invocation.Task(taskID)
+	if !ok {
+		return since
+	}
+
+	var finishes []time.Time
+	for depID := range task.GetSpec().GetRequires() {
+		if finishedAt, ok := dependencyFinishedAt(invocation, depID); ok {
+			finishes = append(finishes, finishedAt)
+		}
+	}
+	if len(finishes) == 0 {
+		return since
+	}
+	sort.Slice(finishes, func(i, j int) bool {
+		return finishes[i].Before(finishes[j])
+	})
+	startedAt := finishes[len(finishes)-1]
+	if await := int(task.GetSpec().GetAwait()); task.GetSpec().AwaitsQuorum() && await <= len(finishes) {
+		startedAt = finishes[await-1]
+	}
+	if startedAt.After(since) {
+		return startedAt
+	}
+	return since
+}
+
+// dependencyFinishedAt returns the time at which the dependency finished, or the time at which the dynamic task that
+// took its place finished.
+func dependencyFinishedAt(invocation *types.WorkflowInvocation, taskID string) (time.Time, bool) {
+	taskRun, ok := invocation.TaskInvocation(taskID)
+	if !ok || !taskRun.GetStatus().Finished() {
+		return time.Time{}, false
+	}
+	if child, ok := invocation.DynamicChild(taskID); ok {
+		return dependencyFinishedAt(invocation, child.ID())
+	}
+	finishedAt, err := ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt())
+	return finishedAt, err == nil
+}
@@ -0,0 +1,159 @@
+package builtin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fes/cache"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupAwait(t *testing.T) (*FunctionAwait, *api.Invocation, string) {
+	backend := mem.NewBackend()
+	projector := projectors.NewWorkflowInvocation()
+	c := cache.NewSubscribedCache(cache.NewLoadingCache(cache.NewLRUCache(10), backend, projector), projector,
+		backend.Subscribe())
+	invocationAPI := api.NewInvocationAPI(backend)
+	invocationID, err := invocationAPI.Invoke(types.NewWorkflowInvocationSpec("wf-1", time.Now().Add(time.Minute)))
+	assert.NoError(t, err)
+	return NewFunctionAwait(store.NewInvocationStore(c)), invocationAPI, invocationID
+}
+
+// awaitSpec creates the spec of a task run of an await task for the signal.
+func awaitSpec(invocationID string, signal string, inputs map[string]*typedvalues.TypedValue) *types.TaskInvocationSpec {
+	if inputs == nil {
+		inputs = map[string]*typedvalues.TypedValue{}
+	}
+	inputs[AwaitInputSignal] = typedvalues.MustWrap(signal)
+	return &types.TaskInvocationSpec{
+		InvocationId: invocationID,
+		TaskId:       "approval",
+		Inputs:       inputs,
+	}
+}
+
+// invokeUntilUnparked invokes the function until it no longer parks the task, giving the invocation store some slack
+// to pick up the events of the invocation.
+func invokeUntilUnparked(fn *FunctionAwait, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		output, err := fn.Invoke(context.Background(), spec)
+		if err != fnenv.ErrTaskParked || time.Now().After(deadline) {
+			return output, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFunctionAwait_Signal(t *testing.T) {
+	fn, invocationAPI, invocationID := setupAwait(t)
+
+	// Until the signal has been received, the task is parked.
+	_, err := fn.Invoke(context.Background(), awaitSpec(invocationID, "approved", nil))
+	assert.Equal(t, fnenv.ErrTaskParked, err)
+
+	err = invocationAPI.Signal(invocationID, "approved", typedvalues.MustWrap("yes"))
+	assert.NoError(t, err)
+	output, err := invokeUntilUnparked(fn, awaitSpec(invocationID, "approved", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", typedvalues.MustUnwrap(output))
+
+	// A signal that has already been received is returned immediately.
+	output, err = fn.Invoke(context.Background(), awaitSpec(invocationID, "approved", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "yes", typedvalues.MustUnwrap(output))
+}
+
+func TestFunctionAwait_Timeout(t *testing.T) {
+	fn, _, invocationID := setupAwait(t)
+	spec := awaitSpec(invocationID, "approved", map[string]*typedvalues.TypedValue{
+		AwaitInputTimeout: typedvalues.MustWrap("100ms"),
+	})
+
+	// The timeout starts once the task is able to start, which for a task without dependencies is at the start of the
+	// invocation.
+	_, err := fn.Invoke(context.Background(), spec)
+	assert.Equal(t, fnenv.ErrTaskParked, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// The timeout does not start over after a restart, since it is derived from the invocation.
+	restarted := NewFunctionAwait(fn.invocations)
+	_, err = restarted.Invoke(context.Background(), spec)
+	assert.Error(t, err)
+	assert.NotEqual(t, fnenv.ErrTaskParked, err)
+}
+
+func TestWaitingSince(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	at := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(start.Add(d))
+		return ts
+	}
+	finished := func(d time.Duration) *types.TaskInvocation {
+		return &types.TaskInvocation{
+			Status: &types.TaskInvocationStatus{
+				Status:    types.TaskInvocationStatus_SUCCEEDED,
+				UpdatedAt: at(d),
+			},
+		}
+	}
+	approval := types.NewTask("approval", Await)
+	approval.Spec.Require("a").Require("b")
+	wi := types.NewWorkflowInvocation("wf-1", "wi-1", start.Add(time.Hour))
+	wi.Metadata.CreatedAt = at(0)
+	wi.Spec.Workflow = &types.Workflow{
+		Metadata: types.NewObjectMetadata("wf-1"),
+		Spec:     &types.WorkflowSpec{},
+		Status: &types.WorkflowStatus{
+			Tasks: map[string]*types.Task{
+				"a":        types.NewTask("a", "noop"),
+				"b":        types.NewTask("b", "noop"),
+				"approval": approval,
+			},
+		},
+	}
+	wi.Status.StartedAt = at(time.Minute)
+
+	// Without finished dependencies, the task waits since the start of the invocation.
+	assert.True(t, start.Add(time.Minute).Equal(waitingSince(wi, "approval")))
+
+	// The task waits since the last of its dependencies has finished.
+	wi.Status.Tasks = map[string]*types.TaskInvocation{
+		"a": finished(2 * time.Minute),
+		"b": finished(3 * time.Minute),
+	}
+	assert.True(t, start.Add(3*time.Minute).Equal(waitingSince(wi, "approval")))
+
+	// A task that awaits some of its dependencies waits since enough of them have finished.
+	approval.Spec.Await = 1
+	assert.True(t, start.Add(2*time.Minute).Equal(waitingSince(wi, "approval")))
+
+	// A retried task waits since the retry.
+	wi.Status.Tasks["approval"] = &types.TaskInvocation{
+		Status: &types.TaskInvocationStatus{
+			Status:    types.TaskInvocationStatus_IN_PROGRESS,
+			UpdatedAt: at(4 * time.Minute),
+		},
+	}
+	assert.True(t, start.Add(4*time.Minute).Equal(waitingSince(wi, "approval")))
+}
+
+func TestFunctionAwait_InvocationFinished(t *testing.T) {
+	fn, invocationAPI, invocationID := setupAwait(t)
+	err := invocationAPI.Cancel(invocationID)
+	assert.NoError(t, err)
+
+	_, err = invokeUntilUnparked(fn, awaitSpec(invocationID, "approved", nil))
+	assert.Error(t, err)
+	assert.NotEqual(t, fnenv.ErrTaskParked, err)
+}
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -53,7 +54,7 @@ func ensureInput(inputs map[string]*typedvalues.TypedValue, key string, validTyp
 }
 
 func internalFunctionTest(t *testing.T, fn native.InternalFunction, input *types.TaskInvocationSpec, expected interface{}) {
-	output, err := fn.Invoke(input)
+	output, err := fn.Invoke(context.Background(), input)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1,6 +1,8 @@
 package builtin
 
 import (
+	"context"
+
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/sirupsen/logrus"
@@ -53,7 +55,7 @@ foo:
 // TODO avoid adding function-injected fields to compose
 type FunctionCompose struct{}
 
-func (fn *FunctionCompose) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionCompose) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 
 	var output *typedvalues.TypedValue
 	switch len(spec.GetInputs()) {
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -41,7 +42,7 @@ A runnable example of this function can be found in the [failwhale](../examples/
 */
 type FunctionFail struct{}
 
-func (fn *FunctionFail) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionFail) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	var output *typedvalues.TypedValue
 	switch len(spec.GetInputs()) {
 	case 0:
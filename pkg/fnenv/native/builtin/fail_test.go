@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -10,7 +11,7 @@ import (
 
 func TestFunctionFail_InvokeEmpty(t *testing.T) {
 	fn := &FunctionFail{}
-	out, err := fn.Invoke(&types.TaskInvocationSpec{})
+	out, err := fn.Invoke(context.Background(), &types.TaskInvocationSpec{})
 	assert.Nil(t, out)
 	assert.EqualError(t, err, typedvalues.MustUnwrap(defaultErrMsg).(string))
 
@@ -19,7 +20,7 @@ func TestFunctionFail_InvokeEmpty(t *testing.T) {
 func TestFunctionFail_InvokeString(t *testing.T) {
 	fn := &FunctionFail{}
 	errMsg := "custom error message"
-	out, err := fn.Invoke(&types.TaskInvocationSpec{
+	out, err := fn.Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: types.Input(errMsg),
 	})
 	assert.Nil(t, out)
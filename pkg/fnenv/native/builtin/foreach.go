@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -54,7 +55,7 @@ A complete example of this function can be found in the [foreachwhale](../exampl
 */
 type FunctionForeach struct{}
 
-func (fn *FunctionForeach) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionForeach) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	// Verify and parse foreach
 	headerTv, err := ensureInput(spec.GetInputs(), ForeachInputForeach)
 	if err != nil {
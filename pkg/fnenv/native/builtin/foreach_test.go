@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -11,7 +12,7 @@ import (
 
 func TestFunctionForeach_Invoke(t *testing.T) {
 	foreachElements := []interface{}{1, 2, 3, 4, "foo"}
-	out, err := (&FunctionForeach{}).Invoke(&types.TaskInvocationSpec{
+	out, err := (&FunctionForeach{}).Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			ForeachInputForeach: typedvalues.MustWrap(foreachElements),
 			ForeachInputDo: typedvalues.MustWrap(&types.TaskSpec{
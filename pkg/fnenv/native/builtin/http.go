@@ -1,10 +1,12 @@
 package builtin
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/fnenv/http"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
@@ -63,7 +65,7 @@ func NewFunctionHTTP() *FunctionHTTP {
 	}
 }
 
-func (fn *FunctionHTTP) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionHTTP) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	// Get the actual url
 	targetUrl, err := fn.determineTargetURL(spec.Inputs)
 	if err != nil {
@@ -76,7 +78,7 @@ func (fn *FunctionHTTP) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.Typ
 	clonedSpec := proto.Clone(spec).(*types.TaskInvocationSpec)
 	clonedSpec.FnRef = &fnref
 
-	result, err := fn.runtime.Invoke(clonedSpec)
+	result, err := fn.runtime.Invoke(clonedSpec, fnenv.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
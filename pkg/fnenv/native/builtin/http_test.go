@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -38,7 +39,7 @@ func TestFunctionHttp_Invoke(t *testing.T) {
 	fn := NewFunctionHTTP()
 	body := "body"
 	deadline, _ := ptypes.TimestampProto(time.Now().Add(10 * time.Second))
-	out, err := fn.Invoke(&types.TaskInvocationSpec{
+	out, err := fn.Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			types.InputMethod: typedvalues.MustWrap(http.MethodPost),
 			HttpInputUrl:      typedvalues.MustWrap(ts.URL),
@@ -62,7 +63,7 @@ func TestFunctionHttp_Invoke_Invalid(t *testing.T) {
 
 	fn := NewFunctionHTTP()
 	body := "body"
-	out, err := fn.Invoke(&types.TaskInvocationSpec{
+	out, err := fn.Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			types.InputMethod: typedvalues.MustWrap(http.MethodDelete),
 			HttpInputUrl:      typedvalues.MustWrap(ts.URL),
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -54,7 +55,7 @@ A complete example of this function can be found in the [maybewhale](../examples
 */
 type FunctionIf struct{}
 
-func (fn *FunctionIf) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionIf) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 
 	// Verify and get condition
 	expr, err := ensureInput(spec.GetInputs(), IfInputCondition)
@@ -1,6 +1,8 @@
 package builtin
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -62,7 +64,7 @@ func NewFunctionJavascript() *FunctionJavascript {
 	}
 }
 
-func (fn *FunctionJavascript) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionJavascript) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	exprVal, err := ensureInput(spec.Inputs, JavascriptInputExpr, typedvalues.TypeString)
 	argsVal, _ := spec.Inputs[JavascriptInputArgs]
 	if err != nil {
@@ -78,7 +80,7 @@ func (fn *FunctionJavascript) Invoke(spec *types.TaskInvocationSpec) (*typedvalu
 	}
 	logrus.WithField("taskID", spec.TaskId).
 		Infof("[internal://%s] args: %v | expr: %v", Javascript, args, expr)
-	result, err := fn.exec(expr, args)
+	result, err := fn.exec(ctx, expr, args)
 	if err != nil {
 		return nil, err
 	}
@@ -88,12 +90,16 @@ func (fn *FunctionJavascript) Invoke(spec *types.TaskInvocationSpec) (*typedvalu
 	return typedvalues.Wrap(result)
 }
 
-func (fn *FunctionJavascript) exec(expr string, args interface{}) (interface{}, error) {
+// exec runs the expression until it completes, the execution timeout is exceeded, or the context is done.
+func (fn *FunctionJavascript) exec(ctx context.Context, expr string, args interface{}) (result interface{}, err error) {
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
 	defer func() {
 		if caught := recover(); caught != nil {
 			if errTimeout != caught {
 				panic(caught)
 			}
+			err = fmt.Errorf("%s: %v", errTimeout, ctx.Err())
 		}
 	}()
 
@@ -113,8 +119,9 @@ func (fn *FunctionJavascript) exec(expr string, args interface{}) (interface{},
 		}
 	}
 
+	scoped.Interrupt = make(chan func(), 1)
 	go func() {
-		<-time.After(execTimeout)
+		<-ctx.Done()
 		scoped.Interrupt <- func() {
 			panic(errTimeout)
 		}
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -20,7 +21,7 @@ func TestFunctionJavascript_InvokeMap(t *testing.T) {
 	}
 
 	js := NewFunctionJavascript()
-	tv, err := js.Invoke(spec)
+	tv, err := js.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	assert.Equal(t, 10, int(typedvalues.MustUnwrap(tv).(float64)))
 }
@@ -34,7 +35,21 @@ func TestFunctionJavascript_Invoke(t *testing.T) {
 	}
 
 	js := NewFunctionJavascript()
-	tv, err := js.Invoke(spec)
+	tv, err := js.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	assert.Equal(t, 20, int(typedvalues.MustUnwrap(tv).(float64)))
 }
+
+func TestFunctionJavascript_InvokeCanceled(t *testing.T) {
+	spec := &types.TaskInvocationSpec{
+		Inputs: map[string]*typedvalues.TypedValue{
+			JavascriptInputExpr: typedvalues.MustWrap("while (true) {}"),
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	js := NewFunctionJavascript()
+	_, err := js.Invoke(ctx, spec)
+	assert.EqualError(t, err, errTimeout+": "+context.Canceled.Error())
+}
@@ -1,6 +1,8 @@
 package builtin
 
 import (
+	"context"
+
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/sirupsen/logrus"
@@ -37,7 +39,7 @@ A complete example of this function can be found in the [fortunewhale](../exampl
 */
 type FunctionNoop struct{}
 
-func (fn *FunctionNoop) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionNoop) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 
 	var output *typedvalues.TypedValue
 	switch len(spec.GetInputs()) {
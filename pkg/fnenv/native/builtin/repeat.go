@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -51,7 +52,7 @@ A complete example of this function can be found in the [repeatwhale](../example
 // TODO minor: chose between unrolled loop and dynamic loop based on number of tasks for performance
 type FunctionRepeat struct{}
 
-func (fn *FunctionRepeat) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionRepeat) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 
 	n, ok := spec.Inputs[RepeatInputTimes]
 	if !ok {
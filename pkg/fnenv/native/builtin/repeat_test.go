@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -22,7 +23,7 @@ func TestFunctionRepeat_Invoke(t *testing.T) {
 			RepeatInputTimes: typedvalues.MustWrap(10),
 		},
 	}
-	result, err := repeatFn.Invoke(spec)
+	result, err := repeatFn.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	wf, err := controlflow.UnwrapWorkflow(result)
 	assert.NoError(t, err)
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -44,36 +45,44 @@ A complete example of this function can be found in the [sleepalot](../examples/
 */
 type FunctionSleep struct{}
 
-func (f *FunctionSleep) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (f *FunctionSleep) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	duration := SleepInputDefault
 	input, ok := spec.Inputs[SleepInput]
 	if ok {
-		i, err := typedvalues.Unwrap(input)
+		d, err := parseDuration(input)
 		if err != nil {
 			return nil, err
 		}
+		duration = d
+	}
 
-		switch t := i.(type) {
-		case string:
-			d, err := time.ParseDuration(t)
-			if err != nil {
-				return nil, err
-			}
-			duration = d
-		case int32:
-			duration = time.Duration(t) * time.Millisecond
-		case int64:
-			duration = time.Duration(t) * time.Millisecond
-		case float32:
-			duration = time.Duration(t) * time.Millisecond
-		case float64:
-			duration = time.Duration(t) * time.Millisecond
-		default:
-			return nil, fmt.Errorf("invalid input '%v'", input.ValueType())
-		}
+	select {
+	case <-time.After(duration):
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
 
-	time.Sleep(duration)
+// parseDuration parses a duration, which is either a Golang Duration string or a number of milliseconds.
+func parseDuration(input *typedvalues.TypedValue) (time.Duration, error) {
+	i, err := typedvalues.Unwrap(input)
+	if err != nil {
+		return 0, err
+	}
 
-	return nil, nil
+	switch t := i.(type) {
+	case string:
+		return time.ParseDuration(t)
+	case int32:
+		return time.Duration(t) * time.Millisecond, nil
+	case int64:
+		return time.Duration(t) * time.Millisecond, nil
+	case float32:
+		return time.Duration(t) * time.Millisecond, nil
+	case float64:
+		return time.Duration(t) * time.Millisecond, nil
+	default:
+		return 0, fmt.Errorf("invalid input '%v'", input.ValueType())
+	}
 }
@@ -1,8 +1,8 @@
 package builtin
 
 import (
+	"context"
 	"testing"
-
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -37,3 +37,16 @@ func TestSleepFunctionInt(t *testing.T) {
 	end := time.Now()
 	assert.True(t, (end.UnixNano()-start.UnixNano()) > (time.Duration(900)*time.Millisecond).Nanoseconds())
 }
+
+func TestSleepFunctionCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := (&FunctionSleep{}).Invoke(ctx, &types.TaskInvocationSpec{
+		Inputs: map[string]*typedvalues.TypedValue{
+			SleepInput: typedvalues.MustWrap("1h"),
+		},
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"errors"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -54,7 +55,7 @@ A complete example of this function can be found in the [switchwhale](../example
 */
 type FunctionSwitch struct{}
 
-func (fn *FunctionSwitch) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionSwitch) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	switchVal, err := fn.getSwitch(spec.Inputs)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -20,7 +21,7 @@ func TestFunctionSwitch_Invoke(t *testing.T) {
 			SwitchInputDefaultCase: typedvalues.MustWrap("default"),
 		},
 	}
-	out, err := fn.Invoke(spec)
+	out, err := fn.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	assert.Equal(t, "case1Val", typedvalues.MustUnwrap(out))
 }
@@ -36,7 +37,7 @@ func TestFunctionSwitch_InvokeDefaultCase(t *testing.T) {
 			SwitchInputDefaultCase: typedvalues.MustWrap("default"),
 		},
 	}
-	out, err := fn.Invoke(spec)
+	out, err := fn.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	assert.Equal(t, spec.Inputs[SwitchInputDefaultCase], out)
 }
@@ -49,7 +50,7 @@ func TestFunctionSwitch_InvokeNoCase(t *testing.T) {
 			"case2":              typedvalues.MustWrap("case2"),
 		},
 	}
-	out, err := fn.Invoke(spec)
+	out, err := fn.Invoke(context.Background(), spec)
 	assert.NoError(t, err)
 	assert.Nil(t, out)
 }
@@ -61,7 +62,7 @@ func TestFunctionSwitch_InvokeNoSwitch(t *testing.T) {
 			"case2": typedvalues.MustWrap("case2"),
 		},
 	}
-	out, err := fn.Invoke(spec)
+	out, err := fn.Invoke(context.Background(), spec)
 	assert.Error(t, err)
 	assert.Nil(t, out)
 }
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -63,7 +64,7 @@ A complete example of this function can be found in the [whilewhale](../examples
 */
 type FunctionWhile struct{}
 
-func (fn *FunctionWhile) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+func (fn *FunctionWhile) Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
 	// Expr
 	exprTv, err := ensureInput(spec.Inputs, WhileInputExpr, typedvalues.TypeBool)
 	if err != nil {
@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fission/fission-workflows/pkg/types"
@@ -10,7 +11,7 @@ import (
 )
 
 func TestFunctionWhile_Invoke(t *testing.T) {
-	out, err := (&FunctionWhile{}).Invoke(&types.TaskInvocationSpec{
+	out, err := (&FunctionWhile{}).Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			WhileInputExpr:  typedvalues.MustWrap(true).SetMetadata("src", "{}"),
 			WhileInputLimit: typedvalues.MustWrap(10),
@@ -26,7 +27,7 @@ func TestFunctionWhile_Invoke(t *testing.T) {
 }
 
 func TestFunctionWhile_InvokeCompletedInitial(t *testing.T) {
-	out, err := (&FunctionWhile{}).Invoke(&types.TaskInvocationSpec{
+	out, err := (&FunctionWhile{}).Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			WhileInputExpr:  typedvalues.MustWrap(false).SetMetadata("src", "{}"),
 			WhileInputLimit: typedvalues.MustWrap(10),
@@ -43,7 +44,7 @@ func TestFunctionWhile_InvokeCompletedInitial(t *testing.T) {
 
 func TestFunctionWhile_InvokeCompleted(t *testing.T) {
 	prev := typedvalues.MustWrap("prev result")
-	out, err := (&FunctionWhile{}).Invoke(&types.TaskInvocationSpec{
+	out, err := (&FunctionWhile{}).Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			WhileInputExpr:  typedvalues.MustWrap(false).SetMetadata("src", "{}"),
 			WhileInputLimit: typedvalues.MustWrap(10),
@@ -60,7 +61,7 @@ func TestFunctionWhile_InvokeCompleted(t *testing.T) {
 }
 
 func TestFunctionWhile_Invoke_LimitExceeded(t *testing.T) {
-	out, err := (&FunctionWhile{}).Invoke(&types.TaskInvocationSpec{
+	out, err := (&FunctionWhile{}).Invoke(context.Background(), &types.TaskInvocationSpec{
 		Inputs: map[string]*typedvalues.TypedValue{
 			WhileInputExpr:  typedvalues.MustWrap(true).SetMetadata("src", "{}"),
 			WhileInputLimit: typedvalues.MustWrap(10),
@@ -2,6 +2,7 @@
 package native
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
 	"time"
@@ -22,7 +23,9 @@ const (
 
 // An InternalFunction is a function that will be executed in the same process as the invoker.
 type InternalFunction interface {
-	Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error)
+	// Invoke runs the function. Once the context is done, the result is no longer needed, so the function should stop
+	// as soon as possible.
+	Invoke(ctx context.Context, spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error)
 }
 
 // FunctionEnv for executing low overhead functions, such as control flow constructs, inside the workflow engine
@@ -39,16 +42,11 @@ func NewFunctionEnv(fns map[string]InternalFunction) *FunctionEnv {
 	return env
 }
 
+// Invoke runs the internal function. If the context is canceled before the function has completed, for example
+// because its result is no longer needed, the function is canceled as well and Invoke returns the error of the
+// context without waiting for the function to stop.
 func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOption) (*types.TaskInvocationStatus, error) {
 	cfg := fnenv.ParseInvokeOptions(opts)
-	defer func() {
-		if r := recover(); r != nil {
-			log.WithFields(log.Fields{
-				"err": r,
-			}).Error("Internal function crashed.")
-			fmt.Println(string(debug.Stack()))
-		}
-	}()
 	if err := validate.TaskInvocationSpec(spec); err != nil {
 		return nil, err
 	}
@@ -62,10 +60,43 @@ func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.Invo
 	}
 	span, _ := opentracing.StartSpanFromContext(cfg.Ctx, fmt.Sprintf("/fnenv/internal/%s", fnID))
 	defer span.Finish()
-	fnenv.FnActive.WithLabelValues(Name).Inc()
-	out, err := fn.Invoke(spec)
-	fnenv.FnActive.WithLabelValues(Name).Dec()
-	fnenv.FnCount.WithLabelValues(Name).Inc()
+	type result struct {
+		out *typedvalues.TypedValue
+		err error
+	}
+	done := make(chan *result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.WithFields(log.Fields{
+					"err": r,
+				}).Error("Internal function crashed.")
+				fmt.Println(string(debug.Stack()))
+				done <- nil
+			}
+		}()
+		fnenv.FnActive.WithLabelValues(Name).Inc()
+		defer fnenv.FnActive.WithLabelValues(Name).Dec()
+		defer fnenv.FnCount.WithLabelValues(Name).Inc()
+		out, err := fn.Invoke(cfg.Ctx, spec)
+		done <- &result{out: out, err: err}
+	}()
+
+	var out *typedvalues.TypedValue
+	var err error
+	select {
+	case r := <-done:
+		if r == nil {
+			return nil, nil
+		}
+		out, err = r.out, r.err
+		if err == fnenv.ErrTaskParked {
+			return nil, err
+		}
+	case <-cfg.Ctx.Done():
+		log.WithField("fnID", fnID).Infof("Stopped waiting for internal function: %v", cfg.Ctx.Err())
+		return nil, cfg.Ctx.Err()
+	}
 	if err != nil {
 		log.WithFields(log.Fields{
 			"fnID": fnID,
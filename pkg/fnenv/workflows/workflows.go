@@ -16,14 +16,12 @@ import (
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
-	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/api/store"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/validate"
-	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/fission/fission-workflows/pkg/util/pubsub"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
@@ -169,119 +167,94 @@ func (rt *Runtime) checkForReadyWorkflow(workflowID string) (*types.Workflow, er
 	return wf, nil
 }
 
-func (rt *Runtime) awaitReadyWorkflow(ctx context.Context, workflowID string) (wf *types.Workflow, err error) {
-	if wf, err = rt.checkForReadyWorkflow(workflowID); err == nil && wf != nil {
+func (rt *Runtime) awaitReadyWorkflow(ctx context.Context, workflowID string) (*types.Workflow, error) {
+	if wf, err := rt.checkForReadyWorkflow(workflowID); err == nil && wf != nil {
 		return wf, nil
 	}
 
-	// await the parsing of the workflow
-	if pub, ok := rt.invocations.CacheReader.(pubsub.Publisher); ok {
-		sub := pub.Subscribe(pubsub.SubscriptionOptions{
-			Buffer: 1,
-			LabelMatcher: labels.And(
-				labels.In(fes.PubSubLabelAggregateType, types.TypeWorkflow),
-				labels.In(fes.PubSubLabelAggregateID, workflowID),
-				labels.In(fes.PubSubLabelEventType,
-					append(events.WorkflowTerminalEvents, events.EventWorkflowParsed)...)),
-		})
-		defer pub.Unsubscribe(sub)
-
-		// Check the cache once to ensure that we did not miss the terminal event while subscribing
-		if wf, err = rt.checkForReadyWorkflow(workflowID); err == nil {
+	// Await the parsing of the workflow
+	updates, err := rt.workflows.WatchWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow %v for new invocation", workflowID)
+	}
+	for wf := range updates {
+		if wf.GetStatus().Ready() {
 			return wf, nil
 		}
-
-		select {
-		case <-ctx.Done():
-			// Check once before cancelling, whether cancelling is needed.
-			if result, err := rt.checkForReadyWorkflow(workflowID); result != nil {
-				return result, nil
-			} else {
-				return nil, err
-			}
-		case <-sub.Ch:
-			return rt.checkForReadyWorkflow(workflowID)
+		if wf.GetStatus().Failed() {
+			break
 		}
 	}
-	return rt.pollUntilWorkflowResult(ctx, workflowID)
+	// Check once more, to either return the workflow or the reason why it is not ready.
+	return rt.checkForReadyWorkflow(workflowID)
 }
 
-func (rt *Runtime) awaitInvocationResult(ctx context.Context, invocationID string) (invocation *types.WorkflowInvocation, err error) {
-	if pub, ok := rt.invocations.CacheReader.(pubsub.Publisher); ok {
-		sub := pub.Subscribe(pubsub.SubscriptionOptions{
-			Buffer: 1,
-			LabelMatcher: labels.And(
-				labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation),
-				labels.In(fes.PubSubLabelAggregateID, invocationID),
-				labels.In(fes.PubSubLabelEventType, events.InvocationTerminalEvents...)),
-		})
-		defer pub.Unsubscribe(sub)
-		logrus.Debugf("Listening for termination event for invocation %s", invocationID)
-
-		// Check the cache once to ensure that we did not miss the terminal event while subscribing
-		if result := rt.checkForInvocationResult(invocationID); result != nil {
-			return result, nil
-		}
-
-		// Block until either we received an completion event or the context completed
-		select {
-		case <-ctx.Done():
-			// Check once before cancelling, whether cancelling is needed.
-			if result := rt.checkForInvocationResult(invocationID); result != nil {
-				return result, nil
-			}
+func (rt *Runtime) awaitInvocationResult(ctx context.Context, invocationID string) (*types.WorkflowInvocation,
+	error) {
+	if _, ok := rt.invocations.CacheReader.(pubsub.Publisher); !ok {
+		// Fallback to polling the cache if the cache does not support pubsub.
+		logrus.Debug("Workflows store does not support pubsub, falling back to polling.")
+		return rt.pollUntilInvocationResult(ctx, invocationID)
+	}
 
-			// Cancel the invocation
-			err := rt.api.Cancel(invocationID)
-			if err == nil {
-				err = errors.New(api.ErrInvocationCanceled)
-			} else {
-				logrus.Errorf("Failed to cancel invocation: %v", err)
+	// Block until either the invocation has finished or the context completed
+	updates, err := rt.watchInvocation(ctx, invocationID)
+	if err == nil {
+		logrus.Debugf("Watching invocation %s until it has finished", invocationID)
+		for wfi := range updates {
+			if wfi.GetStatus().Finished() {
+				logrus.Debugf("Received terminal update for invocation %s", invocationID)
+				return wfi, nil
 			}
-			//span.LogKV("error", err)
-			return nil, err
-		case <-sub.Ch:
-			logrus.Debugf("Received terminal event for invocation %s", invocationID)
-			return rt.checkForInvocationResult(invocationID), nil
 		}
+	} else if ctx.Err() == nil {
+		return nil, err
 	}
 
-	// Fallback to polling the cache if the cache does not support pubsub.
-	logrus.Debug("Workflows store does not support pubsub, falling back to polling.")
-	return rt.pollUntilInvocationResult(ctx, invocationID)
+	// Check once before cancelling, whether cancelling is needed.
+	if result := rt.checkForInvocationResult(invocationID); result != nil {
+		return result, nil
+	}
+
+	// Cancel the invocation
+	err = rt.api.Cancel(invocationID)
+	if err == nil {
+		err = errors.New(api.ErrInvocationCanceled)
+	} else {
+		logrus.Errorf("Failed to cancel invocation: %v", err)
+	}
+	return nil, err
 }
 
-// pollUntilInvocationResult continuously (or until the context is canceled) polls whether the workflow invocation with the
-// specified ID has finished. It either returns the invocation object (if completed) or an error in case of timeouts or
-// context cancellation.
-func (rt *Runtime) pollUntilInvocationResult(ctx context.Context, wfiID string) (*types.WorkflowInvocation, error) {
+// watchInvocation watches the invocation in the store. Because the store might not have processed the creation of the
+// invocation yet, it retries until the invocation is available or the context is done.
+func (rt *Runtime) watchInvocation(ctx context.Context, invocationID string) (<-chan *types.WorkflowInvocation,
+	error) {
 	for {
-		if result := rt.checkForInvocationResult(wfiID); result != nil {
-			return result, nil
+		updates, err := rt.invocations.WatchInvocation(ctx, invocationID)
+		if err == nil || !fes.ErrEntityNotFound.Is(err) {
+			return updates, err
 		}
-
 		select {
 		case <-ctx.Done():
-			err := rt.api.Cancel(wfiID)
-			if err != nil {
-				return nil, err
-			}
 			return nil, ctx.Err()
-		default:
-			time.Sleep(rt.pollInterval)
+		case <-time.After(rt.pollInterval):
 		}
 	}
 }
 
-func (rt *Runtime) pollUntilWorkflowResult(ctx context.Context, workflowID string) (*types.Workflow, error) {
+// pollUntilInvocationResult continuously (or until the context is canceled) polls whether the workflow invocation with the
+// specified ID has finished. It either returns the invocation object (if completed) or an error in case of timeouts or
+// context cancellation.
+func (rt *Runtime) pollUntilInvocationResult(ctx context.Context, wfiID string) (*types.WorkflowInvocation, error) {
 	for {
-		if wf, err := rt.checkForReadyWorkflow(workflowID); err == nil {
-			return wf, nil
+		if result := rt.checkForInvocationResult(wfiID); result != nil {
+			return result, nil
 		}
 
 		select {
 		case <-ctx.Done():
-			err := rt.api.Cancel(workflowID)
+			err := rt.api.Cancel(wfiID)
 			if err != nil {
 				return nil, err
 			}
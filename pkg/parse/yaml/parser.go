@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
@@ -67,11 +71,22 @@ func parseWorkflow(def *workflowSpec) (*types.WorkflowSpec, error) {
 		tasks[id] = p
 	}
 
-	return &types.WorkflowSpec{
-		ApiVersion: def.APIVersion,
-		OutputTask: def.Output,
-		Tasks:      tasks,
-	}, nil
+	spec := &types.WorkflowSpec{
+		ApiVersion:     def.APIVersion,
+		OutputTask:     def.Output,
+		Tasks:          tasks,
+		MaxConcurrency: def.MaxConcurrency,
+	}
+
+	if len(def.Retention) > 0 {
+		retention, err := time.ParseDuration(def.Retention)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention '%s': %v", def.Retention, err)
+		}
+		spec.Retention = ptypes.DurationProto(retention)
+	}
+
+	return spec, nil
 }
 
 func parseTask(t *taskSpec) (*types.TaskSpec, error) {
@@ -97,9 +112,120 @@ func parseTask(t *taskSpec) (*types.TaskSpec, error) {
 		Inputs:      inputs,
 	}
 
+	if t.Await > 0 {
+		result.Await = t.Await
+	}
+
+	if len(t.AwaitMode) > 0 {
+		mode, ok := types.TaskSpec_AwaitMode_value[strings.ToUpper(t.AwaitMode)]
+		if !ok {
+			return nil, fmt.Errorf("invalid awaitMode '%s'", t.AwaitMode)
+		}
+		result.AwaitMode = types.TaskSpec_AwaitMode(mode)
+	}
+
+	if t.When != nil {
+		when, err := parseInput(t.When)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition: %v", err)
+		}
+		result.When = when
+	}
+
+	if t.Retry != nil {
+		retry, err := parseRetryPolicy(t.Retry)
+		if err != nil {
+			return nil, err
+		}
+		result.Retry = retry
+	}
+
+	if t.Cache != nil {
+		cache, err := parseCachePolicy(t.Cache)
+		if err != nil {
+			return nil, err
+		}
+		result.Cache = cache
+	}
+
+	if t.OnError != nil {
+		onError, err := parseErrorHandler(t.OnError)
+		if err != nil {
+			return nil, err
+		}
+		result.OnError = onError
+	}
+
+	if t.Compensate != nil {
+		compensate, err := parseTask(t.Compensate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compensation: %v", err)
+		}
+		result.Compensate = compensate
+	}
+
 	return result, nil
 }
 
+// parseErrorHandler parses the error handler of a task. The fallback is either a task or a workflow.
+func parseErrorHandler(h *errorHandler) (*types.ErrorHandler, error) {
+	handler := &types.ErrorHandler{
+		Continue: h.Continue,
+	}
+	if h.Fallback != nil {
+		tv, err := parseInput(h.Fallback)
+		if err != nil {
+			return nil, err
+		}
+		flow, err := controlflow.UnwrapControlFlow(tv)
+		if err != nil {
+			return nil, fmt.Errorf("fallback should be a task or a workflow: %v", err)
+		}
+		handler.FallbackTask = flow.GetTask()
+		handler.FallbackWorkflow = flow.GetWorkflow()
+	}
+	return handler, nil
+}
+
+func parseCachePolicy(c *cachePolicy) (*types.CachePolicy, error) {
+	policy := &types.CachePolicy{}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache ttl '%s': %v", c.TTL, err)
+	}
+	policy.Ttl = ptypes.DurationProto(ttl)
+	if c.Key != nil {
+		key, err := parseInput(c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache key: %v", err)
+		}
+		policy.Key = key
+	}
+	return policy, nil
+}
+
+func parseRetryPolicy(r *retryPolicy) (*types.RetryPolicy, error) {
+	policy := &types.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		RetryOn:     r.RetryOn,
+	}
+	if len(r.Backoff) > 0 {
+		backoff, err := time.ParseDuration(r.Backoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry backoff '%s': %v", r.Backoff, err)
+		}
+		policy.Backoff = ptypes.DurationProto(backoff)
+	}
+	if len(r.MaxBackoff) > 0 {
+		maxBackoff, err := time.ParseDuration(r.MaxBackoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry maxBackoff '%s': %v", r.MaxBackoff, err)
+		}
+		policy.MaxBackoff = ptypes.DurationProto(maxBackoff)
+	}
+	return policy, nil
+}
+
 // parseInputs parses the inputs of a task. This is typically a map[interface{}]interface{}.
 func parseInputs(i interface{}) (map[string]*typedvalues.TypedValue, error) {
 	if i == nil {
@@ -229,15 +355,41 @@ func convertInterfaceMaps(src map[interface{}]interface{}) map[string]interface{
 //
 
 type workflowSpec struct {
-	APIVersion  string
-	Description string
-	Output      string
-	Tasks       map[string]*taskSpec
+	APIVersion     string
+	Description    string
+	Output         string
+	Retention      string
+	MaxConcurrency int32 `yaml:"maxConcurrency"`
+	Tasks          map[string]*taskSpec
 }
 
 type taskSpec struct {
-	ID       string
-	Run      string
-	Inputs   interface{}
-	Requires []string
+	ID         string
+	Run        string
+	Inputs     interface{}
+	Requires   []string
+	Await      int32
+	AwaitMode  string `yaml:"awaitMode"`
+	When       interface{}
+	Cache      *cachePolicy
+	Retry      *retryPolicy
+	OnError    *errorHandler `yaml:"onError"`
+	Compensate *taskSpec
+}
+
+type errorHandler struct {
+	Continue bool
+	Fallback interface{}
+}
+
+type cachePolicy struct {
+	TTL string `yaml:"ttl"`
+	Key interface{}
+}
+
+type retryPolicy struct {
+	MaxAttempts int32    `yaml:"maxAttempts"`
+	Backoff     string   `yaml:"backoff"`
+	MaxBackoff  string   `yaml:"maxBackoff"`
+	RetryOn     []string `yaml:"retryOn"`
 }
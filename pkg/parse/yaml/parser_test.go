@@ -3,11 +3,14 @@ package yaml
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"fmt"
 
+	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -161,3 +164,234 @@ tasks:
 	assert.NoError(t, err)
 	assert.NotNil(t, wf)
 }
+
+func TestParseWorkflowWithRetention(t *testing.T) {
+	data := `
+retention: 24h
+tasks:
+  foo:
+    run: bla
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	retention, err := ptypes.Duration(wf.GetRetention())
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, retention)
+
+	_, err = Parse(strings.NewReader("retention: forever"))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithMaxConcurrency(t *testing.T) {
+	data := `
+maxConcurrency: 5
+tasks:
+  foo:
+    run: bla
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), wf.GetMaxConcurrency())
+}
+
+func TestParseWorkflowWithConditions(t *testing.T) {
+	data := `
+tasks:
+  check:
+    run: check-stock
+  order:
+    run: order-stock
+    requires:
+    - check
+    when: "{ output('check') < 10 }"
+  never:
+    run: noop
+    when: false
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Nil(t, wf.GetTasks()["check"].GetWhen())
+	assert.Equal(t, typedvalues.TypeExpression, wf.GetTasks()["order"].GetWhen().ValueType())
+	never, err := typedvalues.Unwrap(wf.GetTasks()["never"].GetWhen())
+	assert.NoError(t, err)
+	assert.Equal(t, false, never)
+}
+
+func TestParseWorkflowWithCache(t *testing.T) {
+	data := `
+tasks:
+  geocode:
+    run: geocode
+    inputs: "{$.Invocation.Inputs.default}"
+    cache:
+      ttl: 1h
+  thumbnail:
+    run: thumbnail
+    inputs: "{$.Invocation.Inputs.default}"
+    cache:
+      ttl: 10m
+      key: "{$.Invocation.Inputs.default.url}"
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	geocode := wf.GetTasks()["geocode"].GetCache()
+	assert.Equal(t, ptypes.DurationProto(time.Hour), geocode.GetTtl())
+	assert.Nil(t, geocode.GetKey())
+	thumbnail := wf.GetTasks()["thumbnail"].GetCache()
+	assert.Equal(t, ptypes.DurationProto(10*time.Minute), thumbnail.GetTtl())
+	assert.Equal(t, typedvalues.TypeExpression, thumbnail.GetKey().ValueType())
+
+	_, err = Parse(strings.NewReader(`
+tasks:
+  foo:
+    cache:
+      ttl: forever
+`))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithAwait(t *testing.T) {
+	data := `
+tasks:
+  replica1:
+    run: lookup
+  replica2:
+    run: lookup
+  fastest:
+    run: noop
+    requires:
+    - replica1
+    - replica2
+    await: 1
+    awaitMode: cancel
+  all:
+    run: noop
+    requires:
+    - replica1
+    - replica2
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), wf.GetTasks()["fastest"].GetAwait())
+	assert.Equal(t, types.TaskSpec_CANCEL, wf.GetTasks()["fastest"].GetAwaitMode())
+	assert.Equal(t, int32(2), wf.GetTasks()["all"].GetAwait())
+	assert.Equal(t, types.TaskSpec_CONTINUE, wf.GetTasks()["all"].GetAwaitMode())
+
+	_, err = Parse(strings.NewReader(`
+tasks:
+  foo:
+    awaitMode: eventually
+`))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithRetry(t *testing.T) {
+	data := `
+tasks:
+  foo:
+    run: bla
+    retry:
+      maxAttempts: 3
+      backoff: 500ms
+      maxBackoff: 10s
+      retryOn:
+      - "503"
+      - timeout
+  bar:
+    run: bla
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	retry := wf.GetTasks()["foo"].GetRetry()
+	assert.NotNil(t, retry)
+	assert.EqualValues(t, 3, retry.GetMaxAttempts())
+	backoff, err := ptypes.Duration(retry.GetBackoff())
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, backoff)
+	maxBackoff, err := ptypes.Duration(retry.GetMaxBackoff())
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, maxBackoff)
+	assert.Equal(t, []string{"503", "timeout"}, retry.GetRetryOn())
+	assert.Nil(t, wf.GetTasks()["bar"].GetRetry())
+
+	_, err = Parse(strings.NewReader(`
+tasks:
+  foo:
+    retry:
+      backoff: soon
+`))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithErrorHandlers(t *testing.T) {
+	data := `
+tasks:
+  optional:
+    run: enrich
+    onError:
+      continue: true
+  withFallbackTask:
+    run: fetch-prices
+    onError:
+      fallback:
+        run: cached-prices
+        inputs: $.invocation.inputs
+  withFallbackWorkflow:
+    run: fetch-prices
+    onError:
+      fallback:
+        output: cached
+        tasks:
+          cached:
+            run: cached-prices
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.True(t, wf.GetTasks()["optional"].GetOnError().GetContinue())
+
+	fallbackTask := wf.GetTasks()["withFallbackTask"].GetOnError().GetFallbackTask()
+	assert.NotNil(t, fallbackTask)
+	assert.Equal(t, "cached-prices", fallbackTask.GetFunctionRef())
+	assert.NotNil(t, fallbackTask.GetInputs()["default"])
+
+	fallbackWorkflow := wf.GetTasks()["withFallbackWorkflow"].GetOnError().GetFallbackWorkflow()
+	assert.NotNil(t, fallbackWorkflow)
+	assert.Equal(t, "cached", fallbackWorkflow.GetOutputTask())
+	assert.Contains(t, fallbackWorkflow.GetTasks(), "cached")
+
+	_, err = Parse(strings.NewReader(`
+tasks:
+  foo:
+    onError:
+      fallback: 42
+`))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithCompensation(t *testing.T) {
+	data := `
+tasks:
+  reserve:
+    run: reserve-stock
+    inputs: "{$.Invocation.Inputs.default}"
+    compensate:
+      run: release-stock
+      inputs:
+        reservation: "{output()}"
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	compensate := wf.GetTasks()["reserve"].GetCompensate()
+	assert.NotNil(t, compensate)
+	assert.Equal(t, "release-stock", compensate.GetFunctionRef())
+	assert.NotNil(t, compensate.GetInputs()["reservation"])
+	assert.Empty(t, compensate.GetRequires())
+}
@@ -0,0 +1,59 @@
+package retention
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/jsonpb"
+)
+
+// Archiver stores the events of an aggregate before the aggregate is purged from the event store.
+type Archiver interface {
+	Archive(aggregate fes.Aggregate, events []*fes.Event) error
+}
+
+// DirArchiver archives each aggregate as a file in a directory, containing the events as JSON, one event per line.
+// The files are located at <dir>/<aggregate type>/<aggregate id>.jsonl.
+type DirArchiver struct {
+	Dir string
+}
+
+func NewDirArchiver(dir string) *DirArchiver {
+	return &DirArchiver{
+		Dir: dir,
+	}
+}
+
+func (a *DirArchiver) Archive(aggregate fes.Aggregate, events []*fes.Event) error {
+	if err := fes.ValidateAggregate(&aggregate); err != nil {
+		return err
+	}
+	dir := filepath.Join(a.Dir, filepath.Base(aggregate.Type))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s.jsonl", filepath.Base(aggregate.Id)))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	marshaler := &jsonpb.Marshaler{}
+	for _, event := range events {
+		if err := marshaler.Marshal(w, event); err != nil {
+			return err
+		}
+		if err := w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
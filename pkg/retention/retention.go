@@ -0,0 +1,238 @@
+// Package retention provides the retention subsystem, which removes finished invocations from the event store once
+// their retention period has expired.
+//
+// Without it, the event streams of finished invocations accumulate in the event store forever. The retention period
+// defaults to the configured TTL, but can be overridden per workflow using the retention field of the WorkflowSpec.
+// Only backends that implement fes.Purger are supported.
+package retention
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultInterval = time.Minute
+)
+
+var (
+	ErrPurgeNotSupported = errors.New("event store does not support purging aggregates")
+
+	aggregatesReclaimed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "retention",
+		Name:      "aggregates_reclaimed_total",
+		Help:      "Number of aggregates removed from the event store by the retention subsystem.",
+	}, []string{"type"})
+
+	eventsReclaimed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "retention",
+		Name:      "events_reclaimed_total",
+		Help:      "Number of events removed from the event store by the retention subsystem.",
+	}, []string{"type"})
+
+	runDuration = prometheus.NewSummary(prometheus.SummaryOpts{
+		Namespace: "workflows",
+		Subsystem: "retention",
+		Name:      "run_duration_seconds",
+		Help:      "Duration of a single pass of the retention subsystem over the event store.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(aggregatesReclaimed, eventsReclaimed, runDuration)
+}
+
+// Config contains the user-configurable options of the retention subsystem.
+type Config struct {
+	// TTL is the default duration for which finished invocations are retained. If 0 or less, invocations are
+	// retained indefinitely, unless their workflow specifies a retention.
+	TTL time.Duration
+
+	// Interval is the time between two passes over the event store. If 0 or less, DefaultInterval is used.
+	Interval time.Duration
+
+	// Archiver is optional. If set, the events of an aggregate are archived before the aggregate is purged.
+	Archiver Archiver
+}
+
+// Collector periodically removes the aggregates of finished invocations of which the retention period has expired.
+type Collector struct {
+	Config
+	backend   fes.Backend
+	purger    fes.Purger
+	caches    []fes.CacheWriter
+	projector *projectors.WorkflowInvocation
+	closeC    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewCollector creates a collector for the backend. Purged aggregates are also invalidated in the provided caches.
+//
+// It returns ErrPurgeNotSupported if the backend does not implement fes.Purger.
+func NewCollector(backend fes.Backend, cfg Config, caches ...fes.CacheWriter) (*Collector, error) {
+	purger, ok := backend.(fes.Purger)
+	if !ok {
+		return nil, ErrPurgeNotSupported
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	return &Collector{
+		Config:    cfg,
+		backend:   backend,
+		purger:    purger,
+		caches:    caches,
+		projector: projectors.NewWorkflowInvocation(),
+		closeC:    make(chan struct{}),
+	}, nil
+}
+
+// Run periodically collects expired aggregates until the collector is closed.
+func (c *Collector) Run() error {
+	logrus.Infof("Running retention collector every %v (default TTL: %v)", c.Interval, c.TTL)
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeC:
+			return nil
+		case <-ticker.C:
+			aggregates, events, err := c.Collect(time.Now())
+			if err != nil {
+				logrus.Errorf("Retention collector failed: %v", err)
+			} else if aggregates > 0 {
+				logrus.Infof("Retention collector reclaimed %d aggregate(s) with %d event(s)", aggregates, events)
+			}
+		}
+	}
+}
+
+func (c *Collector) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closeC)
+	})
+	return nil
+}
+
+// Collect performs a single pass over the invocations in the event store, purging the invocations that finished
+// before now minus their retention. It returns the number of aggregates and events that were reclaimed.
+func (c *Collector) Collect(now time.Time) (aggregates int, events int, err error) {
+	start := time.Now()
+	defer func() {
+		runDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	keys, err := c.backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeInvocation
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, key := range keys {
+		n, err := c.collect(key, now)
+		if err != nil {
+			logrus.Warnf("Failed to collect %v: %v", key.Format(), err)
+			continue
+		}
+		if n > 0 {
+			aggregates++
+			events += n
+		}
+	}
+	return aggregates, events, nil
+}
+
+// collect purges the invocation if its retention has expired, returning the number of purged events.
+func (c *Collector) collect(key fes.Aggregate, now time.Time) (int, error) {
+	events, err := c.backend.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 || !hasCompletedHint(events) {
+		return 0, nil
+	}
+
+	// Confirm that the invocation is in a terminal state, since the hints are only meant as an optimization.
+	invocation, err := c.project(key, events)
+	if err != nil {
+		return 0, err
+	}
+	if !invocation.GetStatus().Finished() || invocation.CompensationPending() {
+		return 0, nil
+	}
+
+	retention := c.retentionOf(invocation)
+	if retention <= 0 {
+		return 0, nil
+	}
+	finishedAt, err := ptypes.Timestamp(events[len(events)-1].GetTimestamp())
+	if err != nil {
+		return 0, err
+	}
+	if now.Sub(finishedAt) < retention {
+		return 0, nil
+	}
+
+	if c.Archiver != nil {
+		if err := c.Archiver.Archive(key, events); err != nil {
+			return 0, fmt.Errorf("failed to archive: %v", err)
+		}
+	}
+	n, err := c.purger.Purge(key)
+	if err != nil {
+		return 0, err
+	}
+	for _, cache := range c.caches {
+		cache.Invalidate(key)
+	}
+	aggregatesReclaimed.WithLabelValues(key.Type).Inc()
+	eventsReclaimed.WithLabelValues(key.Type).Add(float64(n))
+	logrus.Debugf("Purged %v (%d events) after a retention of %v", key.Format(), n, retention)
+	return n, nil
+}
+
+func (c *Collector) project(key fes.Aggregate, events []*fes.Event) (*types.WorkflowInvocation, error) {
+	base, err := c.projector.NewProjection(key)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := c.projector.Project(base, events...)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.WorkflowInvocation), nil
+}
+
+// retentionOf returns the retention of the invocation, which is the retention specified by the workflow if present
+// and the default TTL otherwise.
+func (c *Collector) retentionOf(invocation *types.WorkflowInvocation) time.Duration {
+	if retention := invocation.Workflow().GetSpec().GetRetention(); retention != nil {
+		d, err := ptypes.Duration(retention)
+		if err == nil {
+			return d
+		}
+		logrus.Warnf("Ignoring invalid retention of workflow %s: %v", invocation.GetSpec().GetWorkflowId(), err)
+	}
+	return c.TTL
+}
+
+func hasCompletedHint(events []*fes.Event) bool {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].GetHints().GetCompleted() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package retention
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func invoke(t *testing.T, invocationAPI *api.Invocation, wf *types.Workflow) fes.Aggregate {
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(time.Hour))
+	spec.Workflow = wf
+	id, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	return fes.Aggregate{Type: types.TypeInvocation, Id: id}
+}
+
+func TestCollector_Collect(t *testing.T) {
+	backend := mem.NewBackend()
+	invocationAPI := api.NewInvocationAPI(backend)
+	collector, err := NewCollector(backend, Config{TTL: time.Hour})
+	assert.NoError(t, err)
+
+	wf := types.NewWorkflow("wf-1")
+	running := invoke(t, invocationAPI, wf)
+	completed := invoke(t, invocationAPI, wf)
+	assert.NoError(t, invocationAPI.Complete(completed.Id, typedvalues.MustWrap("output"), nil))
+	canceled := invoke(t, invocationAPI, wf)
+	assert.NoError(t, invocationAPI.Cancel(canceled.Id))
+
+	// Nothing has expired yet
+	aggregates, events, err := collector.Collect(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, aggregates)
+	assert.Equal(t, 0, events)
+
+	// Only the finished invocations should be purged
+	aggregates, events, err = collector.Collect(time.Now().Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, aggregates)
+	assert.Equal(t, 4, events)
+	keys, err := backend.List(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{running}, keys)
+}
+
+func TestCollector_CollectWorkflowRetention(t *testing.T) {
+	backend := mem.NewBackend()
+	invocationAPI := api.NewInvocationAPI(backend)
+
+	// Without a default TTL, only the invocations of workflows that specify a retention are purged.
+	collector, err := NewCollector(backend, Config{})
+	assert.NoError(t, err)
+
+	retained := invoke(t, invocationAPI, types.NewWorkflow("wf-retained"))
+	assert.NoError(t, invocationAPI.Cancel(retained.Id))
+	wf := types.NewWorkflow("wf-short-lived")
+	wf.Spec.Retention = ptypes.DurationProto(time.Minute)
+	shortLived := invoke(t, invocationAPI, wf)
+	assert.NoError(t, invocationAPI.Cancel(shortLived.Id))
+
+	aggregates, _, err := collector.Collect(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, aggregates)
+	keys, err := backend.List(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{retained}, keys)
+}
+
+func TestCollector_CollectArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "retention")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	backend := mem.NewBackend()
+	invocationAPI := api.NewInvocationAPI(backend)
+	collector, err := NewCollector(backend, Config{
+		TTL:      time.Minute,
+		Archiver: NewDirArchiver(dir),
+	})
+	assert.NoError(t, err)
+
+	key := invoke(t, invocationAPI, types.NewWorkflow("wf-1"))
+	assert.NoError(t, invocationAPI.Cancel(key.Id))
+	aggregates, _, err := collector.Collect(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, aggregates)
+
+	f, err := os.Open(filepath.Join(dir, key.Type, key.Id+".jsonl"))
+	assert.NoError(t, err)
+	defer f.Close()
+	var lines int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.NoError(t, scanner.Err())
+	assert.Equal(t, 2, lines)
+}
+
+func TestNewCollectorUnsupportedBackend(t *testing.T) {
+	_, err := NewCollector(struct{ fes.Backend }{}, Config{})
+	assert.Equal(t, ErrPurgeNotSupported, err)
+}
@@ -0,0 +1,292 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/golang-lru"
+)
+
+const (
+	// adaptiveHistorySize is the number of task runs that the AdaptivePolicy keeps track of, to ensure that each task
+	// run is only learned from once, and to know which of the task runs have been prepared.
+	adaptiveHistorySize = 10000
+
+	// adaptiveSmoothing is the weight of a new observation in the moving averages of the AdaptivePolicy.
+	adaptiveSmoothing = 0.3
+)
+
+// AdaptivePolicy is a prewarming policy that learns from the history of the task runs.
+//
+// The policy, like the HorizonPolicy, schedules all tasks on the scheduling horizon optimistically.
+// Similarly, it also fails workflow invocations immediately if a task has failed and it is neither retried nor
+// handled by an error handler.
+//
+// On top of that, the policy keeps track of the execution time of each function, based on the task runs that have
+// succeeded in the invocations that it evaluates. The cold start of a function is estimated as the difference between
+// the runs that were not prepared and the runs that were prepared by this policy. Until both are known for a function,
+// the policy assumes the static cold start duration.
+//
+// Using these statistics, the policy predicts when the dependencies of each open task will have finished. Rather than
+// prewarming tasks as early as possible, a task is prepared just in time; once its predicted start is within the
+// cold start of its function.
+type AdaptivePolicy struct {
+	coldStartDuration time.Duration
+	now               func() time.Time
+	stats             map[string]*fnStats
+	statsLock         sync.RWMutex
+	observed          *lru.Cache
+	prepared          *lru.Cache
+}
+
+// fnStats contains the moving averages of the durations of the runs of a single function.
+type fnStats struct {
+	prepared       time.Duration
+	preparedRuns   int
+	unprepared     time.Duration
+	unpreparedRuns int
+}
+
+func NewAdaptivePolicy(coldStartDuration time.Duration) *AdaptivePolicy {
+	observed, err := lru.New(adaptiveHistorySize)
+	if err != nil {
+		panic(err)
+	}
+	prepared, err := lru.New(adaptiveHistorySize)
+	if err != nil {
+		panic(err)
+	}
+	return &AdaptivePolicy{
+		coldStartDuration: coldStartDuration,
+		now:               time.Now,
+		stats:             map[string]*fnStats{},
+		observed:          observed,
+		prepared:          prepared,
+	}
+}
+
+func (p *AdaptivePolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
+	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
+	now := p.now()
+
+	// Learn from the task runs that have finished since the previous evaluation.
+	p.observe(invocation)
+
+	// If there are failed tasks that are neither retried nor handled halt the workflow
+	pendingTasks, aborted := scheduleFailedTasks(invocation, schedule)
+	if aborted {
+		return schedule, nil
+	}
+
+	// Find and schedule all tasks on the scheduling horizon
+	openTasks := getOpenTasks(invocation)
+	horizon := getHorizon(invocation, openTasks, pendingTasks)
+	for _, taskRun := range horizon {
+		schedule.AddRunTask(newRunTaskAction(taskRun.TaskInvocation.ID()))
+	}
+
+	// Prepare the tasks that are still waiting for their dependencies once their predicted start is within the cold
+	// start of their function. The controller also evaluates the policy while tasks are running, in which case the
+	// dependents of the running tasks end up on the horizon as well.
+	predictor := &startPredictor{
+		policy:     p,
+		invocation: invocation,
+		now:        now,
+		finishAt:   map[string]time.Time{},
+	}
+	for id, taskRun := range openTasks {
+		task := taskRun.Task()
+		if len(invocation.PendingDependencies(task)) == 0 || invocation.AwaitReached(task) {
+			continue
+		}
+		startAt := predictor.startAt(task)
+		if startAt.Add(-p.ColdStart(taskFnRef(task))).After(now) {
+			continue
+		}
+		if prepared, _ := p.prepared.ContainsOrAdd(taskRunKey(invocation, id), struct{}{}); prepared {
+			continue
+		}
+		schedule.AddPrepareTask(newPrepareTaskAction(id, startAt))
+	}
+	return schedule, nil
+}
+
+// ExecutionTime returns the expected execution time of the function, excluding the cold start if possible. If there
+// are no runs of the function known yet, it returns 0.
+func (p *AdaptivePolicy) ExecutionTime(fn string) time.Duration {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+	stats, ok := p.stats[fn]
+	switch {
+	case !ok:
+		return 0
+	case stats.preparedRuns > 0:
+		return stats.prepared
+	default:
+		return stats.unprepared
+	}
+}
+
+// ColdStart returns the expected cold start of the function. If there are not enough runs of the function known to
+// estimate it, it returns the static cold start duration of the policy.
+func (p *AdaptivePolicy) ColdStart(fn string) time.Duration {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+	stats, ok := p.stats[fn]
+	if !ok || stats.preparedRuns == 0 || stats.unpreparedRuns == 0 {
+		return p.coldStartDuration
+	}
+	if stats.unprepared < stats.prepared {
+		return 0
+	}
+	return stats.unprepared - stats.prepared
+}
+
+// observe records the durations of the task runs of the invocation that have succeeded. Each task run is only
+// recorded once. Cached and retried task runs are ignored, because their duration does not reflect the execution
+// time of the function.
+func (p *AdaptivePolicy) observe(invocation *types.WorkflowInvocation) {
+	for id, taskRun := range invocation.TaskInvocations() {
+		status := taskRun.GetStatus()
+		if status.GetStatus() != types.TaskInvocationStatus_SUCCEEDED || status.GetCached() ||
+			len(status.GetAttempts()) > 0 {
+			continue
+		}
+		key := taskRunKey(invocation, id)
+		if observed, _ := p.observed.ContainsOrAdd(key, struct{}{}); observed {
+			continue
+		}
+		// Task runs recorded before the start time was persisted have no start time, and are ignored as well.
+		startedAt, err := ptypes.Timestamp(status.GetStartedAt())
+		if err != nil {
+			continue
+		}
+		finishedAt, err := ptypes.Timestamp(status.GetUpdatedAt())
+		if err != nil || finishedAt.Before(startedAt) {
+			continue
+		}
+		task, ok := invocation.Task(id)
+		if !ok {
+			continue
+		}
+		p.record(taskFnRef(task), finishedAt.Sub(startedAt), p.prepared.Contains(key))
+	}
+}
+
+func (p *AdaptivePolicy) record(fn string, duration time.Duration, prepared bool) {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+	stats, ok := p.stats[fn]
+	if !ok {
+		stats = &fnStats{}
+		p.stats[fn] = stats
+	}
+	if prepared {
+		stats.prepared = movingAverage(stats.prepared, duration, stats.preparedRuns)
+		stats.preparedRuns++
+	} else {
+		stats.unprepared = movingAverage(stats.unprepared, duration, stats.unpreparedRuns)
+		stats.unpreparedRuns++
+	}
+}
+
+// startPredictor predicts when the tasks of an invocation will start and finish, using the execution times learned
+// by the policy.
+type startPredictor struct {
+	policy     *AdaptivePolicy
+	invocation *types.WorkflowInvocation
+	now        time.Time
+	finishAt   map[string]time.Time
+}
+
+// startAt predicts when the task will start; once its dependencies, or enough of them if the task awaits only some,
+// have finished.
+func (sp *startPredictor) startAt(task *types.Task) time.Time {
+	var finishes []time.Time
+	for depID := range task.GetSpec().GetRequires() {
+		if _, ok := sp.invocation.Task(depID); ok {
+			finishes = append(finishes, sp.finish(depID))
+		}
+	}
+	if len(finishes) == 0 {
+		return sp.now
+	}
+	sort.Slice(finishes, func(i, j int) bool {
+		return finishes[i].Before(finishes[j])
+	})
+	startAt := finishes[len(finishes)-1]
+	if await := int(task.GetSpec().GetAwait()); task.GetSpec().AwaitsQuorum() && await <= len(finishes) {
+		startAt = finishes[await-1]
+	}
+	if startAt.Before(sp.now) {
+		return sp.now
+	}
+	return startAt
+}
+
+// finish predicts when the task will finish. For finished tasks it returns the actual finish, or the predicted
+// finish of the dynamic task that took its place.
+func (sp *startPredictor) finish(taskID string) time.Time {
+	if finishAt, ok := sp.finishAt[taskID]; ok {
+		return finishAt
+	}
+	// Guard against cycles in the dependencies.
+	sp.finishAt[taskID] = sp.now
+
+	task, _ := sp.invocation.Task(taskID)
+	executionTime := sp.policy.ExecutionTime(taskFnRef(task))
+	var finishAt time.Time
+	taskRun, ok := sp.invocation.TaskInvocation(taskID)
+	status := taskRun.GetStatus().GetStatus()
+	switch {
+	case !ok || status == types.TaskInvocationStatus_UNKNOWN:
+		finishAt = sp.startAt(task).Add(executionTime)
+	case status == types.TaskInvocationStatus_FAILED:
+		// The task is either retried or handled, either of which will start soon.
+		finishAt = sp.now.Add(executionTime)
+	case taskRun.GetStatus().Finished():
+		if child, ok := sp.invocation.DynamicChild(taskID); ok {
+			finishAt = sp.finish(child.ID())
+		} else {
+			finishAt, _ = ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt())
+		}
+	default:
+		// The start of a running attempt is only recorded once it has finished, so assume that the attempt started
+		// when it was retried.
+		startedAt, err := ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt())
+		if err != nil {
+			startedAt = sp.now
+		}
+		finishAt = startedAt.Add(executionTime)
+		if finishAt.Before(sp.now) {
+			// The task is taking longer than expected; it could finish any moment now.
+			finishAt = sp.now
+		}
+	}
+	sp.finishAt[taskID] = finishAt
+	return finishAt
+}
+
+// taskFnRef returns the function of the task, using the resolved function reference if it is available.
+func taskFnRef(task *types.Task) string {
+	if fnRef := task.GetStatus().GetFnRef(); fnRef != nil {
+		return fnRef.Format()
+	}
+	return task.GetSpec().GetFunctionRef()
+}
+
+func taskRunKey(invocation *types.WorkflowInvocation, taskID string) string {
+	return fmt.Sprintf("%s/%s", invocation.ID(), taskID)
+}
+
+// movingAverage adds the observation to the exponentially weighted moving average of n observations.
+func movingAverage(avg time.Duration, observation time.Duration, n int) time.Duration {
+	if n == 0 {
+		return observation
+	}
+	return time.Duration(adaptiveSmoothing*float64(observation) + (1-adaptiveSmoothing)*float64(avg))
+}
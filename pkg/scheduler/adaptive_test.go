@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+// projectTaskEvent projects the event of the task onto the invocation, as if the event was appended at the given time.
+func projectTaskEvent(t *testing.T, invocation *types.WorkflowInvocation, taskID string, appendedAt time.Time,
+	msg proto.Message) *types.WorkflowInvocation {
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), msg)
+	assert.NoError(t, err)
+	aggregate := projectors.NewInvocationAggregate(invocation.ID())
+	event.Parent = &aggregate
+	event.Timestamp = timestampProto(appendedAt)
+	updated, err := projectors.NewWorkflowInvocation().Project(invocation, event)
+	assert.NoError(t, err)
+	return updated.(*types.WorkflowInvocation)
+}
+
+// projectSucceededTaskRun projects a task run that started at startedAt and succeeded after duration.
+func projectSucceededTaskRun(t *testing.T, invocation *types.WorkflowInvocation, taskID string, startedAt time.Time,
+	duration time.Duration) *types.WorkflowInvocation {
+	return projectTaskEvent(t, invocation, taskID, startedAt.Add(duration), &events.TaskSucceeded{
+		Result:    &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_SUCCEEDED},
+		StartedAt: timestampProto(startedAt),
+	})
+}
+
+func timestampProto(t time.Time) *timestamp.Timestamp {
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+func TestAdaptivePolicy_LearnsFromTaskRuns(t *testing.T) {
+	policy := NewAdaptivePolicy(time.Second)
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "noop"})
+	now := time.Now()
+
+	// Without any runs, the execution time is unknown and the cold start is static.
+	assert.Equal(t, time.Duration(0), policy.ExecutionTime("noop"))
+	assert.Equal(t, time.Second, policy.ColdStart("noop"))
+
+	// A run that was not prepared only provides the execution time.
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", now.Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	invocation = projectSucceededTaskRun(t, invocation, "a", now, 3*time.Second)
+	_, err := policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, policy.ExecutionTime("noop"))
+	assert.Equal(t, time.Second, policy.ColdStart("noop"))
+
+	// Each task run should only be learned from once.
+	_, err = policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, policy.stats["noop"].unpreparedRuns)
+
+	// Once a prepared run is known as well, the cold start is the difference between the two.
+	invocation = types.NewWorkflowInvocation("wf-1", "wi-2", now.Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	invocation = projectSucceededTaskRun(t, invocation, "a", now, 500*time.Millisecond)
+	policy.prepared.Add(taskRunKey(invocation, "a"), struct{}{})
+	_, err = policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, policy.ExecutionTime("noop"))
+	assert.Equal(t, 2500*time.Millisecond, policy.ColdStart("noop"))
+
+	// Task runs of which the start is unknown should be ignored.
+	invocation = types.NewWorkflowInvocation("wf-1", "wi-3", now.Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	invocation = projectTaskEvent(t, invocation, "a", now.Add(time.Minute), &events.TaskSucceeded{
+		Result: &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_SUCCEEDED},
+	})
+	_, err = policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, policy.stats["noop"].unpreparedRuns)
+}
+
+func TestAdaptivePolicy_PreparesJustInTime(t *testing.T) {
+	now := time.Now()
+	policy := NewAdaptivePolicy(time.Second)
+	policy.now = func() time.Time {
+		return now
+	}
+	policy.record("slow", 10*time.Second, false)
+
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "slow"})
+	wf.Spec.AddTask("b", &types.TaskSpec{FunctionRef: "noop", Requires: types.Require("a")})
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", now.Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	// The first attempt of the dependency failed, after which it is run again.
+	invocation = projectTaskEvent(t, invocation, "a", now, &events.TaskFailed{
+		Error:     &types.Error{Message: "unavailable"},
+		StartedAt: timestampProto(now.Add(-time.Second)),
+	})
+	invocation = projectTaskEvent(t, invocation, "a", now, &events.TaskRetried{Attempt: 1})
+
+	// The dependent task should not be prepared while its dependency is expected to run for a while. While tasks are
+	// running, the controller only considers the prepare actions of the schedule.
+	schedule, err := policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Empty(t, schedule.GetPrepareTasks())
+
+	// Once the predicted finish of the dependency is within the cold start, the dependent task should be prepared.
+	now = now.Add(9500 * time.Millisecond)
+	schedule, err = policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Len(t, schedule.GetPrepareTasks(), 1)
+	assert.Equal(t, "b", schedule.GetPrepareTasks()[0].GetTaskID())
+	assert.WithinDuration(t, now.Add(500*time.Millisecond), schedule.GetPrepareTasks()[0].GetExpectedAtTime(), 0)
+
+	// The task should only be prepared once.
+	schedule, err = policy.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Empty(t, schedule.GetPrepareTasks())
+}
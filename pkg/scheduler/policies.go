@@ -14,7 +14,8 @@ var DefaultPolicy = NewHorizonPolicy()
 // HorizonPolicy is the default policy of the workflow engine. It solely schedules tasks that are on the scheduling horizon.
 //
 // The scheduling horizon is the set of tasks that only depend on tasks that have already completed.
-// If a task has failed this policy simply fails the workflow
+// If a task has failed, and it is neither retried nor handled by an error handler, this policy simply fails the
+// workflow.
 type HorizonPolicy struct {
 }
 
@@ -25,24 +26,17 @@ func NewHorizonPolicy() *HorizonPolicy {
 func (p *HorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
-		for _, failedTask := range failedTasks {
-			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
-			if err := failedTask.GetStatus().GetError(); err != nil {
-				msg = err.Message
-			}
-			schedule.Abort = newAbortAction(msg)
-		}
+	// If there are failed tasks that are neither retried nor handled halt the workflow
+	pendingTasks, aborted := scheduleFailedTasks(invocation, schedule)
+	if aborted {
 		return schedule, nil
 	}
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
+	horizon := getHorizon(invocation, openTasks, pendingTasks)
 	for _, node := range horizon {
-		schedule.AddRunTask(newRunTaskAction(node.(*graph.TaskInvocationNode).Task().ID()))
+		schedule.AddRunTask(newRunTaskAction(node.Task().ID()))
 	}
 	return schedule, nil
 }
@@ -50,7 +44,8 @@ func (p *HorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedul
 // PrewarmAllPolicy is the policy with the most aggressive form of prewarming.
 //
 // The policy, like the HorizonPolicy, schedules all tasks on the scheduling horizon optimistically.
-// Similarly, it also fails workflow invocations immediately if a task has failed
+// Similarly, it also fails workflow invocations immediately if a task has failed and it is neither retried nor
+// handled by an error handler.
 //
 // However, on top of the HorizonPolicy, this policy prewarms tasks aggressively. Any unstarted task not on the
 // scheduling horizon will be prewarmed.
@@ -67,24 +62,16 @@ func NewPrewarmAllPolicy(coldstartDuration time.Duration) *PrewarmAllPolicy {
 func (p *PrewarmAllPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
-		for _, failedTask := range failedTasks {
-			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
-			if err := failedTask.GetStatus().GetError(); err != nil {
-				msg = err.Message
-			}
-			schedule.Abort = newAbortAction(msg)
-		}
+	// If there are failed tasks that are neither retried nor handled halt the workflow
+	pendingTasks, aborted := scheduleFailedTasks(invocation, schedule)
+	if aborted {
 		return schedule, nil
 	}
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
-	for _, node := range horizon {
-		taskRun := node.(*graph.TaskInvocationNode)
+	horizon := getHorizon(invocation, openTasks, pendingTasks)
+	for _, taskRun := range horizon {
 		schedule.AddRunTask(newRunTaskAction(taskRun.TaskInvocation.ID()))
 		delete(openTasks, taskRun.GetMetadata().GetId())
 	}
@@ -100,7 +87,8 @@ func (p *PrewarmAllPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Sche
 // PrewarmHorizonPolicy is the policy with the most aggressive form of prewarming.
 //
 // The policy, like the HorizonPolicy, schedules all tasks on the scheduling horizon optimistically.
-// Similarly, it also fails workflow invocations immediately if a task has failed
+// Similarly, it also fails workflow invocations immediately if a task has failed and it is neither retried nor
+// handled by an error handler.
 //
 // However, on top of the HorizonPolicy, tries to policy prewarms tasks aggressively. Any unstarted task on the
 // prewarm horizon will be prewarmed.
@@ -117,24 +105,16 @@ func NewPrewarmHorizonPolicy(coldstartDuration time.Duration) *PrewarmHorizonPol
 func (p *PrewarmHorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
-		for _, failedTask := range failedTasks {
-			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
-			if err := failedTask.GetStatus().GetError(); err != nil {
-				msg = err.Message
-			}
-			schedule.Abort = newAbortAction(msg)
-		}
+	// If there are failed tasks that are neither retried nor handled halt the workflow
+	pendingTasks, aborted := scheduleFailedTasks(invocation, schedule)
+	if aborted {
 		return schedule, nil
 	}
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
-	for _, node := range horizon {
-		taskRun := node.(*graph.TaskInvocationNode)
+	horizon := getHorizon(invocation, openTasks, pendingTasks)
+	for _, taskRun := range horizon {
 		schedule.AddRunTask(newRunTaskAction(taskRun.TaskInvocation.ID()))
 		delete(openTasks, taskRun.GetMetadata().GetId())
 	}
@@ -152,6 +132,83 @@ func (p *PrewarmHorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*
 	return schedule, nil
 }
 
+// scheduleFailedTasks handles the failed tasks of the invocation. If the failure of one of the tasks is neither retried
+// nor handled by the error handler of the task, the abort action is added to the schedule and aborted is true.
+// Otherwise, the failed tasks for which the backoff of their retry policy has passed are scheduled to run again.
+//
+// It returns the failed tasks that are still pending; the tasks that are to be retried, regardless of whether their
+// retry has been scheduled yet, and the tasks of which the fallback has not been added to the invocation yet.
+func scheduleFailedTasks(invocation *types.WorkflowInvocation,
+	schedule *Schedule) (pendingTasks map[string]*types.TaskInvocation, aborted bool) {
+	now := time.Now()
+	pendingTasks = map[string]*types.TaskInvocation{}
+	var retries []*RunTaskAction
+	for _, failedTask := range getFailedTasks(invocation) {
+		task, ok := invocation.Task(failedTask.ID())
+		retryable := ok && task.GetSpec().GetRetry().Retryable(failedTask.GetStatus())
+		handled := ok && task.GetSpec().FailureHandled(failedTask.GetStatus())
+		if !retryable && !handled {
+			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
+			if err := failedTask.GetStatus().GetError(); err != nil {
+				msg = err.Message
+			}
+			schedule.Abort = newAbortAction(msg)
+			continue
+		}
+		if handled {
+			// The failure is tolerated, or the fallback takes the place of the task once it has been added.
+			if _, ok := invocation.DynamicChild(task.ID()); ok || !task.GetSpec().GetOnError().HasFallback() {
+				continue
+			}
+		}
+		pendingTasks[task.ID()] = &types.TaskInvocation{
+			Metadata: types.NewObjectMetadata(task.ID()),
+			Spec:     types.NewTaskInvocationSpec(invocation, task, now),
+			Status:   failedTask.GetStatus(),
+		}
+		if retryable && !now.Before(task.GetSpec().GetRetry().RetryAt(failedTask.GetStatus())) {
+			retries = append(retries, newRunTaskAction(task.ID()))
+		}
+	}
+	if schedule.Abort != nil {
+		return nil, true
+	}
+	for _, action := range retries {
+		schedule.AddRunTask(action)
+	}
+	return pendingTasks, false
+}
+
+// getHorizon returns the open tasks that are on the scheduling horizon. The failed tasks that are still pending are
+// taken into account as dependencies, to ensure that their dependents are not scheduled before the retry or fallback
+// of the failed task has succeeded. The tasks that await only some of their dependencies are on the horizon as soon
+// as enough of those have succeeded.
+func getHorizon(invocation *types.WorkflowInvocation, openTasks map[string]*types.TaskInvocation,
+	pendingTasks map[string]*types.TaskInvocation) []*graph.TaskInvocationNode {
+	tasks := make(map[string]*types.TaskInvocation, len(openTasks)+len(pendingTasks))
+	for id, task := range openTasks {
+		tasks[id] = task
+	}
+	for id, task := range pendingTasks {
+		tasks[id] = task
+	}
+	var horizon []*graph.TaskInvocationNode
+	onHorizon := map[string]struct{}{}
+	for _, node := range graph.Roots(graph.Parse(graph.NewTaskInstanceIterator(tasks))) {
+		taskRun := node.(*graph.TaskInvocationNode)
+		if _, ok := openTasks[taskRun.Task().ID()]; ok {
+			horizon = append(horizon, taskRun)
+			onHorizon[taskRun.Task().ID()] = struct{}{}
+		}
+	}
+	for id, taskRun := range openTasks {
+		if _, ok := onHorizon[id]; !ok && invocation.AwaitReached(taskRun.Task()) {
+			horizon = append(horizon, &graph.TaskInvocationNode{TaskInvocation: taskRun})
+		}
+	}
+	return horizon
+}
+
 func getFailedTasks(invocation *types.WorkflowInvocation) []*types.TaskInvocation {
 	var failedTasks []*types.TaskInvocation
 	for _, task := range invocation.TaskInvocations() {
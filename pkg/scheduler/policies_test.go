@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupFailedInvocation creates an invocation in which task 'a' failed at failedAt, and task 'b' depends on it.
+func setupFailedInvocation(retry *types.RetryPolicy, onError *types.ErrorHandler,
+	failedAt time.Time) *types.WorkflowInvocation {
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "noop", Retry: retry, OnError: onError})
+	wf.Spec.AddTask("b", &types.TaskSpec{FunctionRef: "noop", Requires: types.Require("a")})
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	ts, _ := ptypes.TimestampProto(failedAt)
+	invocation.Status.Tasks = map[string]*types.TaskInvocation{
+		"a": {
+			Metadata: types.NewObjectMetadata("a"),
+			Status: &types.TaskInvocationStatus{
+				Status:    types.TaskInvocationStatus_FAILED,
+				UpdatedAt: ts,
+				Error:     &types.Error{Message: "function returned status 503"},
+			},
+		},
+	}
+	return invocation
+}
+
+func TestHorizonPolicy_AbortsFailedTask(t *testing.T) {
+	invocation := setupFailedInvocation(nil, nil, time.Now())
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.NotNil(t, schedule.GetAbort())
+	assert.Equal(t, "function returned status 503", schedule.GetAbort().GetReason())
+}
+
+func TestHorizonPolicy_RetriesFailedTask(t *testing.T) {
+	retry := &types.RetryPolicy{
+		MaxAttempts: 2,
+		Backoff:     ptypes.DurationProto(time.Minute),
+		RetryOn:     []string{"503"},
+	}
+
+	// The retry should not be scheduled before the backoff has passed, nor should the dependent task.
+	invocation := setupFailedInvocation(retry, nil, time.Now())
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Empty(t, schedule.GetRunTasks())
+
+	// Once the backoff has passed, only the failed task should be run again.
+	invocation = setupFailedInvocation(retry, nil, time.Now().Add(-time.Minute))
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Len(t, schedule.GetRunTasks(), 1)
+	assert.Equal(t, "a", schedule.GetRunTasks()[0].GetTaskID())
+
+	// Once the attempts have been exhausted, the invocation should be aborted.
+	invocation.Status.Tasks["a"].Status.Attempts = []*types.TaskAttempt{{}}
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.NotNil(t, schedule.GetAbort())
+}
+
+func TestHorizonPolicy_ContinuesOnToleratedFailure(t *testing.T) {
+	invocation := setupFailedInvocation(nil, &types.ErrorHandler{Continue: true}, time.Now())
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Len(t, schedule.GetRunTasks(), 1)
+	assert.Equal(t, "b", schedule.GetRunTasks()[0].GetTaskID())
+}
+
+func TestHorizonPolicy_AwaitsFallback(t *testing.T) {
+	onError := &types.ErrorHandler{FallbackTask: &types.TaskSpec{FunctionRef: "noop"}}
+
+	// Before the fallback has been added, the dependent task should not be scheduled.
+	invocation := setupFailedInvocation(nil, onError, time.Now())
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Empty(t, schedule.GetRunTasks())
+
+	// Once the fallback has been added, it should be scheduled in place of the failed task.
+	fallback := types.NewTask("a_child", "noop")
+	fallback.Spec.Require("a", &types.TaskDependencyParameters{Type: types.TaskDependencyParameters_DYNAMIC_OUTPUT})
+	invocation.Status.DynamicTasks = map[string]*types.Task{fallback.ID(): fallback}
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Len(t, schedule.GetRunTasks(), 1)
+	assert.Equal(t, "a_child", schedule.GetRunTasks()[0].GetTaskID())
+}
+
+func TestHorizonPolicy_AwaitsQuorum(t *testing.T) {
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("b", &types.TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("c", &types.TaskSpec{FunctionRef: "noop", Requires: types.Require("a", "b"), Await: 1})
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+
+	// Before any of its dependencies has succeeded, the task should not be scheduled.
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b"}, runTaskIDs(schedule))
+
+	// Once one of its dependencies has succeeded, the task should be scheduled, regardless of the other dependency.
+	invocation.Status.Tasks = map[string]*types.TaskInvocation{
+		"a": {
+			Metadata: types.NewObjectMetadata("a"),
+			Status:   &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_SUCCEEDED},
+		},
+	}
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"b", "c"}, runTaskIDs(schedule))
+}
+
+func runTaskIDs(schedule *Schedule) []string {
+	var ids []string
+	for _, action := range schedule.GetRunTasks() {
+		ids = append(ids, action.GetTaskID())
+	}
+	return ids
+}
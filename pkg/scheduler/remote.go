@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"google.golang.org/grpc"
+)
+
+// DefaultRemoteTimeout is the maximum duration of an evaluation by a remote scheduler.
+const DefaultRemoteTimeout = 5 * time.Second
+
+// Server serves the evaluations of the invocation scheduler over the Scheduler gRPC service, which allows another
+// workflow engine to delegate its scheduling decisions to this process using the RemotePolicy.
+type Server struct {
+	scheduler *InvocationScheduler
+}
+
+func NewServer(scheduler *InvocationScheduler) *Server {
+	return &Server{
+		scheduler: scheduler,
+	}
+}
+
+func (s *Server) Evaluate(ctx context.Context, invocation *types.WorkflowInvocation) (*Schedule, error) {
+	return s.scheduler.Evaluate(invocation)
+}
+
+// RemotePolicy is a policy that delegates the evaluation of invocations to an external scheduler, which serves the
+// Scheduler gRPC service.
+//
+// This allows custom schedulers to be used without having to modify the workflow engine. If the external scheduler
+// cannot be reached, or does not respond within the timeout, the evaluation fails; the controller will reevaluate the
+// invocation later on.
+type RemotePolicy struct {
+	client  SchedulerClient
+	timeout time.Duration
+}
+
+func NewRemotePolicy(client SchedulerClient, timeout time.Duration) *RemotePolicy {
+	return &RemotePolicy{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// ConnectRemotePolicy creates a RemotePolicy for the scheduler at addr.
+//
+// addr should be of the format <hostname>:<port>, without a method. (e.g. scheduler:5555)
+func ConnectRemotePolicy(addr string) (*RemotePolicy, error) {
+	cc, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	return NewRemotePolicy(NewSchedulerClient(cc), DefaultRemoteTimeout), nil
+}
+
+func (p *RemotePolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	schedule, err := p.client.Evaluate(ctx, invocation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate invocation with remote scheduler: %v", err)
+	}
+	if schedule.GetInvocationId() != invocation.ID() {
+		return nil, fmt.Errorf("remote scheduler returned schedule for invocation '%s', instead of '%s'",
+			schedule.GetInvocationId(), invocation.ID())
+	}
+	return schedule, nil
+}
@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type errPolicy struct{}
+
+func (p *errPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
+	return nil, errors.New("scheduler is unavailable")
+}
+
+// setupRemotePolicy serves the policy over gRPC and returns a RemotePolicy that is connected to it.
+func setupRemotePolicy(t *testing.T, policy Policy) (*RemotePolicy, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	RegisterSchedulerServer(s, NewServer(NewInvocationScheduler(policy)))
+	go s.Serve(lis)
+	remote, err := ConnectRemotePolicy(lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remote, s.Stop
+}
+
+func TestRemotePolicy(t *testing.T) {
+	remote, stop := setupRemotePolicy(t, NewHorizonPolicy())
+	defer stop()
+	wf := types.NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("b", &types.TaskSpec{FunctionRef: "noop", Requires: types.Require("a")})
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+
+	schedule, err := remote.Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, "wi-1", schedule.GetInvocationId())
+	assert.Equal(t, []string{"a"}, runTaskIDs(schedule))
+}
+
+func TestRemotePolicy_Error(t *testing.T) {
+	remote, stop := setupRemotePolicy(t, &errPolicy{})
+	defer stop()
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+
+	_, err := remote.Evaluate(invocation)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "scheduler is unavailable")
+}
@@ -0,0 +1,86 @@
+package triggers
+
+import (
+	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+// MatchesCompletion checks if the finished invocation matches the completion trigger, which requires the invocation
+// to belong to the workflow of the trigger, to have one of its statuses, and to have all of its labels.
+func MatchesCompletion(completion *types.CompletionTrigger, invocation *types.WorkflowInvocation) bool {
+	if completion == nil || !finished(invocation) {
+		return false
+	}
+	workflowID := completion.GetWorkflowId()
+	if invocation.GetSpec().GetWorkflowId() != workflowID && invocation.Workflow().ID() != workflowID {
+		return false
+	}
+
+	statuses := completion.GetStatuses()
+	if len(statuses) == 0 {
+		statuses = []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_SUCCEEDED}
+	}
+	var matched bool
+	for _, status := range statuses {
+		if invocation.GetStatus().GetStatus() == status {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+
+	labels := invocation.GetSpec().GetLabels()
+	for k, v := range completion.GetLabels() {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// CompletionInputs resolves the inputs of the trigger against the finished invocation. The expressions in the inputs
+// have the same scope as the expressions in the tasks of the invocation, including the status and output of the
+// invocation (e.g. '{$.Invocation.Output}'). If the trigger has no inputs, the output of the invocation is used as the
+// default input.
+func CompletionInputs(spec *types.TriggerSpec, invocation *types.WorkflowInvocation) (map[string]*typedvalues.TypedValue,
+	error) {
+	inputs := map[string]*typedvalues.TypedValue{}
+	if len(spec.GetInputs()) == 0 {
+		if output := invocation.GetStatus().GetOutput(); output != nil {
+			inputs[types.InputMain] = output
+		}
+		return inputs, nil
+	}
+
+	scope, err := expr.NewScope(nil, invocation)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range spec.GetInputs() {
+		resolved, err := expr.Resolve(scope, "", v)
+		if err != nil {
+			return nil, err
+		}
+		inputs[k] = resolved
+	}
+	return inputs, nil
+}
+
+// finished checks if the invocation has finished, guarding against invocations without a status.
+func finished(invocation *types.WorkflowInvocation) bool {
+	return invocation.GetStatus() != nil && invocation.GetStatus().Finished()
+}
+
+// finishedAfter checks if the finished invocation was last updated, which is when it finished, after the given time.
+func finishedAfter(invocation *types.WorkflowInvocation, ts *timestamp.Timestamp) bool {
+	finishedAt := invocation.GetStatus().GetUpdatedAt()
+	if finishedAt == nil || ts == nil {
+		return true
+	}
+	return util.CmpProtoTimestamps(ts, finishedAt)
+}
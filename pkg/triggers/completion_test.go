@@ -0,0 +1,71 @@
+package triggers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func finishedInvocation(status types.WorkflowInvocationStatus_Status) *types.WorkflowInvocation {
+	invocation := types.NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Inputs = map[string]*typedvalues.TypedValue{
+		types.InputMain: typedvalues.MustWrap("input"),
+	}
+	invocation.Spec.Labels = map[string]string{"env": "prod"}
+	invocation.Status.Status = status
+	if status == types.WorkflowInvocationStatus_SUCCEEDED {
+		invocation.Status.Output = typedvalues.MustWrap("output")
+	}
+	return invocation
+}
+
+func TestMatchesCompletion(t *testing.T) {
+	succeeded := finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED)
+	failed := finishedInvocation(types.WorkflowInvocationStatus_FAILED)
+	running := finishedInvocation(types.WorkflowInvocationStatus_IN_PROGRESS)
+
+	// By default, only succeeded invocations match.
+	completion := &types.CompletionTrigger{WorkflowId: "wf-1"}
+	assert.True(t, MatchesCompletion(completion, succeeded))
+	assert.False(t, MatchesCompletion(completion, failed))
+	assert.False(t, MatchesCompletion(completion, running))
+	assert.False(t, MatchesCompletion(&types.CompletionTrigger{WorkflowId: "wf-2"}, succeeded))
+
+	completion.Statuses = []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_FAILED}
+	assert.False(t, MatchesCompletion(completion, succeeded))
+	assert.True(t, MatchesCompletion(completion, failed))
+
+	completion = &types.CompletionTrigger{WorkflowId: "wf-1", Labels: map[string]string{"env": "prod"}}
+	assert.True(t, MatchesCompletion(completion, succeeded))
+	completion.Labels["env"] = "dev"
+	assert.False(t, MatchesCompletion(completion, succeeded))
+}
+
+func TestCompletionInputs(t *testing.T) {
+	invocation := finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED)
+
+	// Without inputs, the output of the invocation is the default input.
+	inputs, err := CompletionInputs(&types.TriggerSpec{}, invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, "output", typedvalues.MustUnwrap(inputs[types.InputMain]))
+
+	inputs, err = CompletionInputs(&types.TriggerSpec{
+		Inputs: map[string]*typedvalues.TypedValue{
+			types.InputMain: typedvalues.MustWrap("{$.Invocation.Output + ' of ' + $.Invocation.Inputs.default}"),
+			"status":        typedvalues.MustWrap("{$.Invocation.Status}"),
+			"static":        typedvalues.MustWrap("foo"),
+		},
+	}, invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, "output of input", typedvalues.MustUnwrap(inputs[types.InputMain]))
+	assert.Equal(t, types.WorkflowInvocationStatus_SUCCEEDED.String(), typedvalues.MustUnwrap(inputs["status"]))
+	assert.Equal(t, "foo", typedvalues.MustUnwrap(inputs["static"]))
+
+	// A failed invocation has no output.
+	inputs, err = CompletionInputs(&types.TriggerSpec{}, finishedInvocation(types.WorkflowInvocationStatus_FAILED))
+	assert.NoError(t, err)
+	assert.Empty(t, inputs)
+}
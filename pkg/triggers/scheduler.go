@@ -0,0 +1,523 @@
+// Package triggers provides the trigger scheduler, which invokes workflows on a schedule defined by triggers.
+//
+// A trigger fires either according to a cron expression, at a fixed interval, or whenever an invocation of another
+// workflow finishes. Each firing is recorded in the event
+// stream of the trigger, which makes the schedule resilient to restarts of the scheduler: firings that were missed
+// while the scheduler was down are caught up once, rather than once per missed firing. Since a firing is only recorded
+// if the trigger was not modified concurrently, multiple schedulers do not start duplicate invocations. If the
+// schedulers of multiple replicas share a partition, each scheduled trigger is evaluated by the replica that owns the
+// trigger, and each completion trigger is fired by the replica that owns the finished invocation.
+//
+// Completion triggers are fired as soon as the scheduler is notified of an invocation finishing. Because notifications
+// are lost when the scheduler restarts, when the ownership of the invocation moves to another replica, or when the
+// event store does not support pubsub, the scheduler also periodically scans the recently finished invocations to
+// catch up on the completions that it missed. The trigger records every invocation that it fired or skipped for, so
+// that an invocation fires the trigger at most once.
+package triggers
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultInterval = time.Second
+
+	// DefaultCatchUpInterval is the default time between two scans for missed completions.
+	DefaultCatchUpInterval = time.Minute
+
+	// CatchUpWindow bounds how long ago an invocation can have finished for its completion to be caught up. It is
+	// well within types.TriggerCompletionRetention, so that a completion is not caught up after the trigger forgot
+	// that it already handled it.
+	CatchUpWindow = types.TriggerCompletionRetention / 2
+
+	// maxCompletionRetries is the maximum number of times that firing a completion trigger is retried after a
+	// concurrent modification of the trigger. If the retries are exhausted, the completion is left to be caught up.
+	maxCompletionRetries = 5
+
+	// LabelTrigger is the label of the invocations started by a trigger, which contains the id of the trigger.
+	LabelTrigger = "trigger"
+)
+
+var (
+	firings = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "triggers",
+		Name:      "firings_total",
+		Help:      "Number of times that triggers fired, by the outcome of the firing.",
+	}, []string{"outcome"})
+
+	evalDuration = prometheus.NewSummary(prometheus.SummaryOpts{
+		Namespace: "workflows",
+		Subsystem: "triggers",
+		Name:      "eval_duration_seconds",
+		Help:      "Duration of a single evaluation of all triggers.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(firings, evalDuration)
+}
+
+// Config contains the user-configurable options of the trigger scheduler.
+type Config struct {
+	// Interval is the time between two evaluations of the triggers. It bounds how late a trigger fires. If 0 or less,
+	// DefaultInterval is used.
+	Interval time.Duration
+
+	// CatchUpInterval is the time between two scans for invocations that finished without the scheduler firing their
+	// completion triggers. It bounds how late a missed completion fires. If 0 or less, DefaultCatchUpInterval is used.
+	CatchUpInterval time.Duration
+}
+
+// Scheduler periodically evaluates the triggers, invoking their workflows when they are due.
+type Scheduler struct {
+	Config
+	backend       fes.Backend
+	triggers      *store.Triggers
+	workflows     *store.Workflows
+	invocations   *store.Invocations
+	triggerAPI    *api.Trigger
+	invocationAPI *api.Invocation
+	partition     ctrl.Partition
+	closeC        chan struct{}
+	closeOnce     sync.Once
+}
+
+func NewScheduler(backend fes.Backend, cfg Config, triggers *store.Triggers, workflows *store.Workflows,
+	invocations *store.Invocations, triggerAPI *api.Trigger, invocationAPI *api.Invocation,
+	partition ctrl.Partition) *Scheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.CatchUpInterval <= 0 {
+		cfg.CatchUpInterval = DefaultCatchUpInterval
+	}
+	return &Scheduler{
+		Config:        cfg,
+		backend:       backend,
+		triggers:      triggers,
+		workflows:     workflows,
+		invocations:   invocations,
+		triggerAPI:    triggerAPI,
+		invocationAPI: invocationAPI,
+		partition:     partition,
+		closeC:        make(chan struct{}),
+	}
+}
+
+// Run periodically evaluates the triggers, and fires the completion triggers of the invocations that finish, until
+// the scheduler is closed. On start, and every CatchUpInterval after, it catches up on missed completions.
+func (s *Scheduler) Run() error {
+	logrus.Infof("Running trigger scheduler every %v", s.Interval)
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	catchUpTicker := time.NewTicker(s.CatchUpInterval)
+	defer catchUpTicker.Stop()
+
+	// Without pubsub support, updates remains nil, which leaves the completion triggers to the catch up.
+	var updates chan pubsub.Msg
+	sub := s.invocations.GetInvocationUpdates()
+	if sub != nil {
+		defer sub.Close()
+		updates = sub.Ch
+	} else {
+		logrus.Warnf("Invocation store does not support pubsub; completion triggers fire up to %v late.",
+			s.CatchUpInterval)
+	}
+
+	if err := s.CatchUp(time.Now()); err != nil {
+		logrus.Errorf("Trigger scheduler failed to catch up on completions: %v", err)
+	}
+	for {
+		select {
+		case <-s.closeC:
+			return nil
+		case <-ticker.C:
+			if err := s.Evaluate(time.Now()); err != nil {
+				logrus.Errorf("Trigger scheduler failed: %v", err)
+			}
+		case <-catchUpTicker.C:
+			if err := s.CatchUp(time.Now()); err != nil {
+				logrus.Errorf("Trigger scheduler failed to catch up on completions: %v", err)
+			}
+		case msg := <-updates:
+			notification, err := sub.ToNotification(msg)
+			if err != nil {
+				logrus.Warnf("Failed to convert pubsub message to notification: %v", err)
+				continue
+			}
+			invocation, ok := notification.Updated.(*types.WorkflowInvocation)
+			if !ok || !finished(invocation) {
+				continue
+			}
+			// Only the notification of the event that finished the invocation fires the completion triggers.
+			if old, ok := notification.Old.(*types.WorkflowInvocation); ok && finished(old) {
+				continue
+			}
+			if err := s.Complete(invocation, time.Now()); err != nil {
+				logrus.Errorf("Trigger scheduler failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closeC)
+	})
+	return nil
+}
+
+// Evaluate performs a single pass over the triggers in the event store, firing the triggers that are due at now.
+// Failures to fire individual triggers are logged, and do not prevent the other triggers from being evaluated.
+func (s *Scheduler) Evaluate(now time.Time) error {
+	start := time.Now()
+	defer func() {
+		evalDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	return s.forEachTrigger(func(trigger *types.Trigger) error {
+		return s.evaluate(trigger, now)
+	})
+}
+
+// Complete fires the completion triggers that match the finished invocation, if the invocation is owned by the
+// partition of the scheduler. Triggers that have already fired or skipped for the invocation are not fired again.
+// Failures to fire individual triggers are logged, and do not prevent the other triggers from being fired.
+func (s *Scheduler) Complete(invocation *types.WorkflowInvocation, now time.Time) error {
+	if !s.owns(invocation.ID()) {
+		return nil
+	}
+	return s.forEachTrigger(func(trigger *types.Trigger) error {
+		return s.complete(trigger, invocation, now)
+	})
+}
+
+// CatchUp fires the completion triggers for the owned invocations that finished within CatchUpWindow before now,
+// which the triggers have not fired or skipped for yet. Only the invocations that finished after the trigger was
+// created are taken into account.
+// Failures to fire individual triggers are logged, and do not prevent the other triggers from being fired.
+func (s *Scheduler) CatchUp(now time.Time) error {
+	var triggers []*types.Trigger
+	err := s.forEachTrigger(func(trigger *types.Trigger) error {
+		if trigger.GetStatus().Active() && trigger.GetSpec().GetCompletion() != nil {
+			triggers = append(triggers, trigger)
+		}
+		return nil
+	})
+	if err != nil || len(triggers) == 0 {
+		return err
+	}
+
+	invocations, err := s.finishedInvocations(now.Add(-CatchUpWindow))
+	if err != nil {
+		return err
+	}
+	for _, trigger := range triggers {
+		for _, invocation := range invocations {
+			if !finishedAfter(invocation, trigger.GetMetadata().GetCreatedAt()) ||
+				!MatchesCompletion(trigger.GetSpec().GetCompletion(), invocation) ||
+				trigger.GetStatus().Completed(invocation.ID()) {
+				continue
+			}
+			// The trigger might have fired for one of the other invocations in the meantime, so load its latest
+			// generation to avoid firing conditionally on an outdated one.
+			current, err := s.loadTrigger(trigger.ID())
+			if err != nil {
+				logrus.WithField("trigger", trigger.ID()).Errorf("Failed to load trigger: %v", err)
+				continue
+			}
+			if err := s.complete(current, invocation, now); err != nil {
+				logrus.WithField("trigger", trigger.ID()).Errorf("Failed to fire trigger: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// complete fires the completion trigger for the finished invocation, if it matches the invocation and has not fired
+// or skipped for it yet. The firing is conditional on the generation of the trigger, which prevents concurrent
+// schedulers from firing the trigger twice for the same invocation. In case of a concurrent modification, the trigger
+// is reloaded and the firing retried, up to maxCompletionRetries times.
+func (s *Scheduler) complete(trigger *types.Trigger, invocation *types.WorkflowInvocation, now time.Time) error {
+	for attempt := 0; ; attempt++ {
+		completion := trigger.GetSpec().GetCompletion()
+		if !trigger.GetStatus().Active() || !MatchesCompletion(completion, invocation) ||
+			trigger.GetStatus().Completed(invocation.ID()) {
+			return nil
+		}
+		logrus.WithField("trigger", trigger.ID()).Infof("Invocation %s of workflow %s finished with status %v",
+			invocation.ID(), completion.GetWorkflowId(), invocation.GetStatus().GetStatus())
+
+		opts := []api.CallOption{
+			api.WithSourceInvocation(invocation.ID()),
+			api.WithExpectedGeneration(trigger.Generation()),
+		}
+		var err error
+		inputs, inputsErr := CompletionInputs(trigger.GetSpec(), invocation)
+		if inputsErr != nil {
+			err = s.skip(trigger, now, fmt.Errorf("failed to resolve inputs: %v", inputsErr), opts...)
+		} else {
+			err = s.fire(trigger, now, inputs, opts...)
+		}
+		if !fes.ErrConcurrentModification.Is(err) || attempt >= maxCompletionRetries {
+			return err
+		}
+		trigger, err = s.loadTrigger(trigger.ID())
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// finishedInvocations returns the owned invocations in the event store that finished after since.
+func (s *Scheduler) finishedInvocations(since time.Time) ([]*types.WorkflowInvocation, error) {
+	keys, err := s.backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeInvocation
+	})
+	if err != nil {
+		return nil, err
+	}
+	sinceTs, err := ptypes.TimestampProto(since)
+	if err != nil {
+		return nil, err
+	}
+	var invocations []*types.WorkflowInvocation
+	for _, key := range keys {
+		if !s.owns(key.Id) {
+			continue
+		}
+		invocation, err := s.invocations.GetInvocation(key.Id)
+		if err != nil || invocation == nil {
+			if err != nil {
+				logrus.Errorf("Failed to get invocation %s: %v", key.Id, err)
+			}
+			continue
+		}
+		if finished(invocation) && finishedAfter(invocation, sinceTs) {
+			invocations = append(invocations, invocation)
+		}
+	}
+	return invocations, nil
+}
+
+// loadTrigger projects the current state of the trigger directly from the event store, rather than from the trigger
+// store, which might not have observed the latest events yet.
+func (s *Scheduler) loadTrigger(triggerID string) (*types.Trigger, error) {
+	aggregate := projectors.NewTriggerAggregate(triggerID)
+	events, err := s.backend.Get(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fes.ErrEntityNotFound.WithAggregate(&aggregate)
+	}
+	projector := projectors.NewTrigger()
+	base, err := projector.NewProjection(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	entity, err := projector.Project(base, events...)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.Trigger), nil
+}
+
+// forEachTrigger applies fn to all triggers in the event store.
+func (s *Scheduler) forEachTrigger(fn func(trigger *types.Trigger) error) error {
+	keys, err := s.backend.List(func(a fes.Aggregate) bool {
+		return a.Type == types.TypeTrigger
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		trigger, err := s.triggers.GetTrigger(key.Id)
+		if err != nil {
+			logrus.Errorf("Failed to get trigger %s: %v", key.Id, err)
+			continue
+		}
+		if err := fn(trigger); err != nil {
+			logrus.WithField("trigger", trigger.ID()).Errorf("Failed to fire trigger: %v", err)
+		}
+	}
+	return nil
+}
+
+// evaluate fires the trigger if it is due at now. If multiple firings were missed, only the latest one is fired.
+func (s *Scheduler) evaluate(trigger *types.Trigger, now time.Time) error {
+	if !trigger.GetStatus().Active() || trigger.GetSpec().GetCompletion() != nil || !s.owns(trigger.ID()) {
+		return nil
+	}
+	scheduledAt, ok, err := Due(trigger, now)
+	if err != nil || !ok {
+		return err
+	}
+	// Fire conditionally, so that a firing is not recorded twice if the trigger was fired concurrently.
+	return s.fire(trigger, scheduledAt, trigger.GetSpec().GetInputs(),
+		api.WithExpectedGeneration(trigger.Generation()))
+}
+
+// fire starts an invocation of the workflow of the trigger with the inputs, taking into account the overlap policy of
+// the trigger, and records the firing in the trigger.
+func (s *Scheduler) fire(trigger *types.Trigger, scheduledAt time.Time, inputs map[string]*typedvalues.TypedValue,
+	opts ...api.CallOption) error {
+	log := logrus.WithField("trigger", trigger.ID()).WithField("scheduledAt", scheduledAt)
+
+	// Apply the overlap policy to the previous invocation, if it is still running.
+	if policy := trigger.GetSpec().GetOverlapPolicy(); policy != types.TriggerSpec_ALLOW {
+		previous, err := s.runningInvocation(trigger.GetStatus().GetLastInvocationId())
+		if err != nil {
+			return err
+		}
+		if previous != "" {
+			switch policy {
+			case types.TriggerSpec_SKIP:
+				log.Infof("Skipping firing, because previous invocation %s is still running", previous)
+				return s.skip(trigger, scheduledAt, fmt.Errorf("previous invocation %s is still running",
+					previous), opts...)
+			case types.TriggerSpec_REPLACE:
+				log.Infof("Canceling previous invocation %s", previous)
+				if err := s.invocationAPI.Cancel(previous); err != nil && !fes.ErrConcurrentModification.Is(err) {
+					return err
+				}
+			}
+		}
+	}
+
+	workflowID := trigger.GetSpec().GetWorkflowId()
+	wf, err := s.workflows.GetWorkflow(workflowID)
+	if err == nil && wf == nil {
+		err = fes.ErrEntityNotFound
+	}
+	if err != nil {
+		log.Warnf("Skipping firing, because workflow %s is not available: %v", workflowID, err)
+		return s.skip(trigger, scheduledAt, fmt.Errorf("workflow %s is not available: %v", workflowID, err),
+			opts...)
+	}
+
+	spec := &types.WorkflowInvocationSpec{
+		WorkflowId: workflowID,
+		Workflow:   wf,
+		Inputs:     map[string]*typedvalues.TypedValue{},
+		Labels: map[string]string{
+			LabelTrigger: trigger.ID(),
+		},
+	}
+	for k, v := range inputs {
+		spec.Inputs[k] = v
+	}
+	invocationID, err := s.invocationAPI.Invoke(spec)
+	if err != nil {
+		log.Warnf("Skipping firing, because the invocation could not be started: %v", err)
+		return s.skip(trigger, scheduledAt, err, opts...)
+	}
+
+	err = s.triggerAPI.Fire(trigger.ID(), scheduledAt, invocationID, opts...)
+	if err != nil {
+		// The trigger was modified in the meantime, for example because it was fired or deleted concurrently.
+		// Cancel the invocation to avoid starting the workflow twice for the same firing.
+		log.Warnf("Canceling invocation %s, because the firing could not be recorded: %v", invocationID, err)
+		if cancelErr := s.invocationAPI.Cancel(invocationID); cancelErr != nil {
+			log.Errorf("Failed to cancel invocation %s: %v", invocationID, cancelErr)
+		}
+		firings.WithLabelValues("conflict").Inc()
+		return err
+	}
+	log.Infof("Fired trigger: started invocation %s", invocationID)
+	firings.WithLabelValues("fired").Inc()
+	return nil
+}
+
+// owns checks if the scheduler is responsible for the entity with the given key. Without a partition, the scheduler is
+// responsible for all entities.
+func (s *Scheduler) owns(key string) bool {
+	return s.partition == nil || s.partition.Owns(key)
+}
+
+// runningInvocation returns the id of the invocation if it has not finished yet, or an empty string otherwise.
+func (s *Scheduler) runningInvocation(invocationID string) (string, error) {
+	if invocationID == "" {
+		return "", nil
+	}
+	invocation, err := s.invocations.GetInvocation(invocationID)
+	if err != nil {
+		if fes.ErrEntityNotFound.Is(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if invocation == nil || invocation.GetStatus().GetStatus() == types.WorkflowInvocationStatus_UNKNOWN ||
+		invocation.GetStatus().Finished() {
+		return "", nil
+	}
+	return invocationID, nil
+}
+
+func (s *Scheduler) skip(trigger *types.Trigger, scheduledAt time.Time, reason error, opts ...api.CallOption) error {
+	firings.WithLabelValues("skipped").Inc()
+	return s.triggerAPI.Skip(trigger.ID(), scheduledAt, reason, opts...)
+}
+
+// Due determines whether the trigger should fire at now. If so, it returns the scheduled time of the firing, which is
+// the latest time in the schedule of the trigger since the previous firing (or the creation of the trigger) that is
+// not after now.
+func Due(trigger *types.Trigger, now time.Time) (time.Time, bool, error) {
+	schedule, err := Schedule(trigger.GetSpec())
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	last := trigger.GetMetadata().GetCreatedAt()
+	if trigger.GetStatus().GetLastScheduledAt() != nil {
+		last = trigger.GetStatus().GetLastScheduledAt()
+	}
+	lastTime, err := ptypes.Timestamp(last)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	now = now.UTC()
+	next := schedule.Next(lastTime.UTC())
+	if next.IsZero() || next.After(now) {
+		return time.Time{}, false, nil
+	}
+	for {
+		following := schedule.Next(next)
+		if following.IsZero() || following.After(now) {
+			return next, true, nil
+		}
+		next = following
+	}
+}
+
+// Schedule returns the schedule of the trigger, which is based on either the cron expression or the interval.
+func Schedule(spec *types.TriggerSpec) (cron.Schedule, error) {
+	if spec.GetCron() != "" {
+		return cron.ParseStandard(spec.GetCron())
+	}
+	if spec.GetInterval() != nil {
+		interval, err := ptypes.Duration(spec.GetInterval())
+		if err != nil {
+			return nil, err
+		}
+		return cron.Every(interval), nil
+	}
+	return nil, errors.New("trigger has no schedule")
+}
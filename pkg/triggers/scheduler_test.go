@@ -0,0 +1,333 @@
+package triggers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// projectingReader projects the entities directly from the backend, so that the tests do not depend on the
+// asynchronous updates of a cache.
+type projectingReader struct {
+	backend   fes.Backend
+	projector fes.Projector
+}
+
+func (r *projectingReader) List() []fes.Aggregate {
+	keys, _ := r.backend.List(func(a fes.Aggregate) bool { return true })
+	return keys
+}
+
+func (r *projectingReader) GetAggregate(key fes.Aggregate) (fes.Entity, error) {
+	events, err := r.backend.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fes.ErrEntityNotFound.WithAggregate(&key)
+	}
+	base, err := r.projector.NewProjection(key)
+	if err != nil {
+		return nil, err
+	}
+	return r.projector.Project(base, events...)
+}
+
+type testSetup struct {
+	scheduler     *Scheduler
+	triggerAPI    *api.Trigger
+	invocationAPI *api.Invocation
+	triggers      *store.Triggers
+	invocations   *store.Invocations
+	workflowID    string
+}
+
+func setup(t *testing.T) *testSetup {
+	backend := mem.NewBackend()
+	workflowID, err := api.NewWorkflowAPI(backend, nil).Create(&types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "a",
+		Tasks: map[string]*types.TaskSpec{
+			"a": {FunctionRef: "noop"},
+		},
+	})
+	assert.NoError(t, err)
+
+	triggerAPI := api.NewTriggerAPI(backend)
+	triggers := store.NewTriggerStore(&projectingReader{backend, projectors.NewTrigger()})
+	workflows := store.NewWorkflowsStore(&projectingReader{backend, projectors.NewWorkflow()})
+	invocations := store.NewInvocationStore(&projectingReader{backend, projectors.NewWorkflowInvocation()})
+	invocationAPI := api.NewInvocationAPI(backend)
+	return &testSetup{
+		scheduler: NewScheduler(backend, Config{}, triggers, workflows, invocations, triggerAPI,
+			invocationAPI, nil),
+		triggerAPI:    triggerAPI,
+		invocationAPI: invocationAPI,
+		triggers:      triggers,
+		invocations:   invocations,
+		workflowID:    workflowID,
+	}
+}
+
+func (ts *testSetup) createTrigger(t *testing.T, spec *types.TriggerSpec) (*types.Trigger, time.Time) {
+	triggerID, err := ts.triggerAPI.Create(spec)
+	assert.NoError(t, err)
+	trigger, err := ts.triggers.GetTrigger(triggerID)
+	assert.NoError(t, err)
+	createdAt, err := ptypes.Timestamp(trigger.GetMetadata().GetCreatedAt())
+	assert.NoError(t, err)
+	return trigger, createdAt
+}
+
+func (ts *testSetup) getTrigger(t *testing.T, triggerID string) *types.Trigger {
+	trigger, err := ts.triggers.GetTrigger(triggerID)
+	assert.NoError(t, err)
+	return trigger
+}
+
+func TestDue(t *testing.T) {
+	createdAt := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	trigger := &types.Trigger{
+		Metadata: &types.ObjectMetadata{CreatedAt: util.MustTimestampProto(createdAt)},
+		Spec:     &types.TriggerSpec{Interval: ptypes.DurationProto(time.Minute)},
+		Status:   &types.TriggerStatus{},
+	}
+
+	_, ok, err := Due(trigger, createdAt.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Only the latest of the missed firings is due.
+	scheduledAt, ok, err := Due(trigger, createdAt.Add(150*time.Second))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, createdAt.Add(2*time.Minute), scheduledAt)
+
+	// The schedule continues from the last firing.
+	trigger.Status.LastScheduledAt = util.MustTimestampProto(scheduledAt)
+	_, ok, err = Due(trigger, createdAt.Add(150*time.Second))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	trigger.Spec = &types.TriggerSpec{Cron: "0 * * * *"}
+	scheduledAt, ok, err = Due(trigger, createdAt.Add(3*time.Hour+time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, createdAt.Add(3*time.Hour), scheduledAt)
+}
+
+func TestScheduler_Evaluate(t *testing.T) {
+	ts := setup(t)
+	trigger, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Interval:   ptypes.DurationProto(time.Minute),
+		Inputs: map[string]*typedvalues.TypedValue{
+			types.InputMain: typedvalues.MustWrap("foo"),
+		},
+	})
+
+	err := ts.scheduler.Evaluate(createdAt.Add(30 * time.Second))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	err = ts.scheduler.Evaluate(createdAt.Add(90 * time.Second))
+	assert.NoError(t, err)
+	updated := ts.getTrigger(t, trigger.ID())
+	assert.EqualValues(t, 1, updated.GetStatus().GetFired())
+	invocation, err := ts.invocations.GetInvocation(updated.GetStatus().GetLastInvocationId())
+	assert.NoError(t, err)
+	assert.Equal(t, ts.workflowID, invocation.GetSpec().GetWorkflowId())
+	assert.Equal(t, "foo", typedvalues.MustUnwrap(invocation.GetSpec().GetInputs()[types.InputMain]))
+
+	// The same firing is not fired twice.
+	err = ts.scheduler.Evaluate(createdAt.Add(90 * time.Second))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	// A deleted trigger no longer fires.
+	err = ts.triggerAPI.Delete(trigger.ID())
+	assert.NoError(t, err)
+	err = ts.scheduler.Evaluate(createdAt.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+}
+
+func TestScheduler_EvaluateOverlapSkip(t *testing.T) {
+	ts := setup(t)
+	trigger, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId:    ts.workflowID,
+		Interval:      ptypes.DurationProto(time.Minute),
+		OverlapPolicy: types.TriggerSpec_SKIP,
+	})
+
+	err := ts.scheduler.Evaluate(createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	err = ts.scheduler.Evaluate(createdAt.Add(2 * time.Minute))
+	assert.NoError(t, err)
+	updated := ts.getTrigger(t, trigger.ID())
+	assert.EqualValues(t, 1, updated.GetStatus().GetFired())
+	assert.EqualValues(t, 1, updated.GetStatus().GetSkipped())
+	assert.NotNil(t, updated.GetStatus().GetError())
+}
+
+func TestScheduler_EvaluateOverlapReplace(t *testing.T) {
+	ts := setup(t)
+	trigger, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId:    ts.workflowID,
+		Interval:      ptypes.DurationProto(time.Minute),
+		OverlapPolicy: types.TriggerSpec_REPLACE,
+	})
+
+	err := ts.scheduler.Evaluate(createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	first := ts.getTrigger(t, trigger.ID()).GetStatus().GetLastInvocationId()
+	err = ts.scheduler.Evaluate(createdAt.Add(2 * time.Minute))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	invocation, err := ts.invocations.GetInvocation(first)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_ABORTED, invocation.GetStatus().GetStatus())
+}
+
+func TestScheduler_EvaluateMissingWorkflow(t *testing.T) {
+	ts := setup(t)
+	trigger, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: "wf-missing",
+		Interval:   ptypes.DurationProto(time.Minute),
+	})
+
+	err := ts.scheduler.Evaluate(createdAt.Add(time.Minute))
+	assert.NoError(t, err)
+	updated := ts.getTrigger(t, trigger.ID())
+	assert.EqualValues(t, 0, updated.GetStatus().GetFired())
+	assert.EqualValues(t, 1, updated.GetStatus().GetSkipped())
+	assert.Contains(t, updated.GetStatus().GetError().GetMessage(), "wf-missing")
+}
+
+func TestScheduler_Complete(t *testing.T) {
+	ts := setup(t)
+	trigger, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Completion: &types.CompletionTrigger{WorkflowId: "wf-1"},
+	})
+
+	// Completion triggers do not fire on a schedule.
+	err := ts.scheduler.Evaluate(createdAt.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	err = ts.scheduler.Complete(finishedInvocation(types.WorkflowInvocationStatus_FAILED), createdAt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	err = ts.scheduler.Complete(finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED), createdAt)
+	assert.NoError(t, err)
+	updated := ts.getTrigger(t, trigger.ID())
+	assert.EqualValues(t, 1, updated.GetStatus().GetFired())
+	invocation, err := ts.invocations.GetInvocation(updated.GetStatus().GetLastInvocationId())
+	assert.NoError(t, err)
+	assert.Equal(t, ts.workflowID, invocation.GetSpec().GetWorkflowId())
+	assert.Equal(t, trigger.ID(), invocation.GetSpec().GetLabels()[LabelTrigger])
+	assert.Equal(t, "output", typedvalues.MustUnwrap(invocation.GetSpec().GetInputs()[types.InputMain]))
+	assert.True(t, updated.GetStatus().Completed("wi-1"))
+
+	// An invocation fires the trigger only once.
+	err = ts.scheduler.Complete(finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED), createdAt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	ts := setup(t)
+	// An invocation that finished before the trigger was created does not fire it.
+	before := ts.finishInvocation(t)
+	trigger, _ := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Completion: &types.CompletionTrigger{WorkflowId: ts.workflowID},
+	})
+	missed := ts.finishInvocation(t)
+
+	err := ts.scheduler.CatchUp(time.Now())
+	assert.NoError(t, err)
+	updated := ts.getTrigger(t, trigger.ID())
+	assert.EqualValues(t, 1, updated.GetStatus().GetFired())
+	assert.True(t, updated.GetStatus().Completed(missed))
+	assert.False(t, updated.GetStatus().Completed(before))
+
+	// Neither a later catch up nor a late notification fire the trigger again.
+	err = ts.scheduler.CatchUp(time.Now())
+	assert.NoError(t, err)
+	invocation, err := ts.invocations.GetInvocation(missed)
+	assert.NoError(t, err)
+	err = ts.scheduler.Complete(invocation, time.Now())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, trigger.ID()).GetStatus().GetFired())
+
+	// Invocations that finished longer than the catch up window ago are not caught up.
+	another := ts.finishInvocation(t)
+	err = ts.scheduler.CatchUp(time.Now().Add(CatchUpWindow + time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, ts.getTrigger(t, trigger.ID()).GetStatus().Completed(another))
+}
+
+// finishInvocation invokes the workflow and completes the invocation, without notifying the scheduler.
+func (ts *testSetup) finishInvocation(t *testing.T) string {
+	invocationID, err := ts.invocationAPI.Invoke(&types.WorkflowInvocationSpec{WorkflowId: ts.workflowID})
+	assert.NoError(t, err)
+	err = ts.invocationAPI.Start(invocationID)
+	assert.NoError(t, err)
+	err = ts.invocationAPI.Complete(invocationID, typedvalues.MustWrap("output"), nil)
+	assert.NoError(t, err)
+	return invocationID
+}
+
+// keyPartition owns only the listed keys.
+type keyPartition map[string]bool
+
+func (p keyPartition) Owns(key string) bool {
+	return p[key]
+}
+
+func TestScheduler_EvaluatePartition(t *testing.T) {
+	ts := setup(t)
+	owned, createdAt := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Interval:   ptypes.DurationProto(time.Minute),
+	})
+	other, _ := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Interval:   ptypes.DurationProto(time.Minute),
+	})
+	ts.scheduler.partition = keyPartition{owned.ID(): true}
+
+	// Only the trigger owned by the partition of the scheduler fires.
+	err := ts.scheduler.Evaluate(createdAt.Add(2 * time.Minute))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, owned.ID()).GetStatus().GetFired())
+	assert.EqualValues(t, 0, ts.getTrigger(t, other.ID()).GetStatus().GetFired())
+
+	// Completion triggers are fired by the scheduler that owns the finished invocation.
+	completion, _ := ts.createTrigger(t, &types.TriggerSpec{
+		WorkflowId: ts.workflowID,
+		Completion: &types.CompletionTrigger{WorkflowId: "wf-1"},
+	})
+	err = ts.scheduler.Complete(finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED), createdAt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, ts.getTrigger(t, completion.ID()).GetStatus().GetFired())
+
+	ts.scheduler.partition = keyPartition{"wi-1": true}
+	err = ts.scheduler.Complete(finishedInvocation(types.WorkflowInvocationStatus_SUCCEEDED), createdAt)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, ts.getTrigger(t, completion.ID()).GetStatus().GetFired())
+}
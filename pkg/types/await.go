@@ -0,0 +1,47 @@
+package types
+
+// AwaitsQuorum checks if the task is started once some, rather than all, of its dependencies have succeeded.
+func (m *TaskSpec) AwaitsQuorum() bool {
+	return m.GetAwait() > 0 && int(m.GetAwait()) < len(m.GetRequires())
+}
+
+// AwaitReached checks if enough of the dependencies of the task have succeeded for the task to be started, in case the
+// task awaits only some of its dependencies. The dependencies of which the dynamic task has not succeeded yet do not
+// count towards the quorum.
+func (m *WorkflowInvocation) AwaitReached(task *Task) bool {
+	if !task.GetSpec().AwaitsQuorum() {
+		return false
+	}
+	var succeeded int32
+	for depID := range task.GetSpec().GetRequires() {
+		if m.dependencySucceeded(depID) {
+			succeeded++
+		}
+	}
+	return succeeded >= task.GetSpec().GetAwait()
+}
+
+// PendingDependencies returns the ids of the dependencies of the task that have not finished yet.
+func (m *WorkflowInvocation) PendingDependencies(task *Task) []string {
+	var pending []string
+	for depID := range task.GetSpec().GetRequires() {
+		if _, ok := m.Task(depID); !ok {
+			continue
+		}
+		if taskRun, ok := m.TaskInvocation(depID); !ok || !taskRun.GetStatus().Finished() {
+			pending = append(pending, depID)
+		}
+	}
+	return pending
+}
+
+func (m *WorkflowInvocation) dependencySucceeded(taskID string) bool {
+	taskRun, ok := m.TaskInvocation(taskID)
+	if !ok || !taskRun.GetStatus().Successful() {
+		return false
+	}
+	if child, ok := m.DynamicChild(taskID); ok {
+		return m.dependencySucceeded(child.ID())
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowInvocation_AwaitReached(t *testing.T) {
+	wf := NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("b", &TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("c", &TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("all", &TaskSpec{FunctionRef: "noop", Requires: Require("a", "b", "c")})
+	wf.Spec.AddTask("two", &TaskSpec{FunctionRef: "noop", Requires: Require("a", "b", "c"), Await: 2})
+	invocation := NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	all, _ := invocation.Task("all")
+	two, _ := invocation.Task("two")
+
+	invocation.Status.Tasks = map[string]*TaskInvocation{
+		"a": {Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}},
+		"b": {Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}},
+	}
+	assert.True(t, invocation.AwaitReached(two))
+	assert.ElementsMatch(t, []string{"c"}, invocation.PendingDependencies(two))
+
+	// A task that awaits all of its dependencies never reaches its quorum early.
+	assert.False(t, all.GetSpec().AwaitsQuorum())
+	assert.False(t, invocation.AwaitReached(all))
+
+	// A failed dependency does not count towards the quorum.
+	invocation.Status.Tasks["b"].Status.Status = TaskInvocationStatus_FAILED
+	assert.False(t, invocation.AwaitReached(two))
+
+	// Nor does a dependency that succeeded, but of which the dynamic task has not succeeded yet.
+	invocation.Status.Tasks["b"].Status.Status = TaskInvocationStatus_SUCCEEDED
+	child := NewTask("b_child", "noop")
+	child.Spec.Require("b", &TaskDependencyParameters{Type: TaskDependencyParameters_DYNAMIC_OUTPUT})
+	invocation.Status.DynamicTasks = map[string]*Task{child.ID(): child}
+	assert.False(t, invocation.AwaitReached(two))
+	invocation.Status.Tasks[child.ID()] = &TaskInvocation{
+		Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED},
+	}
+	assert.True(t, invocation.AwaitReached(two))
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+// DefaultCompensationTimeout is the maximum runtime of a compensation that does not specify a timeout. Compensations
+// run after the invocation has failed, so they are not bound by the deadline of the invocation.
+const DefaultCompensationTimeout = time.Minute
+
+// Compensable checks if the task should be compensated once the invocation fails, which is the case if the task has
+// a compensation and the task invocation with the given status has succeeded.
+func (m *Task) Compensable(status *TaskInvocationStatus) bool {
+	return m.GetSpec().GetCompensate() != nil && status.GetStatus() == TaskInvocationStatus_SUCCEEDED
+}
+
+// CompensableTasks returns the tasks of the invocation that should be compensated once the invocation fails.
+func (m *WorkflowInvocation) CompensableTasks() map[string]*Task {
+	tasks := map[string]*Task{}
+	for id, task := range m.Tasks() {
+		if taskRun, ok := m.TaskInvocation(id); ok && task.Compensable(taskRun.GetStatus()) {
+			tasks[id] = task
+		}
+	}
+	return tasks
+}
+
+// CompensationPending checks if the invocation has failed and the compensation of its tasks has not finished yet.
+func (m *WorkflowInvocation) CompensationPending() bool {
+	if m.GetStatus().GetStatus() != WorkflowInvocationStatus_FAILED {
+		return false
+	}
+	switch m.GetStatus().GetCompensation().GetStatus() {
+	case CompensationStatus_UNKNOWN:
+		return len(m.CompensableTasks()) > 0
+	case CompensationStatus_IN_PROGRESS:
+		return true
+	default:
+		return false
+	}
+}
+
+// Next returns the ID of the next task to be compensated. If all tasks have been compensated, or one of the
+// compensations has failed, it returns false.
+func (m *CompensationStatus) Next() (string, bool) {
+	if _, _, failed := m.Failed(); failed {
+		return "", false
+	}
+	for _, taskID := range m.GetOrder() {
+		if _, ok := m.GetTasks()[taskID]; !ok {
+			return taskID, true
+		}
+	}
+	return "", false
+}
+
+// Failed returns the task of which the compensation failed, if any.
+func (m *CompensationStatus) Failed() (taskID string, status *TaskInvocationStatus, failed bool) {
+	for _, taskID := range m.GetOrder() {
+		status, ok := m.GetTasks()[taskID]
+		if ok && status.GetStatus() != TaskInvocationStatus_SUCCEEDED {
+			return taskID, status, true
+		}
+	}
+	return "", nil, false
+}
+
+// Finished checks if the compensation has reached a terminal state.
+func (m *CompensationStatus) Finished() bool {
+	switch m.GetStatus() {
+	case CompensationStatus_SUCCEEDED, CompensationStatus_FAILED:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewCompensationInvocationSpec creates the task invocation spec to run the compensation of the task. The spec keeps
+// the ID of the compensated task, and contains the compensation as its task.
+func NewCompensationInvocationSpec(invocation *WorkflowInvocation, task *Task, startAt time.Time) *TaskInvocationSpec {
+	compensate := task.GetSpec().GetCompensate()
+	timeout := DefaultCompensationTimeout
+	if d, err := ptypes.Duration(compensate.GetTimeout()); err == nil && d > 0 {
+		timeout = d
+	}
+	deadline, _ := ptypes.TimestampProto(startAt.Add(timeout))
+
+	return &TaskInvocationSpec{
+		InvocationId: invocation.ID(),
+		Task: &Task{
+			Metadata: task.GetMetadata(),
+			Spec:     compensate,
+			Status: &TaskStatus{
+				Status: TaskStatus_READY,
+				FnRef:  task.GetStatus().GetCompensateFnRef(),
+			},
+		},
+		FnRef:    task.GetStatus().GetCompensateFnRef(),
+		TaskId:   task.ID(),
+		Deadline: deadline,
+		Inputs:   compensate.GetInputs(),
+	}
+}
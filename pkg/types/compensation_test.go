@@ -0,0 +1,89 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCompensableInvocation() *WorkflowInvocation {
+	wf := NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &TaskSpec{FunctionRef: "noop", Compensate: NewTaskSpec("undo")})
+	wf.Spec.AddTask("b", &TaskSpec{FunctionRef: "noop", Requires: Require("a")})
+	wf.Status.AddTask("a", &Task{
+		Metadata: NewObjectMetadata("a"),
+		Spec:     wf.Spec.Tasks["a"],
+		Status:   &TaskStatus{CompensateFnRef: &FnRef{Runtime: "internal", ID: "undo"}},
+	})
+	invocation := NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	invocation.Status.Status = WorkflowInvocationStatus_FAILED
+	invocation.Status.Tasks = map[string]*TaskInvocation{
+		"a": {Metadata: NewObjectMetadata("a"), Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}},
+		"b": {Metadata: NewObjectMetadata("b"), Status: &TaskInvocationStatus{Status: TaskInvocationStatus_FAILED}},
+	}
+	return invocation
+}
+
+func TestWorkflowInvocation_CompensationPending(t *testing.T) {
+	invocation := setupCompensableInvocation()
+	assert.Contains(t, invocation.CompensableTasks(), "a")
+	assert.Len(t, invocation.CompensableTasks(), 1)
+	assert.True(t, invocation.CompensationPending())
+
+	invocation.Status.Compensation = &CompensationStatus{Status: CompensationStatus_IN_PROGRESS}
+	assert.True(t, invocation.CompensationPending())
+	invocation.Status.Compensation = &CompensationStatus{Status: CompensationStatus_SUCCEEDED}
+	assert.False(t, invocation.CompensationPending())
+
+	// Only failed invocations are compensated.
+	invocation = setupCompensableInvocation()
+	invocation.Status.Status = WorkflowInvocationStatus_ABORTED
+	assert.False(t, invocation.CompensationPending())
+
+	// Tasks that did not succeed do not need to be compensated.
+	invocation = setupCompensableInvocation()
+	invocation.Status.Tasks["a"].Status.Status = TaskInvocationStatus_FAILED
+	assert.Empty(t, invocation.CompensableTasks())
+	assert.False(t, invocation.CompensationPending())
+}
+
+func TestCompensationStatus_Next(t *testing.T) {
+	compensation := &CompensationStatus{
+		Status: CompensationStatus_IN_PROGRESS,
+		Order:  []string{"b", "a"},
+		Tasks:  map[string]*TaskInvocationStatus{},
+	}
+	next, ok := compensation.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "b", next)
+
+	compensation.Tasks["b"] = &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}
+	next, ok = compensation.Next()
+	assert.True(t, ok)
+	assert.Equal(t, "a", next)
+
+	// The compensation stops at the first compensation that failed.
+	compensation.Tasks["b"] = &TaskInvocationStatus{Status: TaskInvocationStatus_FAILED}
+	_, ok = compensation.Next()
+	assert.False(t, ok)
+	failedTaskID, _, failed := compensation.Failed()
+	assert.True(t, failed)
+	assert.Equal(t, "b", failedTaskID)
+}
+
+func TestNewCompensationInvocationSpec(t *testing.T) {
+	invocation := setupCompensableInvocation()
+	task, ok := invocation.Task("a")
+	assert.True(t, ok)
+
+	// The compensation is not bound by the deadline of the failed invocation.
+	invocation.Spec.Deadline = nil
+	now := time.Now()
+	spec := NewCompensationInvocationSpec(invocation, task, now)
+	assert.Equal(t, "a", spec.GetTaskId())
+	assert.Equal(t, "undo", spec.GetTask().GetSpec().GetFunctionRef())
+	assert.Equal(t, "undo", spec.GetFnRef().GetID())
+	assert.Equal(t, now.Add(DefaultCompensationTimeout).Unix(), spec.GetDeadline().GetSeconds())
+}
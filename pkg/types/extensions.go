@@ -20,6 +20,8 @@ const (
 	TypeWorkflow   = "workflow"
 	TypeInvocation = "invocation"
 	TypeTaskRun    = "taskrun"
+	TypeTrigger    = "trigger"
+	TypeReplica    = "replica"
 )
 
 // InvocationEvent
@@ -60,6 +62,11 @@ func (m *WorkflowInvocation) Type() string {
 	return TypeInvocation
 }
 
+// Generation returns the number of events in the event stream of the invocation that have been applied to it.
+func (m *WorkflowInvocation) Generation() int64 {
+	return m.GetMetadata().GetGeneration()
+}
+
 func (m *WorkflowInvocation) Workflow() *Workflow {
 	return m.GetSpec().GetWorkflow()
 }
@@ -119,6 +126,7 @@ func (m *WorkflowInvocationStatus) ToTaskStatus() *TaskInvocationStatus {
 		WorkflowInvocationStatus_SUCCEEDED:   TaskInvocationStatus_SUCCEEDED,
 		WorkflowInvocationStatus_FAILED:      TaskInvocationStatus_FAILED,
 		WorkflowInvocationStatus_ABORTED:     TaskInvocationStatus_ABORTED,
+		WorkflowInvocationStatus_PAUSED:      TaskInvocationStatus_IN_PROGRESS,
 	}
 
 	return &TaskInvocationStatus{
@@ -144,6 +152,16 @@ func (m WorkflowInvocationStatus) Successful() bool {
 	return m.GetStatus() == WorkflowInvocationStatus_SUCCEEDED
 }
 
+// Scheduled returns true if the invocation is waiting to be started.
+func (m WorkflowInvocationStatus) Scheduled() bool {
+	return m.GetStatus() == WorkflowInvocationStatus_SCHEDULED
+}
+
+// Paused returns true if the invocation has been paused. A paused invocation has not finished.
+func (m WorkflowInvocationStatus) Paused() bool {
+	return m.GetStatus() == WorkflowInvocationStatus_PAUSED
+}
+
 //
 // TaskInvocation
 //
@@ -181,6 +199,16 @@ func (ti TaskInvocationStatus) Successful() bool {
 	return ti.GetStatus() == TaskInvocationStatus_SUCCEEDED
 }
 
+// Skipped returns true if the task was not run, because its condition did not hold or its input was skipped.
+func (ti TaskInvocationStatus) Skipped() bool {
+	return ti.GetStatus() == TaskInvocationStatus_SKIPPED
+}
+
+// Aborted returns true if the task was canceled, because its result was no longer needed.
+func (ti TaskInvocationStatus) Aborted() bool {
+	return ti.GetStatus() == TaskInvocationStatus_ABORTED
+}
+
 //
 // Task
 //
@@ -251,6 +279,11 @@ func (m *Workflow) Type() string {
 	return TypeWorkflow
 }
 
+// Generation returns the number of events in the event stream of the workflow that have been applied to it.
+func (m *Workflow) Generation() int64 {
+	return m.GetMetadata().GetGeneration()
+}
+
 // Note: this only retrieves the statically, top-level defined tasks
 // TODO just store entire task in status
 func (m *Workflow) Task(id string) (*Task, bool) {
@@ -2,14 +2,17 @@ package graph
 
 import (
 	"hash/fnv"
+	"sort"
 
 	"github.com/fission/fission-workflows/pkg/types"
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
 )
 
 type LinkedNode interface {
 	graph.Node
+	TaskID() string
 	Links() []int64
 }
 
@@ -21,6 +24,10 @@ func (n *TaskInvocationNode) ID() int64 {
 	return createID(n.Task().ID())
 }
 
+func (n *TaskInvocationNode) TaskID() string {
+	return n.Task().ID()
+}
+
 func (n *TaskInvocationNode) Links() []int64 {
 	var links []int64
 
@@ -47,6 +54,10 @@ func (n *TaskSpecNode) ID() int64 {
 	return createID(n.id)
 }
 
+func (n *TaskSpecNode) TaskID() string {
+	return n.id
+}
+
 type Iterator interface {
 	Get(ptr int) LinkedNode
 }
@@ -153,6 +164,24 @@ func Roots(g graph.Directed) []graph.Node {
 	return roots
 }
 
+// ReverseTopologicalSort returns the IDs of the tasks in the graph in reverse dependency order, such that each task
+// is ordered before the tasks that it depends on. Independent tasks are ordered by their ID to keep the order stable.
+func ReverseTopologicalSort(g graph.Directed) ([]string, error) {
+	sorted, err := topo.SortStabilized(g, func(nodes []graph.Node) {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].(LinkedNode).TaskID() < nodes[j].(LinkedNode).TaskID()
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(sorted))
+	for i, n := range sorted {
+		ids[len(sorted)-1-i] = n.(LinkedNode).TaskID()
+	}
+	return ids, nil
+}
+
 func createID(s string) int64 {
 	h := fnv.New64a()
 	h.Write([]byte(s))
@@ -1,5 +1,12 @@
 package graph
 
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
 //func TestParse(t *testing.T) {
 //	it := NewTaskInstanceIterator(map[string]*types.TaskInvocation{
 //		"a": {
@@ -104,3 +111,33 @@ package graph
 //}
 
 // TODO FIX
+
+func TestReverseTopologicalSort(t *testing.T) {
+	tasks := map[string]*types.TaskSpec{
+		"a": {},
+		"b": {Requires: types.Require("a")},
+		"c": {Requires: types.Require("a")},
+		"d": {Requires: types.Require("b", "c")},
+		"e": {},
+	}
+	order, err := ReverseTopologicalSort(Parse(NewTaskSpecIterator(tasks)))
+	assert.NoError(t, err)
+	assert.Len(t, order, 5)
+
+	// Every task should be ordered before the tasks that it depends on.
+	pos := map[string]int{}
+	for i, id := range order {
+		pos[id] = i
+	}
+	assert.True(t, pos["d"] < pos["b"])
+	assert.True(t, pos["d"] < pos["c"])
+	assert.True(t, pos["b"] < pos["a"])
+	assert.True(t, pos["c"] < pos["a"])
+
+	// The order should not depend on the order in which the graph was built.
+	for i := 0; i < 10; i++ {
+		next, err := ReverseTopologicalSort(Parse(NewTaskSpecIterator(tasks)))
+		assert.NoError(t, err)
+		assert.Equal(t, order, next)
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+// HasFallback checks if the error handler runs a fallback in place of the failed task.
+func (m *ErrorHandler) HasFallback() bool {
+	return m.GetFallbackTask() != nil || m.GetFallbackWorkflow() != nil
+}
+
+// FailureHandled checks if the failure of a task invocation with the given status is handled by the error handler of
+// the task, instead of failing the workflow invocation. A failure that will still be retried is not handled (yet).
+func (m *TaskSpec) FailureHandled(status *TaskInvocationStatus) bool {
+	if status.GetStatus() != TaskInvocationStatus_FAILED || m.GetRetry().Retryable(status) {
+		return false
+	}
+	return m.GetOnError().GetContinue() || m.GetOnError().HasFallback()
+}
+
+// DynamicChild returns the dynamic task that was added to the invocation as the child of the task, such as a dynamic
+// task returned in the output of the task, or the fallback of the task.
+func (m *WorkflowInvocation) DynamicChild(taskID string) (*Task, bool) {
+	for _, task := range m.GetStatus().GetDynamicTasks() {
+		dep, ok := task.GetSpec().GetRequires()[taskID]
+		if ok && dep.GetType() == TaskDependencyParameters_DYNAMIC_OUTPUT {
+			return task, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskSpec_FailureHandled(t *testing.T) {
+	failed := &TaskInvocationStatus{Status: TaskInvocationStatus_FAILED}
+
+	assert.False(t, (&TaskSpec{}).FailureHandled(failed))
+	assert.True(t, (&TaskSpec{OnError: &ErrorHandler{Continue: true}}).FailureHandled(failed))
+	assert.True(t, (&TaskSpec{OnError: &ErrorHandler{FallbackTask: NewTaskSpec("noop")}}).FailureHandled(failed))
+	assert.False(t, (&TaskSpec{OnError: &ErrorHandler{Continue: true}}).FailureHandled(&TaskInvocationStatus{
+		Status: TaskInvocationStatus_SUCCEEDED,
+	}))
+
+	// The failure is only handled once the task will not be retried anymore.
+	spec := &TaskSpec{
+		Retry:   &RetryPolicy{MaxAttempts: 2},
+		OnError: &ErrorHandler{Continue: true},
+	}
+	assert.False(t, spec.FailureHandled(failed))
+	assert.True(t, spec.FailureHandled(&TaskInvocationStatus{
+		Status:   TaskInvocationStatus_FAILED,
+		Attempts: []*TaskAttempt{{}},
+	}))
+}
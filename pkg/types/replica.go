@@ -0,0 +1,37 @@
+package types
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+func (m *Replica) ID() string {
+	return m.GetMetadata().GetId()
+}
+
+func (m *Replica) Copy() *Replica {
+	return proto.Clone(m).(*Replica)
+}
+
+func (m *Replica) Type() string {
+	return TypeReplica
+}
+
+// Generation returns the number of events in the event stream of the replica that have been applied to it.
+func (m *Replica) Generation() int64 {
+	return m.GetMetadata().GetGeneration()
+}
+
+// Live checks if the replica has joined and holds a lease that has not expired at the given time.
+func (m *ReplicaStatus) Live(now time.Time) bool {
+	if m.GetStatus() != ReplicaStatus_ACTIVE {
+		return false
+	}
+	expiresAt, err := ptypes.Timestamp(m.GetLeaseExpiresAt())
+	if err != nil {
+		return false
+	}
+	return now.Before(expiresAt)
+}
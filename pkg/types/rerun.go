@@ -0,0 +1,101 @@
+package types
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// IsRevisionOf checks if the workflow is a revision of the original workflow, which is either the original workflow
+// itself or a newer workflow with the same name. Workflows are immutable; a workflow is revised by creating a new
+// workflow under the same name, such as when the Fission function of the workflow is updated. Older revisions are not
+// accepted, since rerunning an invocation should not roll back the workflow.
+func (m *Workflow) IsRevisionOf(original *Workflow) bool {
+	if m.ID() == original.ID() {
+		return true
+	}
+	name := m.GetSpec().GetName()
+	if len(name) == 0 || name != original.GetSpec().GetName() {
+		return false
+	}
+	createdAt, err := ptypes.Timestamp(m.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return false
+	}
+	originalCreatedAt, err := ptypes.Timestamp(original.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return false
+	}
+	return createdAt.After(originalCreatedAt)
+}
+
+// RerunSeed determines which task results of the invocation can be reused when rerunning the invocation against the
+// given workflow, which is either the workflow of the invocation or a newer revision of it.
+//
+// The result of a task is reused if the task succeeded, the spec of the task is unchanged in the workflow, and the
+// results of all tasks that it depends on are reused as well. Dynamic tasks are carried over along with the task that
+// added them. All other tasks, which are the failed tasks and their dependents, will be run again.
+func (m *WorkflowInvocation) RerunSeed(wf *Workflow) *InvocationSeed {
+	seed := &InvocationSeed{
+		InvocationId: m.ID(),
+		Tasks:        map[string]*TaskInvocation{},
+		DynamicTasks: map[string]*Task{},
+	}
+
+	// Collect the tasks of the rerun, together with their (original) dependencies.
+	specs := map[string]*TaskSpec{}
+	for id, spec := range wf.GetSpec().GetTasks() {
+		if original, ok := m.Task(id); ok && proto.Equal(original.GetSpec(), spec) {
+			specs[id] = spec
+		}
+	}
+	for id, task := range m.GetStatus().GetDynamicTasks() {
+		if _, ok := wf.GetSpec().GetTasks()[id]; !ok {
+			specs[id] = task.GetSpec()
+		}
+	}
+
+	// Reuse the results of tasks of which all dependencies are reused, until no more tasks can be reused.
+	for changed := true; changed; {
+		changed = false
+		for id, spec := range specs {
+			if _, ok := seed.Tasks[id]; ok {
+				continue
+			}
+			taskRun, ok := m.TaskInvocation(id)
+			if !ok || taskRun.GetStatus().GetStatus() != TaskInvocationStatus_SUCCEEDED {
+				continue
+			}
+			if !m.rerunDependenciesSeeded(id, spec, seed) {
+				continue
+			}
+			seed.Tasks[id] = taskRun.Copy()
+			changed = true
+		}
+	}
+
+	// Carry over the dynamic tasks that were added by the reused tasks, since their output refers to them.
+	for id, task := range m.GetStatus().GetDynamicTasks() {
+		for dep, params := range task.GetSpec().GetRequires() {
+			if _, ok := seed.Tasks[dep]; ok && params.GetType() == TaskDependencyParameters_DYNAMIC_OUTPUT {
+				seed.DynamicTasks[id] = proto.Clone(task).(*Task)
+			}
+		}
+	}
+	return seed
+}
+
+// rerunDependenciesSeeded checks if the results of all dependencies of the task have been reused, including the
+// dynamic tasks that took the place of the dependencies.
+func (m *WorkflowInvocation) rerunDependenciesSeeded(taskID string, spec *TaskSpec, seed *InvocationSeed) bool {
+	for dep := range spec.GetRequires() {
+		if _, ok := seed.Tasks[dep]; !ok {
+			return false
+		}
+		if child, ok := m.DynamicChild(dep); ok && child.ID() != taskID {
+			if _, ok := seed.Tasks[child.ID()]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
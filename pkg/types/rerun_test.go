@@ -0,0 +1,108 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupFailedInvocation() *WorkflowInvocation {
+	wf := NewWorkflow("wf-1")
+	wf.Spec.AddTask("a", &TaskSpec{FunctionRef: "noop"})
+	wf.Spec.AddTask("b", &TaskSpec{FunctionRef: "fail", Requires: Require("a")})
+	wf.Spec.AddTask("c", &TaskSpec{FunctionRef: "noop", Requires: Require("b")})
+	wf.Spec.AddTask("d", &TaskSpec{FunctionRef: "noop", Requires: Require("a")})
+	invocation := NewWorkflowInvocation("wf-1", "wi-1", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	invocation.Status.Status = WorkflowInvocationStatus_FAILED
+	invocation.Status.Tasks = map[string]*TaskInvocation{
+		"a": {Metadata: NewObjectMetadata("a"), Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}},
+		"b": {Metadata: NewObjectMetadata("b"), Status: &TaskInvocationStatus{Status: TaskInvocationStatus_FAILED}},
+		"d": {Metadata: NewObjectMetadata("d"), Status: &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}},
+	}
+	return invocation
+}
+
+func TestWorkflowInvocation_RerunSeed(t *testing.T) {
+	invocation := setupFailedInvocation()
+	seed := invocation.RerunSeed(invocation.Workflow())
+	assert.Equal(t, "wi-1", seed.GetInvocationId())
+	assert.Len(t, seed.GetTasks(), 2)
+	assert.Contains(t, seed.GetTasks(), "a")
+	assert.Contains(t, seed.GetTasks(), "d")
+	assert.Empty(t, seed.GetDynamicTasks())
+}
+
+func TestWorkflowInvocation_RerunSeedChangedTask(t *testing.T) {
+	invocation := setupFailedInvocation()
+
+	// Changing a task in a new revision of the workflow causes the task and its dependents to be run again.
+	wf := NewWorkflow("wf-2")
+	wf.Spec.AddTask("a", &TaskSpec{FunctionRef: "noop", Inputs: Input("changed")})
+	wf.Spec.AddTask("b", &TaskSpec{FunctionRef: "noop", Requires: Require("a")})
+	wf.Spec.AddTask("c", &TaskSpec{FunctionRef: "noop", Requires: Require("b")})
+	wf.Spec.AddTask("d", &TaskSpec{FunctionRef: "noop", Requires: Require("a")})
+	seed := invocation.RerunSeed(wf)
+	assert.Empty(t, seed.GetTasks())
+}
+
+func TestWorkflowInvocation_RerunSeedDynamicTasks(t *testing.T) {
+	invocation := setupFailedInvocation()
+	invocation.Status.DynamicTasks = map[string]*Task{
+		"a-child": {
+			Metadata: NewObjectMetadata("a-child"),
+			Spec: &TaskSpec{
+				FunctionRef: "noop",
+				Requires: map[string]*TaskDependencyParameters{
+					"a": {Type: TaskDependencyParameters_DYNAMIC_OUTPUT},
+				},
+			},
+		},
+	}
+	invocation.Status.Tasks["a-child"] = &TaskInvocation{
+		Metadata: NewObjectMetadata("a-child"),
+		Status:   &TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED},
+	}
+
+	seed := invocation.RerunSeed(invocation.Workflow())
+	assert.Contains(t, seed.GetTasks(), "a-child")
+	assert.Contains(t, seed.GetTasks(), "d")
+	assert.Contains(t, seed.GetDynamicTasks(), "a-child")
+
+	// Tasks that depend on a dynamic task of which the result cannot be reused are run again.
+	invocation.Status.Tasks["a-child"].Status.Status = TaskInvocationStatus_FAILED
+	seed = invocation.RerunSeed(invocation.Workflow())
+	assert.Contains(t, seed.GetTasks(), "a")
+	assert.NotContains(t, seed.GetTasks(), "a-child")
+	assert.NotContains(t, seed.GetTasks(), "d")
+	assert.Contains(t, seed.GetDynamicTasks(), "a-child")
+}
+
+func TestWorkflow_IsRevisionOf(t *testing.T) {
+	now := time.Now()
+	newNamedWorkflow := func(id string, name string, createdAt time.Time) *Workflow {
+		wf := NewWorkflow(id)
+		wf.Spec.Name = name
+		wf.Metadata.CreatedAt, _ = ptypes.TimestampProto(createdAt)
+		return wf
+	}
+	original := newNamedWorkflow("wf-1", "checkout", now)
+	assert.True(t, original.IsRevisionOf(original))
+
+	// A newer workflow with the same name is a revision of the original workflow.
+	revision := newNamedWorkflow("wf-2", "checkout", now.Add(time.Minute))
+	assert.True(t, revision.IsRevisionOf(original))
+
+	// Older workflows with the same name are not, since a rerun should not roll back the workflow.
+	older := newNamedWorkflow("wf-0", "checkout", now.Add(-time.Minute))
+	assert.False(t, older.IsRevisionOf(original))
+	assert.False(t, original.IsRevisionOf(revision))
+
+	// Workflows with another name, or without a name, are not.
+	other := newNamedWorkflow("wf-3", "shipping", now.Add(time.Minute))
+	assert.False(t, other.IsRevisionOf(original))
+	unnamed := NewWorkflow("wf-4")
+	assert.False(t, unnamed.IsRevisionOf(NewWorkflow("wf-5")))
+}
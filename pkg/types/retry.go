@@ -0,0 +1,76 @@
+package types
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/util/backoff"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// DefaultRetryBackoff is the backoff before the first retry, if the retry policy does not specify one.
+const DefaultRetryBackoff = time.Second
+
+// maxBackoffExponent bounds the exponent of the exponential backoff to avoid overflowing the duration.
+const maxBackoffExponent = 32
+
+// Attempt returns the number of the current attempt of the task invocation, where the initial attempt is 1.
+func (ti TaskInvocationStatus) Attempt() int {
+	return len(ti.Attempts) + 1
+}
+
+// Retryable checks if a task invocation with the given status should be retried according to the retry policy.
+//
+// This is the case if the task invocation has failed, it has not exhausted the maximum number of attempts, and the
+// error matches one of the RetryOn patterns (if any).
+func (m *RetryPolicy) Retryable(status *TaskInvocationStatus) bool {
+	if m == nil || status == nil || status.GetStatus() != TaskInvocationStatus_FAILED {
+		return false
+	}
+	if status.Attempt() >= int(m.GetMaxAttempts()) {
+		return false
+	}
+	if len(m.GetRetryOn()) == 0 {
+		return true
+	}
+	msg := status.GetError().GetMessage()
+	for _, pattern := range m.GetRetryOn() {
+		// Invalid patterns are rejected by the validation of the task spec, so they can safely be ignored here.
+		if matched, err := regexp.MatchString(pattern, msg); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryBackoff returns the duration to wait before running the given attempt, where the first retry is attempt 2.
+func (m *RetryPolicy) RetryBackoff(attempt int) time.Duration {
+	base := DefaultRetryBackoff
+	if m.GetBackoff() != nil {
+		if d, err := ptypes.Duration(m.GetBackoff()); err == nil {
+			base = d
+		}
+	}
+	exp := attempt - 2
+	if exp < 0 {
+		exp = 0
+	} else if exp > maxBackoffExponent {
+		exp = maxBackoffExponent
+	}
+	d := backoff.ExponentialBackoff(exp, base)
+	if m.GetMaxBackoff() != nil {
+		if max, err := ptypes.Duration(m.GetMaxBackoff()); err == nil && (d > max || d < 0) {
+			d = max
+		}
+	}
+	return d
+}
+
+// RetryAt returns the earliest time at which the failed task invocation with the given status should be retried.
+func (m *RetryPolicy) RetryAt(status *TaskInvocationStatus) time.Time {
+	failedAt, err := ptypes.Timestamp(status.GetUpdatedAt())
+	if err != nil {
+		return time.Now()
+	}
+	return failedAt.Add(m.RetryBackoff(status.Attempt() + 1))
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		RetryOn:     []string{"503", "^timeout"},
+	}
+	failed := func(msg string, previousAttempts int) *TaskInvocationStatus {
+		status := &TaskInvocationStatus{
+			Status: TaskInvocationStatus_FAILED,
+			Error:  &Error{Message: msg},
+		}
+		for i := 0; i < previousAttempts; i++ {
+			status.Attempts = append(status.Attempts, &TaskAttempt{})
+		}
+		return status
+	}
+
+	assert.True(t, policy.Retryable(failed("function returned status 503", 0)))
+	assert.True(t, policy.Retryable(failed("timeout exceeded", 1)))
+	assert.False(t, policy.Retryable(failed("timeout exceeded", 2)))
+	assert.False(t, policy.Retryable(failed("function returned status 400", 0)))
+	assert.False(t, policy.Retryable(&TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}))
+
+	// Without patterns, any error is retried.
+	assert.True(t, (&RetryPolicy{MaxAttempts: 2}).Retryable(failed("anything", 0)))
+
+	// Without a policy, nothing is retried.
+	var none *RetryPolicy
+	assert.False(t, none.Retryable(failed("function returned status 503", 0)))
+}
+
+func TestRetryPolicy_RetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 10,
+		Backoff:     ptypes.DurationProto(100 * time.Millisecond),
+		MaxBackoff:  ptypes.DurationProto(time.Second),
+	}
+	assert.Equal(t, 100*time.Millisecond, policy.RetryBackoff(2))
+	assert.Equal(t, 200*time.Millisecond, policy.RetryBackoff(3))
+	assert.Equal(t, 400*time.Millisecond, policy.RetryBackoff(4))
+	assert.Equal(t, time.Second, policy.RetryBackoff(8))
+	assert.Equal(t, time.Second, policy.RetryBackoff(100))
+	assert.Equal(t, DefaultRetryBackoff, (&RetryPolicy{}).RetryBackoff(2))
+
+	failedAt := time.Now()
+	ts, _ := ptypes.TimestampProto(failedAt)
+	status := &TaskInvocationStatus{
+		Status:    TaskInvocationStatus_FAILED,
+		UpdatedAt: ts,
+		Attempts:  []*TaskAttempt{{}},
+	}
+	assert.True(t, failedAt.Add(200*time.Millisecond).Equal(policy.RetryAt(status)))
+}
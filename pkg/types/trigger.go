@@ -0,0 +1,39 @@
+package types
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// TriggerCompletionRetention is the duration for which a completion trigger remembers the finished invocations that
+// it has handled. Missed completions can only be caught up within this duration.
+const TriggerCompletionRetention = 24 * time.Hour
+
+func (m *Trigger) ID() string {
+	return m.GetMetadata().GetId()
+}
+
+func (m *Trigger) Copy() *Trigger {
+	return proto.Clone(m).(*Trigger)
+}
+
+func (m *Trigger) Type() string {
+	return TypeTrigger
+}
+
+// Generation returns the number of events in the event stream of the trigger that have been applied to it.
+func (m *Trigger) Generation() int64 {
+	return m.GetMetadata().GetGeneration()
+}
+
+// Active checks if the trigger should (still) fire.
+func (m *TriggerStatus) Active() bool {
+	return m.GetStatus() == TriggerStatus_ACTIVE
+}
+
+// Completed checks if the completion trigger has already fired or skipped for the finished invocation.
+func (m *TriggerStatus) Completed(invocationID string) bool {
+	_, ok := m.GetCompletions()[invocationID]
+	return ok
+}
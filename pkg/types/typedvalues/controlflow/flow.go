@@ -158,8 +158,10 @@ func ResolveTaskOutput(taskID string, invocation *types.WorkflowInvocation) *typ
 		return nil
 	}
 
+	// The output of the task is replaced by the output of its dynamic child, if the task returned a dynamic task or
+	// workflow, or if the task failed and its fallback was run in its place.
 	output := val.Status.Output
-	if IsControlFlow(output) {
+	if IsControlFlow(output) || val.GetStatus().GetStatus() == types.TaskInvocationStatus_FAILED {
 		for outputTaskID, outputTask := range invocation.Status.DynamicTasks {
 			if dep, ok := outputTask.Spec.Requires[taskID]; ok &&
 				dep.Type == types.TaskDependencyParameters_DYNAMIC_OUTPUT {
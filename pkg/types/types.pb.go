@@ -13,20 +13,33 @@ It has these top-level messages:
 	WorkflowStatus
 	WorkflowInvocation
 	WorkflowInvocationSpec
+	InvocationSeed
 	WorkflowInvocationStatus
+	Signal
+	CompensationStatus
 	DependencyConfig
 	Task
 	TaskSpec
+	ErrorHandler
+	RetryPolicy
+	CachePolicy
 	TaskStatus
 	TaskDependencyParameters
 	TaskInvocation
 	TaskInvocationSpec
 	TaskInvocationStatus
+	TaskAttempt
 	ObjectMetadata
 	Error
 	FnRef
 	TypedValueMap
 	TypedValueList
+	Trigger
+	TriggerSpec
+	CompletionTrigger
+	TriggerStatus
+	Replica
+	ReplicaStatus
 */
 package types
 
@@ -84,6 +97,7 @@ const (
 	WorkflowInvocationStatus_SUCCEEDED   WorkflowInvocationStatus_Status = 3
 	WorkflowInvocationStatus_FAILED      WorkflowInvocationStatus_Status = 4
 	WorkflowInvocationStatus_ABORTED     WorkflowInvocationStatus_Status = 5
+	WorkflowInvocationStatus_PAUSED      WorkflowInvocationStatus_Status = 6
 )
 
 var WorkflowInvocationStatus_Status_name = map[int32]string{
@@ -93,6 +107,7 @@ var WorkflowInvocationStatus_Status_name = map[int32]string{
 	3: "SUCCEEDED",
 	4: "FAILED",
 	5: "ABORTED",
+	6: "PAUSED",
 }
 var WorkflowInvocationStatus_Status_value = map[string]int32{
 	"UNKNOWN":     0,
@@ -101,15 +116,71 @@ var WorkflowInvocationStatus_Status_value = map[string]int32{
 	"SUCCEEDED":   3,
 	"FAILED":      4,
 	"ABORTED":     5,
+	"PAUSED":      6,
 }
 
 func (x WorkflowInvocationStatus_Status) String() string {
 	return proto.EnumName(WorkflowInvocationStatus_Status_name, int32(x))
 }
 func (WorkflowInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{5, 0}
+	return fileDescriptor0, []int{6, 0}
 }
 
+type CompensationStatus_Status int32
+
+const (
+	CompensationStatus_UNKNOWN     CompensationStatus_Status = 0
+	CompensationStatus_IN_PROGRESS CompensationStatus_Status = 1
+	CompensationStatus_SUCCEEDED   CompensationStatus_Status = 2
+	CompensationStatus_FAILED      CompensationStatus_Status = 3
+)
+
+var CompensationStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "IN_PROGRESS",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var CompensationStatus_Status_value = map[string]int32{
+	"UNKNOWN":     0,
+	"IN_PROGRESS": 1,
+	"SUCCEEDED":   2,
+	"FAILED":      3,
+}
+
+func (x CompensationStatus_Status) String() string {
+	return proto.EnumName(CompensationStatus_Status_name, int32(x))
+}
+func (CompensationStatus_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{8, 0}
+}
+
+// AwaitMode determines what happens to the dependencies that have not finished yet, when the task is started
+// after awaiting only some of its dependencies.
+type TaskSpec_AwaitMode int32
+
+const (
+	// CONTINUE lets the remaining dependencies run to completion.
+	TaskSpec_CONTINUE TaskSpec_AwaitMode = 0
+	// CANCEL cancels the remaining dependencies, which end up ABORTED. This is useful to race multiple tasks, such
+	// as replicas of a request, of which only the fastest is needed.
+	TaskSpec_CANCEL TaskSpec_AwaitMode = 1
+)
+
+var TaskSpec_AwaitMode_name = map[int32]string{
+	0: "CONTINUE",
+	1: "CANCEL",
+}
+var TaskSpec_AwaitMode_value = map[string]int32{
+	"CONTINUE": 0,
+	"CANCEL":   1,
+}
+
+func (x TaskSpec_AwaitMode) String() string {
+	return proto.EnumName(TaskSpec_AwaitMode_name, int32(x))
+}
+func (TaskSpec_AwaitMode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
+
 type TaskStatus_Status int32
 
 const (
@@ -132,7 +203,7 @@ var TaskStatus_Status_value = map[string]int32{
 func (x TaskStatus_Status) String() string {
 	return proto.EnumName(TaskStatus_Status_name, int32(x))
 }
-func (TaskStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 0} }
+func (TaskStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{15, 0} }
 
 type TaskDependencyParameters_DependencyType int32
 
@@ -157,7 +228,7 @@ func (x TaskDependencyParameters_DependencyType) String() string {
 	return proto.EnumName(TaskDependencyParameters_DependencyType_name, int32(x))
 }
 func (TaskDependencyParameters_DependencyType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10, 0}
+	return fileDescriptor0, []int{16, 0}
 }
 
 type TaskInvocationStatus_Status int32
@@ -195,9 +266,85 @@ func (x TaskInvocationStatus_Status) String() string {
 	return proto.EnumName(TaskInvocationStatus_Status_name, int32(x))
 }
 func (TaskInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{13, 0}
+	return fileDescriptor0, []int{19, 0}
+}
+
+// OverlapPolicy determines what happens when a trigger fires while the invocation that it started previously is
+// still running.
+type TriggerSpec_OverlapPolicy int32
+
+const (
+	TriggerSpec_ALLOW   TriggerSpec_OverlapPolicy = 0
+	TriggerSpec_SKIP    TriggerSpec_OverlapPolicy = 1
+	TriggerSpec_REPLACE TriggerSpec_OverlapPolicy = 2
+)
+
+var TriggerSpec_OverlapPolicy_name = map[int32]string{
+	0: "ALLOW",
+	1: "SKIP",
+	2: "REPLACE",
+}
+var TriggerSpec_OverlapPolicy_value = map[string]int32{
+	"ALLOW":   0,
+	"SKIP":    1,
+	"REPLACE": 2,
 }
 
+func (x TriggerSpec_OverlapPolicy) String() string {
+	return proto.EnumName(TriggerSpec_OverlapPolicy_name, int32(x))
+}
+func (TriggerSpec_OverlapPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{27, 0}
+}
+
+type TriggerStatus_Status int32
+
+const (
+	TriggerStatus_UNKNOWN TriggerStatus_Status = 0
+	TriggerStatus_ACTIVE  TriggerStatus_Status = 1
+	TriggerStatus_DELETED TriggerStatus_Status = 2
+)
+
+var TriggerStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACTIVE",
+	2: "DELETED",
+}
+var TriggerStatus_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACTIVE":  1,
+	"DELETED": 2,
+}
+
+func (x TriggerStatus_Status) String() string {
+	return proto.EnumName(TriggerStatus_Status_name, int32(x))
+}
+func (TriggerStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{29, 0} }
+
+type ReplicaStatus_Status int32
+
+const (
+	ReplicaStatus_UNKNOWN ReplicaStatus_Status = 0
+	ReplicaStatus_ACTIVE  ReplicaStatus_Status = 1
+	ReplicaStatus_LEFT    ReplicaStatus_Status = 2
+)
+
+var ReplicaStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACTIVE",
+	2: "LEFT",
+}
+var ReplicaStatus_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACTIVE":  1,
+	"LEFT":    2,
+}
+
+func (x ReplicaStatus_Status) String() string {
+	return proto.EnumName(ReplicaStatus_Status_name, int32(x))
+}
+func (ReplicaStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{31, 0} }
+
 //
 // Workflow Model
 //
@@ -255,6 +402,13 @@ type WorkflowSpec struct {
 	Name string `protobuf:"bytes,6,opt,name=name" json:"name,omitempty"`
 	// Internal indicates whether is a workflow should be visible to a human (default) or not.
 	Internal bool `protobuf:"varint,7,opt,name=internal" json:"internal,omitempty"`
+	// Retention overrides the default duration for which finished invocations of this workflow are retained in the
+	// event store, before they are purged by the retention subsystem (if enabled).
+	Retention *google_protobuf1.Duration `protobuf:"bytes,8,opt,name=retention" json:"retention,omitempty"`
+	// MaxConcurrency limits the number of invocations of this workflow that are in progress at the same time. Excess
+	// invocations remain SCHEDULED until one of the invocations in progress has finished. If 0, the default limit of
+	// the workflow engine applies (if any). The limit applies to each replica of the workflow engine separately.
+	MaxConcurrency int32 `protobuf:"varint,9,opt,name=maxConcurrency" json:"maxConcurrency,omitempty"`
 }
 
 func (m *WorkflowSpec) Reset()                    { *m = WorkflowSpec{} }
@@ -311,6 +465,20 @@ func (m *WorkflowSpec) GetInternal() bool {
 	return false
 }
 
+func (m *WorkflowSpec) GetRetention() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Retention
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetMaxConcurrency() int32 {
+	if m != nil {
+		return m.MaxConcurrency
+	}
+	return 0
+}
+
 type WorkflowStatus struct {
 	Status    WorkflowStatus_Status      `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -408,6 +576,12 @@ type WorkflowInvocationSpec struct {
 	// Each invocation has a deadline. If no deadline is provided Fission Workflows uses a default deadline (typically
 	// 10 minutes).
 	Deadline *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=Deadline" json:"Deadline,omitempty"`
+	// Seed contains the results of tasks of a previous invocation, which are reused instead of running the tasks again.
+	//
+	// It is set when rerunning a failed invocation, so that only the failed tasks and their dependents are run.
+	Seed *InvocationSeed `protobuf:"bytes,6,opt,name=seed" json:"seed,omitempty"`
+	// Labels are optional key-value pairs that identify the invocation, which triggers can match on.
+	Labels map[string]string `protobuf:"bytes,7,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *WorkflowInvocationSpec) Reset()                    { *m = WorkflowInvocationSpec{} }
@@ -450,6 +624,56 @@ func (m *WorkflowInvocationSpec) GetDeadline() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *WorkflowInvocationSpec) GetSeed() *InvocationSeed {
+	if m != nil {
+		return m.Seed
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationSpec) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+// InvocationSeed contains the task results of a previous invocation that a new invocation starts with.
+type InvocationSeed struct {
+	// InvocationId contains the id of the invocation from which the task results originate.
+	InvocationId string `protobuf:"bytes,1,opt,name=invocationId" json:"invocationId,omitempty"`
+	// Tasks contains the reused task invocations, keyed by task ID.
+	Tasks map[string]*TaskInvocation `protobuf:"bytes,2,rep,name=tasks" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// DynamicTasks contains the dynamic tasks that were added by the reused tasks, keyed by task ID.
+	DynamicTasks map[string]*Task `protobuf:"bytes,3,rep,name=dynamicTasks" json:"dynamicTasks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *InvocationSeed) Reset()                    { *m = InvocationSeed{} }
+func (m *InvocationSeed) String() string            { return proto.CompactTextString(m) }
+func (*InvocationSeed) ProtoMessage()               {}
+func (*InvocationSeed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *InvocationSeed) GetInvocationId() string {
+	if m != nil {
+		return m.InvocationId
+	}
+	return ""
+}
+
+func (m *InvocationSeed) GetTasks() map[string]*TaskInvocation {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *InvocationSeed) GetDynamicTasks() map[string]*Task {
+	if m != nil {
+		return m.DynamicTasks
+	}
+	return nil
+}
+
 type WorkflowInvocationStatus struct {
 	Status    WorkflowInvocationStatus_Status     `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp          `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -460,12 +684,18 @@ type WorkflowInvocationStatus struct {
 	DynamicTasks  map[string]*Task                    `protobuf:"bytes,5,rep,name=dynamicTasks" json:"dynamicTasks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Error         *Error                              `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
 	OutputHeaders *fission_workflows_types.TypedValue `protobuf:"bytes,7,opt,name=outputHeaders" json:"outputHeaders,omitempty"`
+	// Compensation contains the progress of the compensation of the succeeded tasks, after the invocation has failed.
+	Compensation *CompensationStatus `protobuf:"bytes,8,opt,name=compensation" json:"compensation,omitempty"`
+	// Signals contains the external signals that the invocation has received, keyed by the name of the signal.
+	Signals map[string]*Signal `protobuf:"bytes,9,rep,name=signals" json:"signals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// StartedAt is the time at which the invocation left the SCHEDULED state to be processed.
+	StartedAt *google_protobuf.Timestamp `protobuf:"bytes,10,opt,name=startedAt" json:"startedAt,omitempty"`
 }
 
 func (m *WorkflowInvocationStatus) Reset()                    { *m = WorkflowInvocationStatus{} }
 func (m *WorkflowInvocationStatus) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationStatus) ProtoMessage()               {}
-func (*WorkflowInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*WorkflowInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *WorkflowInvocationStatus) GetStatus() WorkflowInvocationStatus_Status {
 	if m != nil {
@@ -516,6 +746,112 @@ func (m *WorkflowInvocationStatus) GetOutputHeaders() *fission_workflows_types.T
 	return nil
 }
 
+func (m *WorkflowInvocationStatus) GetCompensation() *CompensationStatus {
+	if m != nil {
+		return m.Compensation
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationStatus) GetSignals() map[string]*Signal {
+	if m != nil {
+		return m.Signals
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationStatus) GetStartedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+// Signal is an external event, such as an approval or a webhook call, that tasks of an invocation can await.
+type Signal struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Payload contains the (optional) data of the signal, which becomes the output of the tasks awaiting it.
+	Payload    *fission_workflows_types.TypedValue `protobuf:"bytes,2,opt,name=payload" json:"payload,omitempty"`
+	ReceivedAt *google_protobuf.Timestamp          `protobuf:"bytes,3,opt,name=receivedAt" json:"receivedAt,omitempty"`
+}
+
+func (m *Signal) Reset()                    { *m = Signal{} }
+func (m *Signal) String() string            { return proto.CompactTextString(m) }
+func (*Signal) ProtoMessage()               {}
+func (*Signal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Signal) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Signal) GetPayload() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Signal) GetReceivedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return nil
+}
+
+// CompensationStatus contains the progress of the compensations that are run to roll back a failed invocation.
+type CompensationStatus struct {
+	Status    CompensationStatus_Status  `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.CompensationStatus_Status" json:"status,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// Order contains the IDs of the tasks to be compensated, in the order in which the compensations are run.
+	Order []string `protobuf:"bytes,3,rep,name=order" json:"order,omitempty"`
+	// Tasks contains the results of the compensations that have been run, keyed by the ID of the compensated task.
+	Tasks map[string]*TaskInvocationStatus `protobuf:"bytes,4,rep,name=tasks" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Error *Error                           `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *CompensationStatus) Reset()                    { *m = CompensationStatus{} }
+func (m *CompensationStatus) String() string            { return proto.CompactTextString(m) }
+func (*CompensationStatus) ProtoMessage()               {}
+func (*CompensationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CompensationStatus) GetStatus() CompensationStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return CompensationStatus_UNKNOWN
+}
+
+func (m *CompensationStatus) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *CompensationStatus) GetOrder() []string {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *CompensationStatus) GetTasks() map[string]*TaskInvocationStatus {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *CompensationStatus) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type DependencyConfig struct {
 	// Dependencies for this task to execute
 	Requires map[string]*TaskDependencyParameters `protobuf:"bytes,1,rep,name=requires" json:"requires,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -526,7 +862,7 @@ type DependencyConfig struct {
 func (m *DependencyConfig) Reset()                    { *m = DependencyConfig{} }
 func (m *DependencyConfig) String() string            { return proto.CompactTextString(m) }
 func (*DependencyConfig) ProtoMessage()               {}
-func (*DependencyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DependencyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *DependencyConfig) GetRequires() map[string]*TaskDependencyParameters {
 	if m != nil {
@@ -554,7 +890,7 @@ type Task struct {
 func (m *Task) Reset()                    { *m = Task{} }
 func (m *Task) String() string            { return proto.CompactTextString(m) }
 func (*Task) ProtoMessage()               {}
-func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *Task) GetMetadata() *ObjectMetadata {
 	if m != nil {
@@ -591,6 +927,9 @@ type TaskSpec struct {
 	// Dependencies for this task to execute.
 	Requires map[string]*TaskDependencyParameters `protobuf:"bytes,3,rep,name=requires" json:"requires,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Await signals the number of dependencies to wait for before this task can be started.
+	//
+	// If it is less than the number of dependencies, the task is started once that number of its dependencies have
+	// succeeded, regardless of the other dependencies. If not set, the task waits for all of its dependencies.
 	Await int32 `protobuf:"varint,4,opt,name=await" json:"await,omitempty"`
 	// Output transforms or overrides the output of the executed function.
 	Output *fission_workflows_types.TypedValue `protobuf:"bytes,5,opt,name=output" json:"output,omitempty"`
@@ -601,12 +940,34 @@ type TaskSpec struct {
 	// It overrides the deadline specified by the workflow invocation, but cannot exceed it. If set, this field will be
 	// used in the task invocation spec to compute the deadline.
 	Timeout *google_protobuf1.Duration `protobuf:"bytes,7,opt,name=timeout" json:"timeout,omitempty"`
+	// Retry specifies whether, and how, the task should be retried after it has failed.
+	//
+	// If not set, a failed task fails the workflow invocation.
+	Retry *RetryPolicy `protobuf:"bytes,8,opt,name=retry" json:"retry,omitempty"`
+	// OnError specifies how the failure of the task is handled, once it will not be retried (anymore).
+	//
+	// If not set, a failed task fails the workflow invocation.
+	OnError *ErrorHandler `protobuf:"bytes,9,opt,name=onError" json:"onError,omitempty"`
+	// Compensate specifies the task that undoes the effects of this task. In the inputs of the compensation, the
+	// current task refers to this task, so that for example output() returns the output of this task.
+	//
+	// If the workflow invocation fails, the compensations of the succeeded tasks are run in reverse dependency order.
+	Compensate *TaskSpec `protobuf:"bytes,10,opt,name=compensate" json:"compensate,omitempty"`
+	// When is the condition under which the task is run, which is evaluated once the dependencies of the task have
+	// completed. It is typically an expression that evaluates to a boolean. If the condition is false, the task is
+	// SKIPPED, along with the tasks that depend on its output (DATA dependencies). If not set, the task is always run.
+	When *fission_workflows_types.TypedValue `protobuf:"bytes,11,opt,name=when" json:"when,omitempty"`
+	// Cache enables the memoization of the results of the task. If set, a successful result of the task is reused by
+	// subsequent runs of the task (in any workflow) with the same function and inputs, instead of running the
+	// function again. Only use this for functions that are pure, or for which stale results are acceptable.
+	Cache     *CachePolicy       `protobuf:"bytes,12,opt,name=cache" json:"cache,omitempty"`
+	AwaitMode TaskSpec_AwaitMode `protobuf:"varint,13,opt,name=awaitMode,enum=fission.workflows.types.TaskSpec_AwaitMode" json:"awaitMode,omitempty"`
 }
 
 func (m *TaskSpec) Reset()                    { *m = TaskSpec{} }
 func (m *TaskSpec) String() string            { return proto.CompactTextString(m) }
 func (*TaskSpec) ProtoMessage()               {}
-func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *TaskSpec) GetFunctionRef() string {
 	if m != nil {
@@ -657,17 +1018,178 @@ func (m *TaskSpec) GetTimeout() *google_protobuf1.Duration {
 	return nil
 }
 
+func (m *TaskSpec) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetOnError() *ErrorHandler {
+	if m != nil {
+		return m.OnError
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetCompensate() *TaskSpec {
+	if m != nil {
+		return m.Compensate
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetWhen() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.When
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetCache() *CachePolicy {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetAwaitMode() TaskSpec_AwaitMode {
+	if m != nil {
+		return m.AwaitMode
+	}
+	return TaskSpec_CONTINUE
+}
+
+// ErrorHandler specifies how the failure of a task is handled, instead of failing the workflow invocation.
+//
+// Either continue or one of the fallbacks should be set.
+type ErrorHandler struct {
+	// Continue tolerates the failure of the task. The task remains failed, but its dependents are run as if the task
+	// succeeded without an output.
+	Continue bool `protobuf:"varint,1,opt,name=continue" json:"continue,omitempty"`
+	// FallbackTask is run in place of the failed task, as a dynamic task. The output of the fallback is used as the
+	// output of the failed task.
+	FallbackTask *TaskSpec `protobuf:"bytes,2,opt,name=fallbackTask" json:"fallbackTask,omitempty"`
+	// FallbackWorkflow is run in place of the failed task, as a dynamic workflow. The output of the fallback is used
+	// as the output of the failed task.
+	FallbackWorkflow *WorkflowSpec `protobuf:"bytes,3,opt,name=fallbackWorkflow" json:"fallbackWorkflow,omitempty"`
+}
+
+func (m *ErrorHandler) Reset()                    { *m = ErrorHandler{} }
+func (m *ErrorHandler) String() string            { return proto.CompactTextString(m) }
+func (*ErrorHandler) ProtoMessage()               {}
+func (*ErrorHandler) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ErrorHandler) GetContinue() bool {
+	if m != nil {
+		return m.Continue
+	}
+	return false
+}
+
+func (m *ErrorHandler) GetFallbackTask() *TaskSpec {
+	if m != nil {
+		return m.FallbackTask
+	}
+	return nil
+}
+
+func (m *ErrorHandler) GetFallbackWorkflow() *WorkflowSpec {
+	if m != nil {
+		return m.FallbackWorkflow
+	}
+	return nil
+}
+
+// RetryPolicy specifies how a failed task is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times that the task is run, including the initial attempt.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	// Backoff is the duration to wait before the first retry, which is doubled for every subsequent retry.
+	//
+	// If not set, the default backoff of 1 second is used.
+	Backoff *google_protobuf1.Duration `protobuf:"bytes,2,opt,name=backoff" json:"backoff,omitempty"`
+	// MaxBackoff is the maximum duration to wait before a retry. If not set, the backoff is not bounded.
+	MaxBackoff *google_protobuf1.Duration `protobuf:"bytes,3,opt,name=maxBackoff" json:"maxBackoff,omitempty"`
+	// RetryOn is a list of regular expressions of which one should match the error message of the failed attempt
+	// for the task to be retried. If empty, the task is retried on any error.
+	RetryOn []string `protobuf:"bytes,4,rep,name=retryOn" json:"retryOn,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()                    { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()               {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoff() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Backoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetMaxBackoff() *google_protobuf1.Duration {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetRetryOn() []string {
+	if m != nil {
+		return m.RetryOn
+	}
+	return nil
+}
+
+// CachePolicy specifies how the results of a task are cached.
+type CachePolicy struct {
+	// TTL is the duration for which a result is reused.
+	Ttl *google_protobuf1.Duration `protobuf:"bytes,1,opt,name=ttl" json:"ttl,omitempty"`
+	// Key is an optional expression that identifies the result in the cache, instead of the inputs of the task.
+	// The result is always specific to the function of the task.
+	Key *fission_workflows_types.TypedValue `protobuf:"bytes,2,opt,name=key" json:"key,omitempty"`
+}
+
+func (m *CachePolicy) Reset()                    { *m = CachePolicy{} }
+func (m *CachePolicy) String() string            { return proto.CompactTextString(m) }
+func (*CachePolicy) ProtoMessage()               {}
+func (*CachePolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *CachePolicy) GetTtl() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Ttl
+	}
+	return nil
+}
+
+func (m *CachePolicy) GetKey() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
 type TaskStatus struct {
 	Status    TaskStatus_Status          `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TaskStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
 	FnRef     *FnRef                     `protobuf:"bytes,3,opt,name=fnRef" json:"fnRef,omitempty"`
 	Error     *Error                     `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	// CompensateFnRef is the resolved function reference of the compensation of the task, if it has one.
+	CompensateFnRef *FnRef `protobuf:"bytes,5,opt,name=compensateFnRef" json:"compensateFnRef,omitempty"`
 }
 
 func (m *TaskStatus) Reset()                    { *m = TaskStatus{} }
 func (m *TaskStatus) String() string            { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()               {}
-func (*TaskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*TaskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *TaskStatus) GetStatus() TaskStatus_Status {
 	if m != nil {
@@ -697,6 +1219,13 @@ func (m *TaskStatus) GetError() *Error {
 	return nil
 }
 
+func (m *TaskStatus) GetCompensateFnRef() *FnRef {
+	if m != nil {
+		return m.CompensateFnRef
+	}
+	return nil
+}
+
 type TaskDependencyParameters struct {
 	Type  TaskDependencyParameters_DependencyType `protobuf:"varint,1,opt,name=type,enum=fission.workflows.types.TaskDependencyParameters_DependencyType" json:"type,omitempty"`
 	Alias string                                  `protobuf:"bytes,2,opt,name=alias" json:"alias,omitempty"`
@@ -705,7 +1234,7 @@ type TaskDependencyParameters struct {
 func (m *TaskDependencyParameters) Reset()                    { *m = TaskDependencyParameters{} }
 func (m *TaskDependencyParameters) String() string            { return proto.CompactTextString(m) }
 func (*TaskDependencyParameters) ProtoMessage()               {}
-func (*TaskDependencyParameters) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*TaskDependencyParameters) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *TaskDependencyParameters) GetType() TaskDependencyParameters_DependencyType {
 	if m != nil {
@@ -733,7 +1262,7 @@ type TaskInvocation struct {
 func (m *TaskInvocation) Reset()                    { *m = TaskInvocation{} }
 func (m *TaskInvocation) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocation) ProtoMessage()               {}
-func (*TaskInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*TaskInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *TaskInvocation) GetMetadata() *ObjectMetadata {
 	if m != nil {
@@ -781,7 +1310,7 @@ type TaskInvocationSpec struct {
 func (m *TaskInvocationSpec) Reset()                    { *m = TaskInvocationSpec{} }
 func (m *TaskInvocationSpec) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocationSpec) ProtoMessage()               {}
-func (*TaskInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*TaskInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *TaskInvocationSpec) GetFnRef() *FnRef {
 	if m != nil {
@@ -831,12 +1360,20 @@ type TaskInvocationStatus struct {
 	Output        *fission_workflows_types.TypedValue `protobuf:"bytes,3,opt,name=output" json:"output,omitempty"`
 	Error         *Error                              `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
 	OutputHeaders *fission_workflows_types.TypedValue `protobuf:"bytes,5,opt,name=outputHeaders" json:"outputHeaders,omitempty"`
+	// Attempts contains the history of the previous, failed attempts of the task invocation.
+	Attempts []*TaskAttempt `protobuf:"bytes,6,rep,name=attempts" json:"attempts,omitempty"`
+	// Cached indicates that the output was reused from an earlier run of the task, rather than produced by running
+	// the function.
+	Cached bool `protobuf:"varint,7,opt,name=cached" json:"cached,omitempty"`
+	// StartedAt is the time at which the function of the current attempt of the task started running. It is only
+	// known once the attempt has finished.
+	StartedAt *google_protobuf.Timestamp `protobuf:"bytes,8,opt,name=startedAt" json:"startedAt,omitempty"`
 }
 
 func (m *TaskInvocationStatus) Reset()                    { *m = TaskInvocationStatus{} }
 func (m *TaskInvocationStatus) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocationStatus) ProtoMessage()               {}
-func (*TaskInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*TaskInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *TaskInvocationStatus) GetStatus() TaskInvocationStatus_Status {
 	if m != nil {
@@ -873,6 +1410,52 @@ func (m *TaskInvocationStatus) GetOutputHeaders() *fission_workflows_types.Typed
 	return nil
 }
 
+func (m *TaskInvocationStatus) GetAttempts() []*TaskAttempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+func (m *TaskInvocationStatus) GetCached() bool {
+	if m != nil {
+		return m.Cached
+	}
+	return false
+}
+
+func (m *TaskInvocationStatus) GetStartedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+// TaskAttempt is a failed attempt of a task invocation, which has been retried.
+type TaskAttempt struct {
+	FailedAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=failedAt" json:"failedAt,omitempty"`
+	Error    *Error                     `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TaskAttempt) Reset()                    { *m = TaskAttempt{} }
+func (m *TaskAttempt) String() string            { return proto.CompactTextString(m) }
+func (*TaskAttempt) ProtoMessage()               {}
+func (*TaskAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *TaskAttempt) GetFailedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.FailedAt
+	}
+	return nil
+}
+
+func (m *TaskAttempt) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 // ObjectMetadata contains common metadata present for all objects in the workflow engine.
 //
 // It closely follows the structure of Kubernetes' ObjectMetadata, leaving out the parameters that do not fit the
@@ -894,7 +1477,7 @@ type ObjectMetadata struct {
 func (m *ObjectMetadata) Reset()                    { *m = ObjectMetadata{} }
 func (m *ObjectMetadata) String() string            { return proto.CompactTextString(m) }
 func (*ObjectMetadata) ProtoMessage()               {}
-func (*ObjectMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ObjectMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ObjectMetadata) GetId() string {
 	if m != nil {
@@ -931,7 +1514,7 @@ type Error struct {
 func (m *Error) Reset()                    { *m = Error{} }
 func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Error) GetMessage() string {
 	if m != nil {
@@ -955,7 +1538,7 @@ type FnRef struct {
 func (m *FnRef) Reset()                    { *m = FnRef{} }
 func (m *FnRef) String() string            { return proto.CompactTextString(m) }
 func (*FnRef) ProtoMessage()               {}
-func (*FnRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*FnRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *FnRef) GetRuntime() string {
 	if m != nil {
@@ -986,7 +1569,7 @@ type TypedValueMap struct {
 func (m *TypedValueMap) Reset()                    { *m = TypedValueMap{} }
 func (m *TypedValueMap) String() string            { return proto.CompactTextString(m) }
 func (*TypedValueMap) ProtoMessage()               {}
-func (*TypedValueMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*TypedValueMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *TypedValueMap) GetValue() map[string]*fission_workflows_types.TypedValue {
 	if m != nil {
@@ -1003,7 +1586,7 @@ type TypedValueList struct {
 func (m *TypedValueList) Reset()                    { *m = TypedValueList{} }
 func (m *TypedValueList) String() string            { return proto.CompactTextString(m) }
 func (*TypedValueList) ProtoMessage()               {}
-func (*TypedValueList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*TypedValueList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *TypedValueList) GetValue() []*fission_workflows_types.TypedValue {
 	if m != nil {
@@ -1012,128 +1595,514 @@ func (m *TypedValueList) GetValue() []*fission_workflows_types.TypedValue {
 	return nil
 }
 
+// Trigger starts invocations of a workflow periodically, either according to a cron expression or at a fixed interval.
+type Trigger struct {
+	Metadata *ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Spec     *TriggerSpec    `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+	Status   *TriggerStatus  `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Trigger) Reset()                    { *m = Trigger{} }
+func (m *Trigger) String() string            { return proto.CompactTextString(m) }
+func (*Trigger) ProtoMessage()               {}
+func (*Trigger) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *Trigger) GetMetadata() *ObjectMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Trigger) GetSpec() *TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Trigger) GetStatus() *TriggerStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type TriggerSpec struct {
+	// WorkflowId contains a reference to the workflow that needs to be invoked.
+	WorkflowId string `protobuf:"bytes,1,opt,name=workflowId" json:"workflowId,omitempty"`
+	// Cron is a cron expression with five fields (minute, hour, day of month, month, day of week), or a predefined
+	// schedule such as '@hourly' or '@every 5m'. The schedule is evaluated in UTC. Exactly one of cron, interval and
+	// completion is set.
+	Cron string `protobuf:"bytes,2,opt,name=cron" json:"cron,omitempty"`
+	// Interval is the fixed duration between the starts of two consecutive invocations.
+	Interval *google_protobuf1.Duration `protobuf:"bytes,3,opt,name=interval" json:"interval,omitempty"`
+	// Inputs contains the inputs that are provided to every invocation. For completion triggers, the inputs can
+	// contain expressions, which are resolved against the completed invocation (e.g. '{$.Invocation.Output}'). If
+	// a completion trigger has no inputs, the output of the completed invocation is provided as the default input.
+	Inputs        map[string]*fission_workflows_types.TypedValue `protobuf:"bytes,4,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OverlapPolicy TriggerSpec_OverlapPolicy                      `protobuf:"varint,5,opt,name=overlapPolicy,enum=fission.workflows.types.TriggerSpec_OverlapPolicy" json:"overlapPolicy,omitempty"`
+	// Name is solely for human-readablity
+	Name string `protobuf:"bytes,6,opt,name=name" json:"name,omitempty"`
+	// Completion makes the trigger fire whenever an invocation of another workflow finishes, instead of on a schedule.
+	Completion *CompletionTrigger `protobuf:"bytes,7,opt,name=completion" json:"completion,omitempty"`
+}
+
+func (m *TriggerSpec) Reset()                    { *m = TriggerSpec{} }
+func (m *TriggerSpec) String() string            { return proto.CompactTextString(m) }
+func (*TriggerSpec) ProtoMessage()               {}
+func (*TriggerSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *TriggerSpec) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *TriggerSpec) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *TriggerSpec) GetInterval() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
+func (m *TriggerSpec) GetInputs() map[string]*fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *TriggerSpec) GetOverlapPolicy() TriggerSpec_OverlapPolicy {
+	if m != nil {
+		return m.OverlapPolicy
+	}
+	return TriggerSpec_ALLOW
+}
+
+func (m *TriggerSpec) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TriggerSpec) GetCompletion() *CompletionTrigger {
+	if m != nil {
+		return m.Completion
+	}
+	return nil
+}
+
+// CompletionTrigger matches the invocations that cause a trigger to fire when they finish.
+type CompletionTrigger struct {
+	// WorkflowId contains a reference to the workflow of which the invocations are matched.
+	WorkflowId string `protobuf:"bytes,1,opt,name=workflowId" json:"workflowId,omitempty"`
+	// Statuses contains the terminal statuses of the invocations to match. If empty, only succeeded invocations match.
+	Statuses []WorkflowInvocationStatus_Status `protobuf:"varint,2,rep,packed,name=statuses,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"statuses,omitempty"`
+	// Labels contains the labels that a matching invocation should have.
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *CompletionTrigger) Reset()                    { *m = CompletionTrigger{} }
+func (m *CompletionTrigger) String() string            { return proto.CompactTextString(m) }
+func (*CompletionTrigger) ProtoMessage()               {}
+func (*CompletionTrigger) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *CompletionTrigger) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *CompletionTrigger) GetStatuses() []WorkflowInvocationStatus_Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *CompletionTrigger) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type TriggerStatus struct {
+	Status    TriggerStatus_Status       `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TriggerStatus_Status" json:"status,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// LastScheduledAt is the scheduled time of the last firing of the trigger, including skipped firings.
+	LastScheduledAt *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=lastScheduledAt" json:"lastScheduledAt,omitempty"`
+	// LastInvocationId contains the id of the last invocation that was started by the trigger.
+	LastInvocationId string `protobuf:"bytes,4,opt,name=lastInvocationId" json:"lastInvocationId,omitempty"`
+	// Fired is the number of invocations that have been started by the trigger.
+	Fired int64 `protobuf:"varint,5,opt,name=fired" json:"fired,omitempty"`
+	// Skipped is the number of firings that did not start an invocation.
+	Skipped int64 `protobuf:"varint,6,opt,name=skipped" json:"skipped,omitempty"`
+	// Error contains the reason that the last firing was skipped, if it was skipped.
+	Error *Error `protobuf:"bytes,7,opt,name=error" json:"error,omitempty"`
+	// Completions contains the finished invocations that a completion trigger has fired or skipped for, keyed by the
+	// id of the invocation, along with the time at which they were handled. Entries are kept for
+	// TriggerCompletionRetention, which prevents an invocation from firing the trigger twice.
+	Completions map[string]*google_protobuf.Timestamp `protobuf:"bytes,8,rep,name=completions" json:"completions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TriggerStatus) Reset()                    { *m = TriggerStatus{} }
+func (m *TriggerStatus) String() string            { return proto.CompactTextString(m) }
+func (*TriggerStatus) ProtoMessage()               {}
+func (*TriggerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *TriggerStatus) GetStatus() TriggerStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return TriggerStatus_UNKNOWN
+}
+
+func (m *TriggerStatus) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetLastScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LastScheduledAt
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetLastInvocationId() string {
+	if m != nil {
+		return m.LastInvocationId
+	}
+	return ""
+}
+
+func (m *TriggerStatus) GetFired() int64 {
+	if m != nil {
+		return m.Fired
+	}
+	return 0
+}
+
+func (m *TriggerStatus) GetSkipped() int64 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+func (m *TriggerStatus) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetCompletions() map[string]*google_protobuf.Timestamp {
+	if m != nil {
+		return m.Completions
+	}
+	return nil
+}
+
+// Replica is an instance of the workflow engine that shares the event store with other instances. The replicas divide
+// the invocations among each other, based on the replicas that hold a lease.
+type Replica struct {
+	Metadata *ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Status   *ReplicaStatus  `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Replica) Reset()                    { *m = Replica{} }
+func (m *Replica) String() string            { return proto.CompactTextString(m) }
+func (*Replica) ProtoMessage()               {}
+func (*Replica) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *Replica) GetMetadata() *ObjectMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Replica) GetStatus() *ReplicaStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ReplicaStatus struct {
+	Status    ReplicaStatus_Status       `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.ReplicaStatus_Status" json:"status,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// JoinedAt is the time at which the replica (re)joined the cluster.
+	JoinedAt *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=joinedAt" json:"joinedAt,omitempty"`
+	// LeaseExpiresAt is the time until which the replica is considered to be alive, unless it renews its lease.
+	LeaseExpiresAt *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=leaseExpiresAt" json:"leaseExpiresAt,omitempty"`
+	// Error contains the reason that the replica left, if it did not leave by itself.
+	Error *Error `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *ReplicaStatus) Reset()                    { *m = ReplicaStatus{} }
+func (m *ReplicaStatus) String() string            { return proto.CompactTextString(m) }
+func (*ReplicaStatus) ProtoMessage()               {}
+func (*ReplicaStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *ReplicaStatus) GetStatus() ReplicaStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ReplicaStatus_UNKNOWN
+}
+
+func (m *ReplicaStatus) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *ReplicaStatus) GetJoinedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return nil
+}
+
+func (m *ReplicaStatus) GetLeaseExpiresAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LeaseExpiresAt
+	}
+	return nil
+}
+
+func (m *ReplicaStatus) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Workflow)(nil), "fission.workflows.types.Workflow")
 	proto.RegisterType((*WorkflowSpec)(nil), "fission.workflows.types.WorkflowSpec")
 	proto.RegisterType((*WorkflowStatus)(nil), "fission.workflows.types.WorkflowStatus")
 	proto.RegisterType((*WorkflowInvocation)(nil), "fission.workflows.types.WorkflowInvocation")
 	proto.RegisterType((*WorkflowInvocationSpec)(nil), "fission.workflows.types.WorkflowInvocationSpec")
+	proto.RegisterType((*InvocationSeed)(nil), "fission.workflows.types.InvocationSeed")
 	proto.RegisterType((*WorkflowInvocationStatus)(nil), "fission.workflows.types.WorkflowInvocationStatus")
+	proto.RegisterType((*Signal)(nil), "fission.workflows.types.Signal")
+	proto.RegisterType((*CompensationStatus)(nil), "fission.workflows.types.CompensationStatus")
 	proto.RegisterType((*DependencyConfig)(nil), "fission.workflows.types.DependencyConfig")
 	proto.RegisterType((*Task)(nil), "fission.workflows.types.Task")
 	proto.RegisterType((*TaskSpec)(nil), "fission.workflows.types.TaskSpec")
+	proto.RegisterType((*ErrorHandler)(nil), "fission.workflows.types.ErrorHandler")
+	proto.RegisterType((*RetryPolicy)(nil), "fission.workflows.types.RetryPolicy")
+	proto.RegisterType((*CachePolicy)(nil), "fission.workflows.types.CachePolicy")
 	proto.RegisterType((*TaskStatus)(nil), "fission.workflows.types.TaskStatus")
 	proto.RegisterType((*TaskDependencyParameters)(nil), "fission.workflows.types.TaskDependencyParameters")
 	proto.RegisterType((*TaskInvocation)(nil), "fission.workflows.types.TaskInvocation")
 	proto.RegisterType((*TaskInvocationSpec)(nil), "fission.workflows.types.TaskInvocationSpec")
 	proto.RegisterType((*TaskInvocationStatus)(nil), "fission.workflows.types.TaskInvocationStatus")
+	proto.RegisterType((*TaskAttempt)(nil), "fission.workflows.types.TaskAttempt")
 	proto.RegisterType((*ObjectMetadata)(nil), "fission.workflows.types.ObjectMetadata")
 	proto.RegisterType((*Error)(nil), "fission.workflows.types.Error")
 	proto.RegisterType((*FnRef)(nil), "fission.workflows.types.FnRef")
 	proto.RegisterType((*TypedValueMap)(nil), "fission.workflows.types.TypedValueMap")
 	proto.RegisterType((*TypedValueList)(nil), "fission.workflows.types.TypedValueList")
+	proto.RegisterType((*Trigger)(nil), "fission.workflows.types.Trigger")
+	proto.RegisterType((*TriggerSpec)(nil), "fission.workflows.types.TriggerSpec")
+	proto.RegisterType((*CompletionTrigger)(nil), "fission.workflows.types.CompletionTrigger")
+	proto.RegisterType((*TriggerStatus)(nil), "fission.workflows.types.TriggerStatus")
+	proto.RegisterType((*Replica)(nil), "fission.workflows.types.Replica")
+	proto.RegisterType((*ReplicaStatus)(nil), "fission.workflows.types.ReplicaStatus")
 	proto.RegisterEnum("fission.workflows.types.WorkflowStatus_Status", WorkflowStatus_Status_name, WorkflowStatus_Status_value)
 	proto.RegisterEnum("fission.workflows.types.WorkflowInvocationStatus_Status", WorkflowInvocationStatus_Status_name, WorkflowInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.CompensationStatus_Status", CompensationStatus_Status_name, CompensationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.TaskSpec_AwaitMode", TaskSpec_AwaitMode_name, TaskSpec_AwaitMode_value)
 	proto.RegisterEnum("fission.workflows.types.TaskStatus_Status", TaskStatus_Status_name, TaskStatus_Status_value)
 	proto.RegisterEnum("fission.workflows.types.TaskDependencyParameters_DependencyType", TaskDependencyParameters_DependencyType_name, TaskDependencyParameters_DependencyType_value)
 	proto.RegisterEnum("fission.workflows.types.TaskInvocationStatus_Status", TaskInvocationStatus_Status_name, TaskInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.TriggerSpec_OverlapPolicy", TriggerSpec_OverlapPolicy_name, TriggerSpec_OverlapPolicy_value)
+	proto.RegisterEnum("fission.workflows.types.TriggerStatus_Status", TriggerStatus_Status_name, TriggerStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.ReplicaStatus_Status", ReplicaStatus_Status_name, ReplicaStatus_Status_value)
 }
 
 func init() { proto.RegisterFile("pkg/types/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xae, 0x2c, 0xcb, 0x3f, 0xc7, 0x8d, 0x31, 0x3b, 0xa5, 0x18, 0x0f, 0x94, 0x54, 0x1d, 0xa6,
-	0x1d, 0xa0, 0x32, 0x49, 0x0b, 0x4d, 0x09, 0x9d, 0xe2, 0x5a, 0x4a, 0xab, 0xc9, 0x8f, 0x83, 0x6c,
-	0xb7, 0x53, 0x98, 0xb6, 0xa3, 0x58, 0x6b, 0xa3, 0x26, 0x96, 0x84, 0x24, 0xb7, 0x93, 0x3b, 0x9e,
-	0x80, 0x87, 0x60, 0xe0, 0x19, 0xb8, 0xe4, 0xa2, 0x37, 0xcc, 0xf0, 0x0c, 0x0c, 0x17, 0x5c, 0x71,
-	0xc1, 0x3b, 0x30, 0xbb, 0x5a, 0xfd, 0xd9, 0x71, 0x6c, 0x67, 0x5c, 0x86, 0x9b, 0x44, 0xbb, 0x7b,
-	0xce, 0xd9, 0xb3, 0xe7, 0xe7, 0xfb, 0x76, 0x0d, 0x6f, 0x39, 0x87, 0x83, 0xba, 0x7f, 0xec, 0x60,
-	0x2f, 0xf8, 0x2b, 0x39, 0xae, 0xed, 0xdb, 0xe8, 0xed, 0xbe, 0xe9, 0x79, 0xa6, 0x6d, 0x49, 0x2f,
-	0x6d, 0xf7, 0xb0, 0x7f, 0x64, 0xbf, 0xf4, 0x24, 0xba, 0x5c, 0x7b, 0x7f, 0x60, 0xdb, 0x83, 0x23,
-	0x5c, 0xa7, 0x62, 0x07, 0xa3, 0x7e, 0xdd, 0x37, 0x87, 0xd8, 0xf3, 0xf5, 0xa1, 0x13, 0x68, 0xd6,
-	0x2e, 0x8d, 0x0b, 0x18, 0x23, 0x57, 0xf7, 0x89, 0xa9, 0x60, 0x7d, 0x67, 0x60, 0xfa, 0xdf, 0x8e,
-	0x0e, 0xa4, 0x9e, 0x3d, 0xac, 0xb3, 0x4d, 0xc2, 0xff, 0xd7, 0xa3, 0xcd, 0xea, 0x69, 0xaf, 0x8c,
-	0x17, 0xfa, 0xd1, 0x28, 0xfd, 0x1d, 0x58, 0x13, 0x7f, 0xe7, 0xa0, 0xf0, 0x88, 0x69, 0xa1, 0x26,
-	0x14, 0x86, 0xd8, 0xd7, 0x0d, 0xdd, 0xd7, 0xab, 0xdc, 0x2a, 0x77, 0xad, 0xb4, 0x7e, 0x55, 0x9a,
-	0x72, 0x0e, 0xa9, 0x75, 0xf0, 0x1c, 0xf7, 0xfc, 0x5d, 0x26, 0xae, 0x45, 0x8a, 0xe8, 0x36, 0x64,
-	0x3d, 0x07, 0xf7, 0xaa, 0x19, 0x6a, 0xe0, 0x83, 0xa9, 0x06, 0xc2, 0x5d, 0xdb, 0x0e, 0xee, 0x69,
-	0x54, 0x05, 0xdd, 0x85, 0x9c, 0xe7, 0xeb, 0xfe, 0xc8, 0xab, 0xf2, 0x33, 0x76, 0x8f, 0x94, 0xa9,
-	0xb8, 0xc6, 0xd4, 0xc4, 0x3f, 0x33, 0x70, 0x3e, 0x69, 0x17, 0x5d, 0x02, 0xd0, 0x1d, 0xf3, 0x21,
-	0x76, 0x89, 0x15, 0x7a, 0xa6, 0xa2, 0x96, 0x98, 0x41, 0x5b, 0x20, 0xf8, 0xba, 0x77, 0xe8, 0x55,
-	0x33, 0xab, 0xfc, 0xb5, 0xd2, 0xfa, 0x27, 0x73, 0x79, 0x2b, 0x75, 0x88, 0x8a, 0x62, 0xf9, 0xee,
-	0xb1, 0x16, 0xa8, 0x93, 0x7d, 0xec, 0x91, 0xef, 0x8c, 0x7c, 0xb2, 0x44, 0xbd, 0x2f, 0x6a, 0x89,
-	0x19, 0xb4, 0x0a, 0x25, 0x03, 0x7b, 0x3d, 0xd7, 0x74, 0x48, 0x26, 0xab, 0x59, 0x2a, 0x90, 0x9c,
-	0x42, 0x55, 0xc8, 0xf7, 0x6d, 0xb7, 0x87, 0x55, 0xa3, 0x2a, 0xd0, 0xd5, 0x70, 0x88, 0x10, 0x64,
-	0x2d, 0x7d, 0x88, 0xab, 0x39, 0x3a, 0x4d, 0xbf, 0x51, 0x0d, 0x0a, 0xa6, 0xe5, 0x63, 0xd7, 0xd2,
-	0x8f, 0xaa, 0xf9, 0x55, 0xee, 0x5a, 0x41, 0x8b, 0xc6, 0xb5, 0x6f, 0x00, 0x62, 0x07, 0x51, 0x05,
-	0xf8, 0x43, 0x7c, 0xcc, 0x8e, 0x4e, 0x3e, 0xd1, 0x2d, 0x10, 0x68, 0x09, 0xb0, 0x0c, 0x5d, 0x9e,
-	0x7a, 0x66, 0x62, 0x85, 0x66, 0x27, 0x90, 0xff, 0x3c, 0xb3, 0xc1, 0x89, 0x3f, 0xf3, 0x50, 0x4e,
-	0x07, 0x1f, 0x6d, 0x45, 0x59, 0x23, 0x9b, 0x94, 0xd7, 0xa5, 0x39, 0xb3, 0x26, 0xa5, 0x93, 0x87,
-	0x36, 0xa0, 0x38, 0x72, 0x0c, 0xdd, 0xc7, 0x46, 0xc3, 0x67, 0xbe, 0xd5, 0xa4, 0xa0, 0x19, 0xa4,
-	0xb0, 0x19, 0xa4, 0x4e, 0xd8, 0x2d, 0x5a, 0x2c, 0x8c, 0x1e, 0x84, 0x59, 0xe4, 0x69, 0x16, 0xd7,
-	0xe7, 0x75, 0x60, 0x32, 0x8f, 0x37, 0x41, 0xc0, 0xae, 0x6b, 0xbb, 0x34, 0x43, 0xa5, 0xf5, 0x4b,
-	0x53, 0x2d, 0x29, 0x44, 0x4a, 0x0b, 0x84, 0x6b, 0x8f, 0x66, 0x44, 0xfc, 0x46, 0x3a, 0xe2, 0xef,
-	0x9d, 0x1a, 0xf1, 0x64, 0xb4, 0x37, 0x20, 0xc7, 0x82, 0x0c, 0x90, 0xfb, 0xaa, 0xab, 0x74, 0x15,
-	0xb9, 0x72, 0x0e, 0x15, 0x41, 0xd0, 0x94, 0x86, 0xfc, 0xb8, 0x92, 0x21, 0xd3, 0x5b, 0x0d, 0x75,
-	0x47, 0x91, 0x2b, 0x3c, 0x2a, 0x41, 0x5e, 0x56, 0x76, 0x94, 0x8e, 0x22, 0x57, 0xb2, 0xe2, 0xdf,
-	0x1c, 0xa0, 0xf0, 0xb4, 0xaa, 0xf5, 0xc2, 0xee, 0x51, 0x08, 0x59, 0x4e, 0x87, 0x37, 0x53, 0x1d,
-	0x5e, 0x9f, 0x19, 0xed, 0x78, 0xff, 0x44, 0xaf, 0xab, 0x63, 0xbd, 0xbe, 0xb6, 0x88, 0x99, 0x74,
-	0xd7, 0x7f, 0xcf, 0xc3, 0xc5, 0x93, 0xf7, 0x22, 0x7d, 0x19, 0x9a, 0x53, 0x8d, 0xb0, 0xff, 0xe3,
-	0x19, 0xd4, 0x86, 0x9c, 0x69, 0x39, 0x23, 0x3f, 0x04, 0x80, 0xcd, 0x05, 0x0f, 0x23, 0xa9, 0x54,
-	0x3b, 0xa8, 0x21, 0x66, 0x8a, 0x34, 0xa7, 0xa3, 0xbb, 0xd8, 0xf2, 0x55, 0x83, 0x41, 0x41, 0x34,
-	0x46, 0x77, 0xa0, 0x10, 0x5a, 0x66, 0x35, 0x76, 0x79, 0xe6, 0x96, 0x5a, 0xa4, 0x82, 0x3e, 0x83,
-	0x82, 0x8c, 0x75, 0xe3, 0xc8, 0xb4, 0x30, 0x85, 0x89, 0xd3, 0x5b, 0x24, 0x92, 0xad, 0x3d, 0x85,
-	0x52, 0xc2, 0xd3, 0x13, 0x4a, 0xf4, 0x76, 0xba, 0x44, 0xaf, 0x4c, 0x2f, 0x51, 0x42, 0x21, 0x0f,
-	0x89, 0x68, 0xb2, 0x50, 0x5f, 0xe5, 0xa0, 0x3a, 0x2d, 0x4f, 0x68, 0x7f, 0x0c, 0x20, 0x36, 0x16,
-	0x4e, 0xf5, 0xf2, 0xa0, 0x42, 0x4b, 0x43, 0xc5, 0x17, 0x8b, 0xbb, 0x32, 0x09, 0x1a, 0x9b, 0x90,
-	0x0b, 0xa0, 0x9e, 0x65, 0x74, 0xae, 0xe0, 0x31, 0x15, 0x34, 0x80, 0xf3, 0xc6, 0xb1, 0xa5, 0x0f,
-	0xcd, 0x1e, 0x35, 0x5c, 0x15, 0xa8, 0x5f, 0xcd, 0xc5, 0xfd, 0x92, 0x13, 0x56, 0x02, 0xf7, 0x52,
-	0x86, 0x63, 0x68, 0xcb, 0x2d, 0x00, 0x6d, 0x48, 0x85, 0x95, 0xc0, 0xd1, 0x07, 0x58, 0x37, 0xb0,
-	0xeb, 0x51, 0xb6, 0x99, 0xf3, 0x88, 0x69, 0xcd, 0x9a, 0x3e, 0x03, 0x25, 0xef, 0xa4, 0x4b, 0xf0,
-	0xea, 0xa9, 0x28, 0x19, 0x1f, 0x3f, 0x51, 0x86, 0xb5, 0xa7, 0xf0, 0xe6, 0x44, 0x18, 0x96, 0x89,
-	0xc7, 0x4f, 0x22, 0x3c, 0x2e, 0x41, 0xbe, 0xbb, 0xb7, 0xbd, 0xd7, 0x7a, 0xb4, 0x57, 0x39, 0x87,
-	0x56, 0xa0, 0xd8, 0x6e, 0x3e, 0x50, 0xe4, 0x2e, 0x01, 0x62, 0x0e, 0xbd, 0x01, 0x25, 0x75, 0xef,
-	0xd9, 0xbe, 0xd6, 0xba, 0xaf, 0x29, 0xed, 0x76, 0x25, 0x43, 0xd7, 0xbb, 0xcd, 0xa6, 0xa2, 0xc8,
-	0x14, 0xa8, 0x63, 0xd0, 0xce, 0x12, 0x3b, 0x8d, 0x7b, 0x2d, 0x8d, 0x80, 0xb6, 0x20, 0xfe, 0xc3,
-	0x41, 0x45, 0xc6, 0x0e, 0xb6, 0x0c, 0x6c, 0xf5, 0x8e, 0x9b, 0xb6, 0xd5, 0x37, 0x07, 0xa8, 0x0d,
-	0x05, 0x17, 0x7f, 0x37, 0x32, 0x5d, 0x4c, 0xfa, 0x87, 0x14, 0xc7, 0xad, 0xa9, 0xfe, 0x8e, 0x2b,
-	0x4b, 0x1a, 0xd3, 0x0c, 0x0a, 0x22, 0x32, 0x84, 0x2e, 0x80, 0xa0, 0xbf, 0xd4, 0xcd, 0xa0, 0x79,
-	0x04, 0x2d, 0x18, 0xd4, 0x2c, 0x58, 0x49, 0x29, 0x9c, 0x10, 0xba, 0xfb, 0xe9, 0xd0, 0xad, 0x9d,
-	0x1a, 0xba, 0xd8, 0x9d, 0x7d, 0xdd, 0xd5, 0x87, 0xd8, 0xc7, 0xae, 0x97, 0x0c, 0xe7, 0xaf, 0x1c,
-	0x64, 0xe9, 0xf5, 0x68, 0x29, 0xb4, 0xf4, 0x69, 0x8a, 0x96, 0xe6, 0xb8, 0xd6, 0x04, 0x44, 0xb4,
-	0x39, 0x46, 0x44, 0x57, 0x4e, 0x57, 0x4c, 0x53, 0xcf, 0x5f, 0x59, 0x28, 0x84, 0xf6, 0xc8, 0x25,
-	0xaf, 0x3f, 0xb2, 0x7a, 0xb4, 0x28, 0x71, 0x9f, 0x45, 0x2d, 0x39, 0x85, 0x94, 0x31, 0xba, 0xb9,
-	0x3e, 0xd3, 0xc9, 0x13, 0x09, 0x66, 0x3b, 0x51, 0x12, 0x01, 0x8e, 0xd5, 0x67, 0x1b, 0x9a, 0x59,
-	0x0a, 0xd9, 0x44, 0x29, 0x24, 0x30, 0x4d, 0x58, 0x1c, 0xd3, 0x26, 0x40, 0x23, 0x77, 0x56, 0xd0,
-	0x40, 0x37, 0x20, 0x4f, 0x1e, 0x48, 0xf6, 0xc8, 0x67, 0xc8, 0xf3, 0xce, 0x04, 0xce, 0xcb, 0xec,
-	0x7d, 0xa4, 0x85, 0x92, 0xaf, 0x9b, 0xed, 0xfe, 0xf3, 0x3e, 0xf9, 0x29, 0x13, 0x40, 0x27, 0xc3,
-	0x9e, 0x7b, 0x63, 0x7c, 0xfa, 0xe1, 0x1c, 0x15, 0xbb, 0x3c, 0x06, 0xbd, 0x09, 0x42, 0x9f, 0xd6,
-	0x37, 0x3f, 0x83, 0x47, 0xb6, 0x88, 0x94, 0x16, 0x08, 0x9f, 0xed, 0x62, 0x2d, 0x7e, 0x9c, 0xc4,
-	0xdb, 0x76, 0xa7, 0x41, 0x71, 0x32, 0x71, 0x01, 0xe6, 0x12, 0x58, 0x9a, 0x11, 0x5f, 0x71, 0x50,
-	0x9d, 0x16, 0x4e, 0xd4, 0x81, 0x2c, 0xd9, 0x80, 0x85, 0xec, 0xcb, 0x85, 0xf3, 0x91, 0xc0, 0x56,
-	0x52, 0x14, 0x1a, 0xb5, 0x46, 0x9b, 0xe7, 0xc8, 0xd4, 0x3d, 0x1a, 0xc2, 0xa2, 0x16, 0x0c, 0xc4,
-	0x4d, 0x28, 0xa7, 0xa5, 0x51, 0x01, 0xb2, 0x72, 0xa3, 0xd3, 0xa8, 0x9c, 0x23, 0x07, 0x69, 0xb6,
-	0xf6, 0x3a, 0x5a, 0x6b, 0xa7, 0xc2, 0x21, 0x04, 0x65, 0xf9, 0xf1, 0x5e, 0x63, 0x57, 0x6d, 0x3e,
-	0x6b, 0x75, 0x3b, 0xfb, 0xdd, 0x4e, 0x25, 0x23, 0xfe, 0xc1, 0x41, 0x39, 0xcd, 0x70, 0xcb, 0x81,
-	0xc7, 0xbb, 0x29, 0x78, 0xfc, 0x68, 0x4e, 0x76, 0x4d, 0x00, 0xa5, 0x32, 0x06, 0x94, 0xd7, 0xe7,
-	0x35, 0x91, 0x86, 0xcc, 0x1f, 0x79, 0x40, 0x93, 0x7b, 0xc4, 0x65, 0xc5, 0x2d, 0x52, 0x56, 0x17,
-	0x21, 0x47, 0xee, 0x60, 0xaa, 0xc1, 0x12, 0xc0, 0x46, 0xa8, 0x15, 0x01, 0x2d, 0x3f, 0x83, 0x32,
-	0x27, 0x5d, 0x39, 0x11, 0x72, 0x45, 0x38, 0x6f, 0x46, 0x52, 0xaa, 0xc1, 0x5e, 0xf0, 0xa9, 0x39,
-	0xb4, 0x06, 0x59, 0xb2, 0x3d, 0x43, 0xcc, 0x19, 0xb7, 0x0a, 0x2a, 0x9a, 0xba, 0xcf, 0xe7, 0xfe,
-	0x47, 0xf7, 0xf9, 0xdf, 0x78, 0xb8, 0x70, 0x52, 0x16, 0xd1, 0xce, 0x18, 0xf6, 0xdc, 0x5c, 0xa8,
-	0x08, 0x96, 0x87, 0x42, 0x31, 0x3f, 0xf1, 0x8b, 0xf3, 0xd3, 0x99, 0xc0, 0x68, 0x92, 0xd5, 0x84,
-	0xb3, 0xb2, 0x9a, 0xf8, 0xfc, 0xb5, 0xde, 0x23, 0x29, 0x58, 0x6e, 0xab, 0xfb, 0xfb, 0x8a, 0x5c,
-	0xc9, 0x89, 0x3f, 0x70, 0x50, 0x4e, 0x83, 0x02, 0x2a, 0x43, 0xc6, 0x0c, 0x5f, 0xc3, 0x19, 0x33,
-	0xfe, 0x85, 0x29, 0x93, 0xf8, 0x85, 0x69, 0x03, 0x8a, 0x3d, 0x17, 0xb3, 0xd4, 0xf0, 0xb3, 0x53,
-	0x13, 0x09, 0x93, 0x37, 0xf7, 0x00, 0x5b, 0x38, 0x20, 0x65, 0x1a, 0x62, 0x5e, 0x4b, 0xcc, 0x88,
-	0x97, 0x41, 0xa0, 0x71, 0x45, 0x55, 0xc8, 0x0f, 0xb1, 0xe7, 0xe9, 0x03, 0xcc, 0x7c, 0x09, 0x87,
-	0x62, 0x0b, 0x04, 0xda, 0xe6, 0x44, 0xc4, 0x1d, 0x59, 0x84, 0xd7, 0x99, 0x73, 0xe1, 0x10, 0xbd,
-	0x0b, 0x45, 0xe2, 0xa7, 0xe7, 0xe8, 0x3d, 0xcc, 0x5e, 0xd9, 0xf1, 0x04, 0x39, 0xa1, 0x2a, 0xb3,
-	0x26, 0xcd, 0xa8, 0xb2, 0xf8, 0x0b, 0x07, 0x2b, 0x71, 0x3a, 0x76, 0x75, 0x87, 0x10, 0x34, 0xfd,
-	0x66, 0x77, 0xea, 0xb5, 0x39, 0xb2, 0xb8, 0xab, 0x3b, 0x12, 0xfd, 0x60, 0xaf, 0x3f, 0xfa, 0x5d,
-	0x7b, 0x02, 0x10, 0x4f, 0x2e, 0xbf, 0x13, 0xb7, 0xa1, 0x1c, 0x2f, 0xec, 0x98, 0x9e, 0x4f, 0x0c,
-	0x26, 0x3d, 0x9f, 0xcf, 0x20, 0xfd, 0x77, 0x2f, 0xff, 0xb5, 0x40, 0x97, 0x0e, 0x72, 0x34, 0x85,
-	0x37, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x41, 0x5e, 0x49, 0xf4, 0xbe, 0x16, 0x00, 0x00,
+	// 2567 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x5f, 0x6f, 0x1b, 0xc7,
+	0x11, 0xf7, 0x91, 0xc7, 0x7f, 0x43, 0x49, 0x61, 0x16, 0x6e, 0x7a, 0x25, 0x5a, 0x47, 0xb9, 0x34,
+	0x89, 0x61, 0xd7, 0x54, 0x2c, 0xff, 0x93, 0xed, 0x3a, 0x0e, 0x4d, 0x9e, 0x23, 0xc6, 0xb4, 0xa8,
+	0x9c, 0x28, 0x3b, 0x4e, 0x90, 0x04, 0x2b, 0x72, 0x49, 0x9f, 0x75, 0xbc, 0xbb, 0xdc, 0x1d, 0x6d,
+	0x0b, 0xfd, 0x06, 0x7d, 0x68, 0x51, 0xa0, 0x40, 0x5f, 0x8b, 0x02, 0xfd, 0x0c, 0x45, 0x9f, 0x5a,
+	0xa0, 0x7d, 0x28, 0x50, 0x14, 0xfd, 0x02, 0x2d, 0xd0, 0xd7, 0x3e, 0xb4, 0xfd, 0x0a, 0xc5, 0xee,
+	0xfd, 0xdb, 0x25, 0x29, 0x1d, 0xa9, 0xd0, 0x7d, 0xe8, 0x8b, 0xc4, 0x5d, 0xce, 0xcc, 0xee, 0xcd,
+	0xfe, 0x66, 0xe6, 0xb7, 0x73, 0x84, 0x6f, 0x39, 0x87, 0xc3, 0x0d, 0xff, 0xc8, 0x21, 0x5e, 0xf0,
+	0xb7, 0xe6, 0xb8, 0xb6, 0x6f, 0xa3, 0x6f, 0x0f, 0x0c, 0xcf, 0x33, 0x6c, 0xab, 0xf6, 0xc2, 0x76,
+	0x0f, 0x07, 0xa6, 0xfd, 0xc2, 0xab, 0xb1, 0xaf, 0xab, 0x6f, 0x0e, 0x6d, 0x7b, 0x68, 0x92, 0x0d,
+	0x26, 0x76, 0x30, 0x1e, 0x6c, 0xf8, 0xc6, 0x88, 0x78, 0x3e, 0x1e, 0x39, 0x81, 0x66, 0xf5, 0xdc,
+	0xa4, 0x40, 0x7f, 0xec, 0x62, 0x9f, 0x9a, 0x0a, 0xbe, 0x6f, 0x0f, 0x0d, 0xff, 0xe9, 0xf8, 0xa0,
+	0xd6, 0xb3, 0x47, 0x1b, 0xe1, 0x22, 0xd1, 0xff, 0x4b, 0xf1, 0x62, 0x1b, 0xe2, 0xae, 0xfa, 0xcf,
+	0xb1, 0x39, 0x16, 0x3f, 0x07, 0xd6, 0xd4, 0x3f, 0x4b, 0x50, 0x7c, 0x1c, 0x6a, 0xa1, 0x06, 0x14,
+	0x47, 0xc4, 0xc7, 0x7d, 0xec, 0x63, 0x45, 0x5a, 0x97, 0xce, 0x97, 0x37, 0xdf, 0xab, 0x1d, 0xf3,
+	0x1c, 0xb5, 0xce, 0xc1, 0x33, 0xd2, 0xf3, 0x1f, 0x86, 0xe2, 0x7a, 0xac, 0x88, 0x6e, 0x82, 0xec,
+	0x39, 0xa4, 0xa7, 0x64, 0x98, 0x81, 0x77, 0x8e, 0x35, 0x10, 0xad, 0xba, 0xe7, 0x90, 0x9e, 0xce,
+	0x54, 0xd0, 0x5d, 0xc8, 0x7b, 0x3e, 0xf6, 0xc7, 0x9e, 0x92, 0x4d, 0x59, 0x3d, 0x56, 0x66, 0xe2,
+	0x7a, 0xa8, 0xa6, 0xfe, 0x3e, 0x0b, 0x2b, 0xbc, 0x5d, 0x74, 0x0e, 0x00, 0x3b, 0xc6, 0x23, 0xe2,
+	0x52, 0x2b, 0xec, 0x99, 0x4a, 0x3a, 0x37, 0x83, 0xee, 0x43, 0xce, 0xc7, 0xde, 0xa1, 0xa7, 0x64,
+	0xd6, 0xb3, 0xe7, 0xcb, 0x9b, 0xef, 0xcf, 0xb5, 0xdb, 0x5a, 0x97, 0xaa, 0x68, 0x96, 0xef, 0x1e,
+	0xe9, 0x81, 0x3a, 0x5d, 0xc7, 0x1e, 0xfb, 0xce, 0xd8, 0xa7, 0x5f, 0xb1, 0xdd, 0x97, 0x74, 0x6e,
+	0x06, 0xad, 0x43, 0xb9, 0x4f, 0xbc, 0x9e, 0x6b, 0x38, 0xf4, 0x24, 0x15, 0x99, 0x09, 0xf0, 0x53,
+	0x48, 0x81, 0xc2, 0xc0, 0x76, 0x7b, 0xa4, 0xd5, 0x57, 0x72, 0xec, 0xdb, 0x68, 0x88, 0x10, 0xc8,
+	0x16, 0x1e, 0x11, 0x25, 0xcf, 0xa6, 0xd9, 0x67, 0x54, 0x85, 0xa2, 0x61, 0xf9, 0xc4, 0xb5, 0xb0,
+	0xa9, 0x14, 0xd6, 0xa5, 0xf3, 0x45, 0x3d, 0x1e, 0xa3, 0x1b, 0x50, 0x72, 0x89, 0x4f, 0x2c, 0xb6,
+	0x52, 0x91, 0x39, 0xf2, 0x3b, 0xb5, 0x00, 0x54, 0xb5, 0x08, 0x54, 0xb5, 0x66, 0x08, 0x2a, 0x3d,
+	0x91, 0x45, 0xef, 0xc2, 0xda, 0x08, 0xbf, 0x6c, 0xd8, 0x56, 0x6f, 0xec, 0xba, 0xc4, 0xea, 0x1d,
+	0x29, 0xa5, 0x75, 0xe9, 0x7c, 0x4e, 0x9f, 0x98, 0xad, 0x7e, 0x0e, 0x90, 0x78, 0x00, 0x55, 0x20,
+	0x7b, 0x48, 0x8e, 0x42, 0xdf, 0xd2, 0x8f, 0xe8, 0x06, 0xe4, 0x18, 0xc6, 0x42, 0x08, 0xbc, 0x75,
+	0xac, 0x53, 0xa9, 0x15, 0x76, 0xfc, 0x81, 0xfc, 0xad, 0xcc, 0x96, 0xa4, 0xfe, 0x3a, 0x0b, 0x6b,
+	0xe2, 0xe9, 0xa2, 0xfb, 0x31, 0x2c, 0xe8, 0x22, 0x6b, 0x9b, 0xb5, 0x39, 0x61, 0x51, 0x13, 0xd1,
+	0x81, 0xb6, 0xa0, 0x34, 0x76, 0xfa, 0xd8, 0x27, 0xfd, 0xba, 0x1f, 0xee, 0xad, 0x3a, 0xe5, 0x98,
+	0x6e, 0x14, 0x8e, 0x7a, 0x22, 0x8c, 0xb6, 0x23, 0x98, 0x64, 0x19, 0x4c, 0x36, 0xe7, 0xdd, 0xc0,
+	0x34, 0x50, 0xae, 0x42, 0x8e, 0xb8, 0xae, 0xed, 0x32, 0x08, 0x94, 0x37, 0xcf, 0x1d, 0x6b, 0x49,
+	0xa3, 0x52, 0x7a, 0x20, 0x5c, 0x7d, 0x9c, 0xe2, 0xf1, 0x2b, 0xa2, 0xc7, 0xbf, 0x77, 0xa2, 0xc7,
+	0x79, 0x6f, 0x6f, 0x41, 0x3e, 0x74, 0x32, 0x40, 0xfe, 0x93, 0x7d, 0x6d, 0x5f, 0x6b, 0x56, 0xce,
+	0xa0, 0x12, 0xe4, 0x74, 0xad, 0xde, 0x7c, 0x52, 0xc9, 0xd0, 0xe9, 0xfb, 0xf5, 0x56, 0x5b, 0x6b,
+	0x56, 0xb2, 0xa8, 0x0c, 0x85, 0xa6, 0xd6, 0xd6, 0xba, 0x5a, 0xb3, 0x22, 0xab, 0xff, 0x94, 0x00,
+	0x45, 0x4f, 0xdb, 0xb2, 0x9e, 0xdb, 0x3d, 0x06, 0xa7, 0xe5, 0xa4, 0x90, 0x86, 0x90, 0x42, 0x36,
+	0x52, 0xbd, 0x9d, 0xac, 0xcf, 0x25, 0x93, 0xd6, 0x44, 0x32, 0xb9, 0xbc, 0x88, 0x19, 0x31, 0xad,
+	0xfc, 0x45, 0x86, 0x37, 0x66, 0xaf, 0x45, 0x03, 0x3f, 0x32, 0xd7, 0xea, 0x47, 0x09, 0x26, 0x99,
+	0x41, 0x7b, 0x90, 0x37, 0x2c, 0x67, 0xec, 0x47, 0x19, 0xe6, 0xf6, 0x82, 0x0f, 0x53, 0x6b, 0x31,
+	0xed, 0x00, 0x43, 0xa1, 0x29, 0x1a, 0xfd, 0x0e, 0x76, 0x89, 0xe5, 0xb7, 0xfa, 0x61, 0xae, 0x89,
+	0xc7, 0xe8, 0x0e, 0x14, 0x23, 0xcb, 0x8a, 0x9c, 0x12, 0x7f, 0xd1, 0x92, 0x7a, 0xac, 0x82, 0xae,
+	0x43, 0xb1, 0x49, 0x70, 0xdf, 0x34, 0x2c, 0xa2, 0xe4, 0x52, 0x43, 0x24, 0x96, 0x45, 0xb7, 0x41,
+	0xf6, 0x08, 0xe9, 0x2b, 0xf9, 0x94, 0x33, 0xe7, 0x9e, 0x8e, 0x90, 0xbe, 0xce, 0x94, 0xa8, 0x93,
+	0x4c, 0x7c, 0x40, 0x4c, 0x4f, 0x29, 0x9c, 0xce, 0x49, 0x6d, 0xa6, 0x1d, 0x3a, 0x29, 0x30, 0x55,
+	0xfd, 0x12, 0xca, 0x9c, 0xef, 0x66, 0x04, 0xcd, 0x4d, 0x31, 0x68, 0xde, 0x3e, 0x3e, 0x68, 0x68,
+	0xd5, 0x7c, 0x44, 0x45, 0xb9, 0xd0, 0xa9, 0xde, 0x84, 0x32, 0xb7, 0xec, 0x0c, 0xfb, 0x67, 0x79,
+	0xfb, 0x25, 0x3e, 0xea, 0x7e, 0x99, 0x85, 0x35, 0xd1, 0x11, 0x48, 0x85, 0x15, 0x23, 0x9e, 0x89,
+	0x91, 0x24, 0xcc, 0x25, 0x59, 0x28, 0x93, 0x92, 0x85, 0x44, 0xdb, 0x33, 0xb2, 0xd0, 0x17, 0xb0,
+	0xd2, 0x3f, 0xb2, 0xf0, 0xc8, 0xe8, 0x75, 0xb9, 0xb4, 0x76, 0x73, 0x5e, 0x83, 0x4d, 0x4e, 0x37,
+	0xb0, 0x2b, 0x98, 0xab, 0xe2, 0x94, 0x74, 0x75, 0x47, 0xf4, 0xfc, 0x7b, 0x27, 0xa6, 0xab, 0x64,
+	0x6d, 0xde, 0xfb, 0x5f, 0xc2, 0xeb, 0x53, 0xbb, 0x58, 0x66, 0x62, 0xfc, 0x59, 0x09, 0x94, 0xe3,
+	0xf2, 0x02, 0xda, 0x9d, 0x28, 0x48, 0x5b, 0x0b, 0xa7, 0x96, 0xe5, 0x95, 0x26, 0x5d, 0x2c, 0x4d,
+	0x3f, 0x5c, 0x7c, 0x2b, 0xd3, 0xf0, 0xb8, 0x0d, 0xf9, 0x80, 0xbb, 0x28, 0xf2, 0xfc, 0xa1, 0x11,
+	0xaa, 0xa0, 0xe1, 0x04, 0xb6, 0x72, 0x6c, 0x5f, 0x8d, 0xc5, 0xf7, 0x95, 0x82, 0xb2, 0xa4, 0x94,
+	0xe6, 0x17, 0x28, 0xa5, 0xa8, 0x05, 0xab, 0xc1, 0x46, 0xb7, 0x09, 0xee, 0x13, 0xd7, 0x53, 0x0a,
+	0xf3, 0x3f, 0xa2, 0xa8, 0x89, 0x3a, 0xb0, 0xd2, 0xb3, 0x47, 0x0e, 0xb1, 0x3c, 0xcc, 0x71, 0xad,
+	0x8b, 0xc7, 0x5a, 0x6a, 0x70, 0xc2, 0xe1, 0xf9, 0x0b, 0x06, 0xd0, 0xa7, 0x50, 0xf0, 0x8c, 0xa1,
+	0x85, 0x4d, 0x4f, 0x29, 0x31, 0xaf, 0x7d, 0x70, 0x0a, 0x60, 0x05, 0x06, 0x02, 0x87, 0x45, 0xe6,
+	0x28, 0xbe, 0x3c, 0x1f, 0xbb, 0x01, 0xbe, 0x20, 0x1d, 0x5f, 0xb1, 0xf0, 0xff, 0x41, 0x2c, 0x57,
+	0x3f, 0x87, 0x15, 0xde, 0x2b, 0x33, 0x4c, 0x5f, 0x13, 0x4d, 0xbf, 0x79, 0xac, 0xe9, 0xc0, 0x0e,
+	0x9f, 0x28, 0x8c, 0x98, 0x41, 0x95, 0xa1, 0xb0, 0xbf, 0xf3, 0x60, 0xa7, 0xf3, 0x78, 0xa7, 0x72,
+	0x06, 0xad, 0x42, 0x69, 0xaf, 0xb1, 0xad, 0x35, 0xf7, 0x29, 0x75, 0x92, 0xd0, 0x6b, 0x50, 0x6e,
+	0xed, 0x7c, 0xb5, 0xab, 0x77, 0x3e, 0xd2, 0xb5, 0xbd, 0xbd, 0x4a, 0x86, 0x7d, 0xbf, 0xdf, 0x68,
+	0x68, 0x5a, 0x93, 0x51, 0xab, 0x84, 0x66, 0xc9, 0xd4, 0x4e, 0xfd, 0x5e, 0x47, 0xa7, 0x34, 0x2b,
+	0x47, 0xbf, 0xd8, 0xad, 0xef, 0xef, 0x69, 0xcd, 0x4a, 0x5e, 0xfd, 0x85, 0x04, 0xf9, 0x60, 0x03,
+	0xf1, 0x9d, 0x40, 0xe2, 0xee, 0x04, 0x77, 0xa0, 0xe0, 0xe0, 0x23, 0xd3, 0xc6, 0xfd, 0x45, 0x2a,
+	0x5a, 0xa4, 0x83, 0x6e, 0x01, 0xb8, 0xa4, 0x47, 0x8c, 0xe7, 0x0c, 0x23, 0xd9, 0x54, 0x8c, 0x70,
+	0xd2, 0xea, 0x3f, 0xb2, 0x80, 0xa6, 0xd1, 0x8d, 0x3e, 0x9e, 0xc8, 0x93, 0x9b, 0x0b, 0x84, 0xc6,
+	0xf2, 0x32, 0xe4, 0x59, 0xc8, 0xd9, 0x6e, 0x9f, 0xb8, 0x2c, 0x43, 0x96, 0xf4, 0x60, 0x80, 0xda,
+	0x51, 0xde, 0x94, 0x59, 0xa4, 0x5d, 0x5f, 0x64, 0x6b, 0x27, 0xd0, 0xfa, 0xdc, 0x22, 0xb4, 0x7e,
+	0x98, 0x12, 0x5b, 0x0d, 0x11, 0x96, 0x97, 0xe6, 0x8c, 0xad, 0xd0, 0x73, 0x1c, 0x48, 0xeb, 0xb3,
+	0x41, 0x3a, 0x81, 0x4a, 0x49, 0x44, 0xa5, 0x40, 0xfe, 0xd5, 0x7f, 0x49, 0x50, 0x69, 0x12, 0x87,
+	0x58, 0x7d, 0x7a, 0x07, 0x6c, 0xd8, 0xd6, 0xc0, 0x18, 0xa2, 0x3d, 0x28, 0xba, 0xe4, 0xeb, 0xb1,
+	0xe1, 0x12, 0x7a, 0xc4, 0xd4, 0x8f, 0x37, 0x8e, 0xdd, 0xe3, 0xa4, 0x72, 0x4d, 0x0f, 0x35, 0x03,
+	0x47, 0xc6, 0x86, 0xe8, 0x79, 0xe1, 0x17, 0xd8, 0x08, 0x4e, 0x39, 0xa7, 0x07, 0x83, 0xaa, 0x05,
+	0xab, 0x82, 0xc2, 0x0c, 0x77, 0x7d, 0x24, 0xba, 0xeb, 0xf2, 0x89, 0xee, 0x4a, 0xb6, 0xb3, 0x8b,
+	0x5d, 0x3c, 0x22, 0x3e, 0x71, 0x05, 0x97, 0xfd, 0x4e, 0x02, 0x99, 0xca, 0x2d, 0xe7, 0x46, 0x73,
+	0x4d, 0xb8, 0xd1, 0xcc, 0x71, 0x23, 0x66, 0xe2, 0xb4, 0x10, 0x0b, 0x77, 0x98, 0xb7, 0x4f, 0x56,
+	0x14, 0x6f, 0x2d, 0x3f, 0x2e, 0x42, 0x31, 0xb2, 0x47, 0x1b, 0x10, 0x83, 0xb1, 0xd5, 0x63, 0xa9,
+	0x97, 0x0c, 0x42, 0xaf, 0xf1, 0x53, 0x48, 0x9b, 0xb8, 0xa9, 0x5c, 0x4a, 0xdd, 0xe4, 0xcc, 0xbb,
+	0xc9, 0x03, 0x0e, 0x12, 0x01, 0x25, 0xd9, 0x48, 0x37, 0x94, 0x0a, 0x05, 0x99, 0x83, 0x02, 0x47,
+	0x4f, 0x72, 0x8b, 0xd3, 0x93, 0xa9, 0xfa, 0x9f, 0x3f, 0x75, 0xfd, 0xbf, 0x02, 0x05, 0xda, 0xbc,
+	0xb3, 0xc7, 0xbe, 0x52, 0x48, 0x6b, 0xb3, 0x44, 0x92, 0xe8, 0x16, 0xe4, 0x5c, 0xe2, 0xbb, 0x47,
+	0x21, 0x5b, 0xf8, 0xfe, 0xb1, 0xeb, 0xea, 0x54, 0x6a, 0xd7, 0x36, 0x8d, 0xde, 0x91, 0x1e, 0xa8,
+	0xa0, 0xbb, 0x50, 0xb0, 0x2d, 0x96, 0x41, 0x94, 0x52, 0x4a, 0x77, 0x8d, 0x49, 0x6d, 0x63, 0xab,
+	0x6f, 0x12, 0x57, 0x8f, 0xb4, 0x50, 0x1d, 0x20, 0x26, 0x1c, 0x44, 0x81, 0x79, 0xc1, 0xc8, 0x29,
+	0xa1, 0x1b, 0x20, 0xbf, 0x78, 0x4a, 0x2c, 0xa5, 0x3c, 0xbf, 0xdb, 0x98, 0x02, 0x7d, 0xf0, 0x1e,
+	0xee, 0x3d, 0x25, 0xca, 0x4a, 0xca, 0x83, 0x37, 0xa8, 0x54, 0xf4, 0xe0, 0x4c, 0x05, 0xb5, 0xa0,
+	0xc4, 0x8e, 0xfe, 0xa1, 0xdd, 0x27, 0xca, 0x2a, 0xab, 0x25, 0x17, 0xd3, 0x51, 0x55, 0x8f, 0x54,
+	0xf4, 0x44, 0xfb, 0x95, 0x5f, 0x0b, 0xff, 0xd7, 0x79, 0xea, 0x1d, 0x28, 0xc5, 0xcf, 0x89, 0x56,
+	0xa0, 0xd8, 0xe8, 0xec, 0x74, 0x5b, 0x3b, 0xfb, 0x5a, 0xe5, 0x0c, 0x4d, 0xdf, 0x8d, 0xfa, 0x4e,
+	0x43, 0x6b, 0x57, 0x24, 0xf5, 0x8f, 0x12, 0xac, 0xf0, 0x98, 0xa0, 0x3d, 0x84, 0x9e, 0x6d, 0xf9,
+	0x86, 0x35, 0x0e, 0x58, 0x44, 0x51, 0x8f, 0xc7, 0x48, 0x83, 0x95, 0x01, 0x36, 0xcd, 0x03, 0xdc,
+	0x3b, 0x64, 0xfd, 0xcc, 0xb9, 0xb3, 0x96, 0xa0, 0x86, 0x3e, 0x81, 0x4a, 0x34, 0x8e, 0xe8, 0xaa,
+	0x92, 0x4d, 0xc1, 0xad, 0xd0, 0x15, 0x9e, 0x52, 0x57, 0x7f, 0x2b, 0x41, 0x99, 0x0b, 0x0c, 0x9a,
+	0xd6, 0x46, 0xf8, 0x65, 0xdd, 0xf7, 0xc9, 0xc8, 0xf1, 0x03, 0x9a, 0x91, 0xd3, 0xf9, 0x29, 0x1a,
+	0xa4, 0xd4, 0x82, 0x3d, 0x18, 0x28, 0x99, 0xd4, 0x20, 0x0d, 0x25, 0xd1, 0x4d, 0x80, 0x11, 0x7e,
+	0x79, 0x2f, 0xd4, 0xcb, 0xa6, 0xe9, 0x71, 0xc2, 0xb4, 0x8f, 0xcb, 0x82, 0xb5, 0x63, 0x31, 0x66,
+	0x51, 0xd2, 0xa3, 0xa1, 0xfa, 0x35, 0x94, 0x39, 0x68, 0xa3, 0x8b, 0x90, 0xf5, 0x7d, 0x53, 0x91,
+	0xd2, 0x8c, 0x53, 0x29, 0x74, 0x2d, 0x00, 0xd1, 0x02, 0x90, 0xa4, 0xf2, 0xea, 0xbf, 0x33, 0x00,
+	0x49, 0x65, 0x40, 0xf7, 0x26, 0xf8, 0xd8, 0x85, 0x39, 0xca, 0xc9, 0xf2, 0x78, 0xd8, 0x55, 0xc8,
+	0x0d, 0x58, 0xf1, 0xc9, 0xa6, 0x70, 0xa4, 0xfb, 0x54, 0x4a, 0x0f, 0x84, 0x4f, 0xd7, 0x30, 0x45,
+	0xdb, 0xf0, 0x5a, 0x92, 0xb3, 0x98, 0xbd, 0x54, 0x66, 0x16, 0xac, 0x3a, 0xa9, 0xa6, 0xfe, 0x80,
+	0xa7, 0x4e, 0x7b, 0xdd, 0xba, 0xde, 0x15, 0x5b, 0xa4, 0x12, 0xc7, 0x92, 0x32, 0xea, 0x1f, 0x24,
+	0x50, 0x8e, 0x8b, 0x5a, 0xd4, 0x05, 0x99, 0x2e, 0x15, 0x3a, 0xff, 0xc3, 0x85, 0xc3, 0x9e, 0xa3,
+	0x50, 0xf4, 0xa0, 0x75, 0x66, 0x8d, 0xd5, 0x48, 0xd3, 0xc0, 0x5e, 0xd4, 0x66, 0x62, 0x03, 0xf5,
+	0x36, 0xac, 0x89, 0xd2, 0xa8, 0x08, 0x72, 0xb3, 0xde, 0xad, 0x57, 0xce, 0xd0, 0x07, 0xa1, 0x59,
+	0x42, 0xef, 0xb4, 0x2b, 0x12, 0x42, 0xb0, 0xd6, 0x7c, 0xb2, 0x53, 0x7f, 0xd8, 0x6a, 0x7c, 0xd5,
+	0xd9, 0xef, 0xee, 0xee, 0x77, 0x2b, 0x19, 0xf5, 0xef, 0x12, 0xac, 0x89, 0x94, 0x72, 0x39, 0x2c,
+	0xe8, 0xae, 0xc0, 0x82, 0x2e, 0xce, 0x4b, 0x67, 0x13, 0x3e, 0xa4, 0x4d, 0xf0, 0xa1, 0x05, 0x19,
+	0x71, 0xc4, 0x8c, 0x7e, 0x95, 0x05, 0x34, 0xbd, 0x46, 0x02, 0x50, 0x69, 0x11, 0x80, 0xbe, 0x01,
+	0x79, 0x7a, 0x07, 0x68, 0xf5, 0xc3, 0x03, 0x08, 0x47, 0xa8, 0x13, 0xf3, 0xa9, 0x6c, 0x0a, 0x33,
+	0x9e, 0xde, 0xca, 0x4c, 0x66, 0x35, 0xd9, 0x22, 0x94, 0x67, 0xb4, 0x08, 0x2f, 0x83, 0x4c, 0x97,
+	0x57, 0x72, 0xf3, 0x5c, 0x91, 0x99, 0xa8, 0xd0, 0xf1, 0xcd, 0xcf, 0xdf, 0xf1, 0x7d, 0xd5, 0x85,
+	0x54, 0xfd, 0x9b, 0x0c, 0x67, 0x67, 0x9d, 0x22, 0x6a, 0x4f, 0x64, 0xb1, 0xab, 0x0b, 0x81, 0x60,
+	0x79, 0xf9, 0x2c, 0xa1, 0xa1, 0xd9, 0xc5, 0x69, 0xe8, 0xe9, 0xd2, 0xda, 0x14, 0x79, 0xcd, 0x9d,
+	0x9a, 0xbc, 0x7e, 0x08, 0x45, 0x1c, 0x95, 0xcd, 0xfc, 0x7a, 0xf6, 0x44, 0x46, 0x46, 0xfd, 0x18,
+	0x16, 0x54, 0x3d, 0xd6, 0xa2, 0xc0, 0x67, 0xec, 0xac, 0x1f, 0xbe, 0x81, 0x0c, 0x47, 0x62, 0xaf,
+	0xa9, 0xb8, 0x40, 0xaf, 0x49, 0x7d, 0xf6, 0x6a, 0x7b, 0x29, 0x34, 0x81, 0x3f, 0x68, 0xed, 0xee,
+	0xb2, 0x66, 0xca, 0x8f, 0xa0, 0xcc, 0x3d, 0x16, 0x8d, 0x82, 0x01, 0x36, 0x4c, 0xb6, 0x67, 0x29,
+	0x3d, 0x0a, 0x22, 0xd9, 0xe4, 0x1c, 0x33, 0x0b, 0x9c, 0xa3, 0xfa, 0x13, 0x09, 0xd6, 0xc4, 0x2c,
+	0x89, 0xd6, 0x20, 0x63, 0x44, 0x6d, 0xff, 0x8c, 0x91, 0xbc, 0xf5, 0xcd, 0x70, 0x1d, 0x9e, 0x2d,
+	0x28, 0xf5, 0x5c, 0x12, 0x62, 0x35, 0xbd, 0x43, 0x93, 0x08, 0xd3, 0xd7, 0x54, 0x43, 0x62, 0x91,
+	0x80, 0x52, 0x30, 0xcc, 0x65, 0x75, 0x6e, 0x46, 0x7d, 0x0b, 0x72, 0xc1, 0x0d, 0x41, 0x81, 0xc2,
+	0x88, 0x78, 0x1e, 0x1e, 0x46, 0xbd, 0xa5, 0x68, 0xa8, 0x76, 0x20, 0xc7, 0xf2, 0x1e, 0x15, 0x71,
+	0xc7, 0x96, 0x6f, 0xc4, 0x9b, 0x8b, 0x86, 0xe8, 0xbb, 0x50, 0xa2, 0xfb, 0xf4, 0x1c, 0xdc, 0x23,
+	0xe1, 0x8b, 0xa9, 0x64, 0x82, 0x3e, 0x61, 0xab, 0x19, 0x66, 0xad, 0x4c, 0xab, 0xa9, 0xfe, 0x46,
+	0x82, 0xd5, 0x04, 0x9f, 0x0f, 0xb1, 0x43, 0x89, 0x31, 0xfb, 0x1c, 0xf6, 0x12, 0x2e, 0xcf, 0x01,
+	0xeb, 0x87, 0xd8, 0xa9, 0xb1, 0x0f, 0x61, 0x3b, 0x86, 0x7d, 0xae, 0x7e, 0x01, 0x90, 0x4c, 0x2e,
+	0x3f, 0x35, 0x3d, 0x80, 0xb5, 0xe4, 0x8b, 0xb6, 0xe1, 0xf9, 0xd4, 0x20, 0xbf, 0xf3, 0xf9, 0x0c,
+	0xb2, 0x7f, 0xea, 0x9f, 0x24, 0x28, 0x74, 0x5d, 0x63, 0x38, 0x24, 0xee, 0x72, 0xaa, 0xec, 0x96,
+	0x50, 0x65, 0x4f, 0x88, 0xea, 0x60, 0x51, 0xae, 0xbc, 0x7e, 0x30, 0x51, 0x5e, 0xdf, 0x4d, 0xd5,
+	0x15, 0xeb, 0xea, 0x4f, 0x65, 0x28, 0x73, 0x56, 0x53, 0x5f, 0x8e, 0x22, 0x90, 0x7b, 0xae, 0x6d,
+	0x45, 0x18, 0xa7, 0x9f, 0xd1, 0xb5, 0xf0, 0x97, 0x0d, 0xcf, 0xb1, 0x99, 0x4e, 0xbc, 0x63, 0x51,
+	0xb4, 0x1d, 0x57, 0x5b, 0x39, 0xe5, 0x97, 0x1c, 0xdc, 0x06, 0x67, 0x96, 0xd9, 0x4f, 0x61, 0xd5,
+	0x7e, 0x4e, 0x5c, 0x13, 0x3b, 0x01, 0x51, 0x57, 0x72, 0x29, 0xbd, 0x4b, 0xde, 0x60, 0x87, 0xd7,
+	0xd4, 0x45, 0x43, 0x33, 0x7f, 0xc8, 0xf1, 0x71, 0x70, 0x23, 0x37, 0x09, 0x0b, 0xcc, 0xa0, 0x8d,
+	0x70, 0xe1, 0xc4, 0x5e, 0x64, 0x20, 0x1a, 0x2e, 0xaa, 0x73, 0xda, 0xaf, 0xbc, 0x22, 0x5f, 0x86,
+	0x55, 0xe1, 0xf9, 0x28, 0x09, 0xae, 0xb7, 0xdb, 0x9d, 0xc7, 0x95, 0x33, 0x94, 0x5d, 0xd2, 0xdc,
+	0x5a, 0x91, 0x68, 0x96, 0xd5, 0xb5, 0xdd, 0x76, 0xbd, 0xa1, 0x55, 0x32, 0xea, 0xcf, 0x33, 0xf0,
+	0xfa, 0xd4, 0xa6, 0x53, 0x71, 0xd1, 0x85, 0x62, 0x80, 0x28, 0x12, 0x34, 0xa3, 0xbe, 0xc9, 0x1b,
+	0xb6, 0xd8, 0x12, 0xda, 0x89, 0xdf, 0x32, 0x67, 0xe7, 0x68, 0xf9, 0x0a, 0x3b, 0x9e, 0xf9, 0x82,
+	0xf9, 0x1b, 0xbc, 0x00, 0xfe, 0xab, 0x0c, 0xab, 0x42, 0x08, 0x71, 0xcc, 0x36, 0x20, 0x35, 0x97,
+	0xe6, 0x0b, 0xbd, 0xe5, 0xb1, 0x99, 0x26, 0xbc, 0x66, 0x62, 0xcf, 0xdf, 0xa3, 0x25, 0x7c, 0x6c,
+	0xce, 0x59, 0x61, 0x26, 0x55, 0xd0, 0x05, 0xa8, 0xd0, 0xa9, 0xd6, 0x34, 0x4f, 0x9d, 0x9a, 0xa7,
+	0xee, 0x19, 0x18, 0x2e, 0x09, 0x7e, 0xef, 0x94, 0xd5, 0x83, 0x01, 0xad, 0x2e, 0xde, 0xa1, 0xe1,
+	0x38, 0xe1, 0x6f, 0x09, 0xb2, 0x7a, 0x34, 0x4c, 0x4a, 0x6d, 0x61, 0x11, 0xca, 0xf4, 0x04, 0xca,
+	0x49, 0x88, 0x78, 0x4a, 0x31, 0x8d, 0x8b, 0x0b, 0xde, 0x4d, 0x80, 0x10, 0x9e, 0x3d, 0x6f, 0xab,
+	0xfa, 0x19, 0x54, 0x26, 0x05, 0x66, 0xa0, 0xe0, 0x7d, 0x31, 0xe8, 0x4e, 0x72, 0x27, 0x87, 0x90,
+	0xda, 0x6c, 0x2a, 0x04, 0x90, 0xaf, 0x37, 0xba, 0xad, 0x47, 0x5a, 0x45, 0xe2, 0x7f, 0x8e, 0x93,
+	0xa1, 0x8c, 0xa2, 0xa0, 0x13, 0xc7, 0x34, 0x7a, 0x78, 0x39, 0x55, 0x24, 0xa9, 0x05, 0x99, 0x94,
+	0x5a, 0x10, 0x2e, 0x3b, 0x51, 0x0b, 0xfe, 0x93, 0x81, 0x55, 0xe1, 0x9b, 0x05, 0x20, 0x2e, 0xe8,
+	0x2d, 0x0f, 0xe2, 0xd7, 0xa1, 0xf8, 0xcc, 0x36, 0xac, 0x39, 0xb1, 0x1d, 0xcb, 0xa2, 0x7b, 0xb0,
+	0x66, 0x12, 0xec, 0x11, 0xed, 0xa5, 0x63, 0xb8, 0xc4, 0xab, 0x47, 0xaf, 0xc5, 0x4f, 0xd2, 0x9e,
+	0xd0, 0x38, 0xdd, 0x0b, 0x22, 0xf5, 0x62, 0x3a, 0x0a, 0x8a, 0x20, 0xb7, 0xb5, 0xfb, 0xdd, 0x4a,
+	0xe6, 0x5e, 0xe1, 0xb3, 0x1c, 0x33, 0x71, 0x90, 0x67, 0xfb, 0xb9, 0xf2, 0xdf, 0x01, 0x00, 0xa2,
+	0x6d, 0x93, 0x5f, 0x9b, 0x2a, 0x00, 0x00,
 }
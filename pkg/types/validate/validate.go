@@ -7,11 +7,16 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/graph"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/robfig/cron"
 	"gonum.org/v1/gonum/graph/topo"
 )
 
@@ -34,6 +39,14 @@ var (
 	ErrNoWorkflow                   = errors.New("workflow id is required")
 	ErrNoID                         = errors.New("id is required")
 	ErrNoStatus                     = errors.New("status is required")
+	ErrInvalidRetryPolicy           = errors.New("invalid retry policy")
+	ErrInvalidErrorHandler          = errors.New("error handler should either continue or have one fallback")
+	ErrInvalidCompensation          = errors.New("compensation cannot have dependencies, conditions, retries or handlers")
+	ErrInvalidCondition             = errors.New("condition should be a boolean or an expression")
+	ErrInvalidCachePolicy           = errors.New("invalid cache policy")
+	ErrInvalidAwait                 = errors.New("await should be between 0 and the number of dependencies")
+	ErrInvalidTriggerSchedule       = errors.New("trigger should have either a valid cron expression, an interval or a completion")
+	ErrInvalidMaxConcurrency        = errors.New("max concurrency should not be negative")
 )
 
 type Error struct {
@@ -119,6 +132,10 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 		errs.append(ErrInvalidOutputTask)
 	}
 
+	if spec.GetMaxConcurrency() < 0 {
+		errs.append(fmt.Errorf("%v: %d", ErrInvalidMaxConcurrency, spec.GetMaxConcurrency()))
+	}
+
 	refTable := map[string]*types.TaskSpec{}
 	for taskID, task := range spec.Tasks {
 		if len(taskID) == 0 {
@@ -175,6 +192,130 @@ func TaskSpec(spec *types.TaskSpec) error {
 		errs.append(ErrTaskRequiresFnRef)
 	}
 
+	if spec.Retry != nil {
+		errs.append(RetryPolicy(spec.Retry))
+	}
+
+	if spec.OnError != nil {
+		errs.append(ErrorHandler(spec.OnError))
+	}
+
+	if spec.Cache != nil {
+		errs.append(CachePolicy(spec.Cache))
+	}
+
+	if spec.Await < 0 || int(spec.Await) > len(spec.Requires) {
+		errs.append(fmt.Errorf("%v: %d", ErrInvalidAwait, spec.Await))
+	}
+
+	if spec.Compensate != nil {
+		errs.append(Compensation(spec.Compensate))
+	}
+
+	if when := spec.GetWhen(); when != nil {
+		if t := when.ValueType(); t != typedvalues.TypeBool && t != typedvalues.TypeExpression {
+			errs.append(fmt.Errorf("%v: %v", ErrInvalidCondition, t))
+		}
+	}
+
+	return errs.getOrNil()
+}
+
+// Compensation validates the compensation of a task. A compensation is run on its own, after the invocation has
+// failed, so it cannot depend on other tasks, nor be retried, handled or compensated itself.
+func Compensation(spec *types.TaskSpec) error {
+	errs := Error{subject: "Compensation"}
+
+	if spec == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	errs.append(TaskSpec(spec))
+	if len(spec.Requires) > 0 || spec.When != nil || spec.Retry != nil || spec.OnError != nil ||
+		spec.Compensate != nil {
+		errs.append(ErrInvalidCompensation)
+	}
+
+	return errs.getOrNil()
+}
+
+// ErrorHandler validates the error handler of a task.
+func ErrorHandler(handler *types.ErrorHandler) error {
+	errs := Error{subject: "ErrorHandler"}
+
+	if handler == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	var handlers int
+	if handler.Continue {
+		handlers++
+	}
+	if handler.FallbackTask != nil {
+		handlers++
+		errs.append(TaskSpec(handler.FallbackTask))
+	}
+	if handler.FallbackWorkflow != nil {
+		handlers++
+		errs.append(WorkflowSpec(handler.FallbackWorkflow))
+	}
+	if handlers != 1 {
+		errs.append(ErrInvalidErrorHandler)
+	}
+
+	return errs.getOrNil()
+}
+
+// CachePolicy validates the cache policy of a task.
+func CachePolicy(policy *types.CachePolicy) error {
+	errs := Error{subject: "CachePolicy"}
+
+	if policy == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if d, err := ptypes.Duration(policy.Ttl); err != nil || d <= 0 {
+		errs.append(fmt.Errorf("%v: ttl should be positive, but was '%v'", ErrInvalidCachePolicy, policy.Ttl))
+	}
+
+	return errs.getOrNil()
+}
+
+// RetryPolicy validates the retry policy of a task.
+func RetryPolicy(policy *types.RetryPolicy) error {
+	errs := Error{subject: "RetryPolicy"}
+
+	if policy == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if policy.MaxAttempts < 1 {
+		errs.append(fmt.Errorf("%v: maxAttempts should be at least 1, but was %d", ErrInvalidRetryPolicy,
+			policy.MaxAttempts))
+	}
+
+	if policy.Backoff != nil {
+		if d, err := ptypes.Duration(policy.Backoff); err != nil || d < 0 {
+			errs.append(fmt.Errorf("%v: invalid backoff '%v'", ErrInvalidRetryPolicy, policy.Backoff))
+		}
+	}
+
+	if policy.MaxBackoff != nil {
+		if d, err := ptypes.Duration(policy.MaxBackoff); err != nil || d < 0 {
+			errs.append(fmt.Errorf("%v: invalid maxBackoff '%v'", ErrInvalidRetryPolicy, policy.MaxBackoff))
+		}
+	}
+
+	for _, pattern := range policy.RetryOn {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs.append(fmt.Errorf("%v: invalid retryOn pattern '%v': %v", ErrInvalidRetryPolicy, pattern, err))
+		}
+	}
+
 	return errs.getOrNil()
 }
 
@@ -248,6 +389,61 @@ func WorkflowInvocationSpec(spec *types.WorkflowInvocationSpec) error {
 	return errs.getOrNil()
 }
 
+// TriggerSpec validates the spec of a trigger, which requires the trigger to have exactly one of a cron expression, an
+// interval of at least a second, or a completion of another workflow to fire on.
+func TriggerSpec(spec *types.TriggerSpec) error {
+	errs := Error{subject: "TriggerSpec"}
+
+	if spec == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if len(spec.WorkflowId) == 0 {
+		errs.append(ErrNoWorkflow)
+	}
+
+	var sources int
+	for _, set := range []bool{len(spec.Cron) > 0, spec.Interval != nil, spec.Completion != nil} {
+		if set {
+			sources++
+		}
+	}
+
+	switch {
+	case sources > 1:
+		errs.append(fmt.Errorf("%v: more than one of cron, interval and completion are set",
+			ErrInvalidTriggerSchedule))
+	case len(spec.Cron) > 0:
+		if _, err := cron.ParseStandard(spec.Cron); err != nil {
+			errs.append(fmt.Errorf("%v: invalid cron expression '%v': %v", ErrInvalidTriggerSchedule, spec.Cron, err))
+		}
+	case spec.Interval != nil:
+		if d, err := ptypes.Duration(spec.Interval); err != nil || d < time.Second {
+			errs.append(fmt.Errorf("%v: interval should be at least 1s, but was '%v'", ErrInvalidTriggerSchedule,
+				spec.Interval))
+		}
+	case spec.Completion != nil:
+		if len(spec.Completion.WorkflowId) == 0 {
+			errs.append(fmt.Errorf("%v: completion requires a workflow", ErrInvalidTriggerSchedule))
+		}
+		for _, status := range spec.Completion.Statuses {
+			if !(types.WorkflowInvocationStatus{Status: status}).Finished() {
+				errs.append(fmt.Errorf("%v: completion status '%v' is not a terminal status",
+					ErrInvalidTriggerSchedule, status))
+			}
+		}
+	default:
+		errs.append(ErrInvalidTriggerSchedule)
+	}
+
+	if _, ok := types.TriggerSpec_OverlapPolicy_name[int32(spec.OverlapPolicy)]; !ok {
+		errs.append(fmt.Errorf("unknown overlap policy '%v'", spec.OverlapPolicy))
+	}
+
+	return errs.getOrNil()
+}
+
 func TaskInvocationSpec(spec *types.TaskInvocationSpec) error {
 	errs := Error{subject: "TaskInvocationSpec"}
 
@@ -2,8 +2,11 @@ package validate
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +68,127 @@ func TestWorkflowSpecInvalidCircularDependency(t *testing.T) {
 	spec.Tasks["first"].Require("last")
 	assert.Error(t, WorkflowSpec(spec))
 }
+
+func TestWorkflowSpecErrorHandler(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].OnError = &types.ErrorHandler{Continue: true}
+	assert.NoError(t, WorkflowSpec(spec))
+
+	spec.Tasks["middle"].OnError = &types.ErrorHandler{FallbackTask: &types.TaskSpec{FunctionRef: "fallback"}}
+	assert.NoError(t, WorkflowSpec(spec))
+
+	// An error handler should have exactly one way to handle the error.
+	spec.Tasks["middle"].OnError = &types.ErrorHandler{}
+	assert.Error(t, WorkflowSpec(spec))
+	spec.Tasks["middle"].OnError = &types.ErrorHandler{
+		Continue:     true,
+		FallbackTask: &types.TaskSpec{FunctionRef: "fallback"},
+	}
+	assert.Error(t, WorkflowSpec(spec))
+
+	// The fallback itself should be valid.
+	spec.Tasks["middle"].OnError = &types.ErrorHandler{FallbackTask: &types.TaskSpec{}}
+	assert.Error(t, WorkflowSpec(spec))
+}
+
+func TestWorkflowSpecCondition(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].When = typedvalues.MustWrap("{ output('first') > 10 }")
+	assert.NoError(t, WorkflowSpec(spec))
+
+	spec.Tasks["middle"].When = typedvalues.MustWrap(false)
+	assert.NoError(t, WorkflowSpec(spec))
+
+	// A condition should be a boolean, or an expression that evaluates to one.
+	spec.Tasks["middle"].When = typedvalues.MustWrap("yes")
+	assert.Error(t, WorkflowSpec(spec))
+
+	// A compensation always runs, so it should not have a condition.
+	spec.Tasks["middle"].When = nil
+	spec.Tasks["middle"].Compensate = &types.TaskSpec{FunctionRef: "undo", When: typedvalues.MustWrap(false)}
+	assert.Error(t, WorkflowSpec(spec))
+}
+
+func TestWorkflowSpecCache(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].Cache = &types.CachePolicy{Ttl: ptypes.DurationProto(time.Hour)}
+	assert.NoError(t, WorkflowSpec(spec))
+
+	// A cached result should expire.
+	spec.Tasks["middle"].Cache = &types.CachePolicy{}
+	assert.Error(t, WorkflowSpec(spec))
+	spec.Tasks["middle"].Cache = &types.CachePolicy{Ttl: ptypes.DurationProto(-time.Hour)}
+	assert.Error(t, WorkflowSpec(spec))
+}
+
+func TestWorkflowSpecAwait(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["last"].Requires = types.Require("first", "middle")
+	spec.Tasks["last"].Await = 1
+	spec.Tasks["last"].AwaitMode = types.TaskSpec_CANCEL
+	assert.NoError(t, WorkflowSpec(spec))
+
+	spec.Tasks["last"].Await = 3
+	assert.Error(t, WorkflowSpec(spec))
+	spec.Tasks["last"].Await = -1
+	assert.Error(t, WorkflowSpec(spec))
+}
+
+func TestWorkflowSpecCompensation(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].Compensate = &types.TaskSpec{FunctionRef: "undo"}
+	assert.NoError(t, WorkflowSpec(spec))
+
+	// The compensation itself should be valid.
+	spec.Tasks["middle"].Compensate = &types.TaskSpec{}
+	assert.Error(t, WorkflowSpec(spec))
+
+	// A compensation runs on its own, so it should not depend on other tasks.
+	spec.Tasks["middle"].Compensate = &types.TaskSpec{FunctionRef: "undo", Requires: types.Require("first")}
+	assert.Error(t, WorkflowSpec(spec))
+}
+
+func TestTriggerSpec(t *testing.T) {
+	assert.NoError(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1", Cron: "*/5 * * * *"}))
+	assert.NoError(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1", Cron: "@every 1m"}))
+	assert.NoError(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1", Interval: ptypes.DurationProto(time.Minute)}))
+
+	// A trigger has exactly one valid schedule.
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1"}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1", Cron: "every minute"}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-1", Interval: ptypes.DurationProto(0)}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{
+		WorkflowId: "wf-1",
+		Cron:       "@hourly",
+		Interval:   ptypes.DurationProto(time.Minute),
+	}))
+
+	// The workflow of the trigger is required.
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{Cron: "@hourly"}))
+
+	// A completion trigger matches the terminal statuses of the invocations of a workflow.
+	assert.NoError(t, TriggerSpec(&types.TriggerSpec{
+		WorkflowId: "wf-2",
+		Completion: &types.CompletionTrigger{WorkflowId: "wf-1"},
+	}))
+	assert.NoError(t, TriggerSpec(&types.TriggerSpec{
+		WorkflowId: "wf-2",
+		Completion: &types.CompletionTrigger{
+			WorkflowId: "wf-1",
+			Statuses:   []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_FAILED},
+		},
+	}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{WorkflowId: "wf-2", Completion: &types.CompletionTrigger{}}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{
+		WorkflowId: "wf-2",
+		Completion: &types.CompletionTrigger{
+			WorkflowId: "wf-1",
+			Statuses:   []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_IN_PROGRESS},
+		},
+	}))
+	assert.Error(t, TriggerSpec(&types.TriggerSpec{
+		WorkflowId: "wf-2",
+		Cron:       "@hourly",
+		Completion: &types.CompletionTrigger{WorkflowId: "wf-1"},
+	}))
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
@@ -22,6 +26,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -473,6 +479,128 @@ func TestWorkflowCancellation(t *testing.T) {
 	assert.Equal(t, api.ErrInvocationCanceled, wfi.GetStatus().GetError().Error())
 }
 
+func TestExportImport(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	spec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "fakeFinalTask",
+		Tasks: map[string]*types.TaskSpec{
+			"fakeFinalTask": {
+				FunctionRef: "noop",
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, spec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	// Export the events of the workflow
+	exportStream, err := client.Admin.Export(ctx, &apiserver.ExportRequest{
+		Types: []string{types.TypeWorkflow},
+	})
+	assert.NoError(t, err)
+	var exported []*fes.Event
+	for {
+		event, err := exportStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		if event.GetAggregate().GetId() == wf.ID() {
+			exported = append(exported, event)
+		}
+	}
+	assert.NotEmpty(t, exported)
+
+	// Import the events as a copy of the workflow
+	importedID := wf.ID() + "-imported"
+	importStream, err := client.Admin.Import(ctx)
+	assert.NoError(t, err)
+	for _, event := range exported {
+		event.Aggregate.Id = importedID
+		assert.NoError(t, importStream.Send(event))
+	}
+	summary, err := importStream.CloseAndRecv()
+	assert.NoError(t, err)
+	assert.EqualValues(t, len(exported), summary.GetEvents())
+
+	imported, err := client.Workflow.Get(ctx, &types.ObjectMetadata{Id: importedID})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, imported.GetMetadata())
+	util.AssertProtoEqual(t, wf.GetSpec(), imported.GetSpec())
+	assert.Equal(t, wf.GetStatus().GetStatus(), imported.GetStatus().GetStatus())
+}
+
+func TestInvocationWatch(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: map[string]*types.TaskSpec{
+			"first": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("100ms"),
+			},
+			"second": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{output('first')}"),
+				Requires:    types.Require("first"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// The stream ends once the invocation has finished.
+	stream, err := client.Invocation.Watch(ctx, md)
+	assert.NoError(t, err)
+	var updates []*types.WorkflowInvocation
+	for {
+		wfi, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		if len(updates) > 0 {
+			assert.True(t, wfi.Generation() > updates[len(updates)-1].Generation())
+		}
+		updates = append(updates, wfi)
+	}
+	assert.True(t, len(updates) > 1)
+	last := updates[len(updates)-1]
+	assert.True(t, last.GetStatus().Successful())
+
+	// Over HTTP, the watch can be consumed as server-sent events.
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:8080/invocation/"+md.GetId()+"/watch", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Accept", apiserver.MIMEEventStream)
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, apiserver.MIMEEventStream, resp.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "data: {\"result\":"), string(body))
+	assert.True(t, strings.HasSuffix(string(body), "}\n\n"), string(body))
+
+	// Watching an invocation that does not exist fails.
+	missing, err := client.Invocation.Watch(ctx, &types.ObjectMetadata{Id: "missing"})
+	assert.NoError(t, err)
+	_, err = missing.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestInvocationInvalid(t *testing.T) {
 	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
 	defer cancelFn()
@@ -522,6 +650,758 @@ func TestInvocationFailed(t *testing.T) {
 	assert.Equal(t, len(wfSpec.Tasks), len(wfi.Status.Tasks))
 }
 
+func TestInvocationRetried(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	msg := "expected error"
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Fail,
+				Inputs:      types.Input(msg),
+				Retry: &types.RetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     ptypes.DurationProto(10 * time.Millisecond),
+					RetryOn:     []string{"^expected"},
+				},
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Finished())
+	assert.False(t, wfi.Status.Successful())
+
+	// The task should have been run for the maximum number of attempts.
+	task, ok := wfi.TaskInvocation("task1")
+	assert.True(t, ok)
+	assert.Equal(t, 3, task.GetStatus().Attempt())
+	for _, attempt := range task.GetStatus().GetAttempts() {
+		assert.Equal(t, msg, attempt.GetError().GetMessage())
+	}
+}
+
+func TestInvocationErrorHandlers(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "final",
+		Tasks: types.Tasks{
+			"optional": {
+				FunctionRef: builtin.Fail,
+				OnError:     &types.ErrorHandler{Continue: true},
+			},
+			"primary": {
+				FunctionRef: builtin.Fail,
+				OnError: &types.ErrorHandler{
+					FallbackTask: &types.TaskSpec{
+						FunctionRef: builtin.Noop,
+						Inputs:      types.Input("fallback"),
+					},
+				},
+			},
+			"final": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Tasks.primary.Output}"),
+				Requires:    types.Require("optional", "primary"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "fallback", typedvalues.MustUnwrap(wfi.Status.Output))
+
+	// The failures of the handled tasks should still be visible in the invocation.
+	optional, _ := wfi.TaskInvocation("optional")
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, optional.GetStatus().GetStatus())
+	primary, _ := wfi.TaskInvocation("primary")
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, primary.GetStatus().GetStatus())
+}
+
+func TestInvocationConditional(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "final",
+		Tasks: types.Tasks{
+			"check": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input(5),
+			},
+			"small": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("small"),
+				Requires:    types.Require("check"),
+				When:        typedvalues.MustWrap("{ $.Tasks.check.Output < 10 }"),
+			},
+			"large": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("large"),
+				Requires:    types.Require("check"),
+				When:        typedvalues.MustWrap("{ $.Tasks.check.Output >= 10 }"),
+			},
+			"afterLarge": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Tasks.large.Output}"),
+				Requires:    types.Require("large"),
+			},
+			"final": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Tasks.small.Output}"),
+				Requires: map[string]*types.TaskDependencyParameters{
+					"small": {},
+					"large": {Type: types.TaskDependencyParameters_CONTROL},
+				},
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "small", typedvalues.MustUnwrap(wfi.Status.Output))
+
+	// The skip should propagate to the tasks that use the output of the skipped task, but not to the tasks that
+	// only run after it.
+	for taskID, status := range map[string]types.TaskInvocationStatus_Status{
+		"small":      types.TaskInvocationStatus_SUCCEEDED,
+		"large":      types.TaskInvocationStatus_SKIPPED,
+		"afterLarge": types.TaskInvocationStatus_SKIPPED,
+		"final":      types.TaskInvocationStatus_SUCCEEDED,
+	} {
+		taskRun, ok := wfi.TaskInvocation(taskID)
+		assert.True(t, ok, taskID)
+		assert.Equal(t, status, taskRun.GetStatus().GetStatus(), taskID)
+	}
+}
+
+func TestInvocationCached(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "lookup",
+		Tasks: types.Tasks{
+			"lookup": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Invocation.Inputs.default}"),
+				Cache:       &types.CachePolicy{Ttl: ptypes.DurationProto(time.Minute)},
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	invoke := func(input string) *types.TaskInvocation {
+		wiSpec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+		wiSpec.Inputs = types.Input(input)
+		wfi, err := client.Invocation.InvokeSync(ctx, wiSpec)
+		assert.NoError(t, err)
+		assert.True(t, wfi.Status.Successful(), wfi.GetStatus().GetError().GetMessage())
+		assert.Equal(t, input, typedvalues.MustUnwrap(wfi.Status.Output))
+		taskRun, _ := wfi.TaskInvocation("lookup")
+		return taskRun
+	}
+
+	// Only the repeated invocation with the same inputs should reuse the result.
+	assert.False(t, invoke("foo").GetStatus().GetCached())
+	assert.True(t, invoke("foo").GetStatus().GetCached())
+	assert.False(t, invoke("bar").GetStatus().GetCached())
+}
+
+func TestInvocationAwaitCancel(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "fastest",
+		Tasks: types.Tasks{
+			"fast": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("fast"),
+			},
+			"slow": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("1m"),
+			},
+			"fastest": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Tasks.fast.Output}"),
+				Requires:    types.Require("fast", "slow"),
+				Await:       1,
+				AwaitMode:   types.TaskSpec_CANCEL,
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	// The invocation should not wait for the slow task, which is no longer needed once the fast task has succeeded.
+	start := time.Now()
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) < 30*time.Second)
+	assert.True(t, wfi.Status.Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "fast", typedvalues.MustUnwrap(wfi.Status.Output))
+	slow, _ := wfi.TaskInvocation("slow")
+	assert.Equal(t, types.TaskInvocationStatus_ABORTED, slow.GetStatus().GetStatus())
+}
+
+func TestInvocationCompensated(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "ship",
+		Tasks: types.Tasks{
+			"reserve": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("reservation-1"),
+				Compensate: &types.TaskSpec{
+					FunctionRef: builtin.Noop,
+					Inputs:      types.Input("{'release ' + output()}"),
+				},
+			},
+			"charge": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("reserve"),
+				Compensate: &types.TaskSpec{
+					FunctionRef: builtin.Noop,
+					Inputs:      types.Input("refund"),
+				},
+			},
+			"ship": {
+				FunctionRef: builtin.Fail,
+				Requires:    types.Require("charge"),
+				Compensate: &types.TaskSpec{
+					FunctionRef: builtin.Noop,
+				},
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// The stream ends once the failed invocation has been compensated.
+	stream, err := client.Invocation.Watch(ctx, md)
+	assert.NoError(t, err)
+	var wfi *types.WorkflowInvocation
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		wfi = update
+	}
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+
+	// Only the succeeded tasks should have been compensated, in reverse dependency order.
+	compensation := wfi.GetStatus().GetCompensation()
+	assert.Equal(t, types.CompensationStatus_SUCCEEDED, compensation.GetStatus())
+	assert.Equal(t, []string{"charge", "reserve"}, compensation.GetOrder())
+	assert.Len(t, compensation.GetTasks(), 2)
+	assert.Equal(t, "refund", typedvalues.MustUnwrap(compensation.GetTasks()["charge"].GetOutput()))
+	assert.Equal(t, "release reservation-1", typedvalues.MustUnwrap(compensation.GetTasks()["reserve"].GetOutput()))
+}
+
+func TestInvocationPaused(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "next",
+		Tasks: types.Tasks{
+			"sleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("1s"),
+			},
+			"next": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("done"),
+				Requires:    types.Require("sleep"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// Give the invocation some slack to show up in the store.
+	for i := 0; i < 10; i++ {
+		_, err = client.Invocation.Pause(ctx, md)
+		if status.Code(err) != codes.NotFound {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+
+	// A paused invocation cannot be paused again.
+	_, err = client.Invocation.Pause(ctx, md)
+	assert.Error(t, err)
+
+	// While paused, no new tasks should be started, even once the running task has completed.
+	time.Sleep(2 * time.Second)
+	wfi, err := client.Invocation.Get(ctx, md)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_PAUSED, wfi.GetStatus().GetStatus())
+	_, ok := wfi.TaskInvocation("next")
+	assert.False(t, ok)
+
+	// Once resumed, the invocation should complete.
+	_, err = client.Invocation.Resume(ctx, md)
+	assert.NoError(t, err)
+	_, err = client.Invocation.Resume(ctx, md)
+	assert.Error(t, err)
+	deadline := time.Now().Add(10 * time.Second)
+	for !wfi.GetStatus().Finished() && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+		wfi, err = client.Invocation.Get(ctx, md)
+		assert.NoError(t, err)
+	}
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "done", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+}
+
+func TestInvocationConcurrencyLimited(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion:     types.WorkflowAPIVersion,
+		OutputTask:     "sleep",
+		MaxConcurrency: 1,
+		Tasks: types.Tasks{
+			"sleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("1s"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	first, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	second, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// The second invocation should wait until the first invocation has finished.
+	time.Sleep(500 * time.Millisecond)
+	wfi, err := client.Invocation.Get(ctx, second)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_SCHEDULED, wfi.GetStatus().GetStatus())
+
+	var results []*types.WorkflowInvocation
+	for _, md := range []*types.ObjectMetadata{first, second} {
+		deadline := time.Now().Add(10 * time.Second)
+		wfi, err := client.Invocation.Get(ctx, md)
+		assert.NoError(t, err)
+		for !wfi.GetStatus().Finished() && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+			wfi, err = client.Invocation.Get(ctx, md)
+			assert.NoError(t, err)
+		}
+		assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+		results = append(results, wfi)
+	}
+	firstFinishedAt, err := ptypes.Timestamp(results[0].GetStatus().GetUpdatedAt())
+	assert.NoError(t, err)
+	secondStartedAt, err := ptypes.Timestamp(results[1].GetStatus().GetStartedAt())
+	assert.NoError(t, err)
+	assert.False(t, secondStartedAt.Before(firstFinishedAt))
+}
+
+func TestInvocationSignaled(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "next",
+		Tasks: types.Tasks{
+			"approval": {
+				FunctionRef: builtin.Await,
+				Inputs: map[string]*typedvalues.TypedValue{
+					builtin.AwaitInputSignal:  typedvalues.MustWrap("approved"),
+					builtin.AwaitInputTimeout: typedvalues.MustWrap("10s"),
+				},
+			},
+			"next": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{'approved by ' + $.Tasks.approval.Output}"),
+				Requires:    types.Require("approval"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// Give the invocation some slack to show up in the store.
+	signal := &apiserver.SignalRequest{Id: md.GetId(), Name: "approved", Payload: typedvalues.MustWrap("manager")}
+	for i := 0; i < 10; i++ {
+		_, err = client.Invocation.Signal(ctx, signal)
+		if status.Code(err) != codes.NotFound {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.NoError(t, err)
+
+	// The invocation receives a signal at most once.
+	_, err = client.Invocation.Signal(ctx, signal)
+	assert.Error(t, err)
+
+	stream, err := client.Invocation.Watch(ctx, md)
+	assert.NoError(t, err)
+	var wfi *types.WorkflowInvocation
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		wfi = update
+	}
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "approved by manager", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+}
+
+func TestInvocationRerun(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		Name:       "rerun",
+		OutputTask: "second",
+		Tasks: types.Tasks{
+			"first": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("first"),
+			},
+			"second": {
+				FunctionRef: builtin.Fail,
+				Inputs:      types.Input("expected error"),
+				Requires:    types.Require("first"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	spec.Labels = map[string]string{"team": "billing"}
+	wfi, err := client.Invocation.InvokeSync(ctx, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wfi.GetStatus().GetStatus())
+	first, ok := wfi.TaskInvocation("first")
+	assert.True(t, ok)
+
+	// Only failed invocations can be rerun.
+	_, err = client.Invocation.Rerun(ctx, &apiserver.RerunRequest{Id: "nonexistent"})
+	assert.Error(t, err)
+
+	// Rerun the invocation against a revision of the workflow in which the failing task has been fixed.
+	wfSpec.Tasks["second"] = &types.TaskSpec{
+		FunctionRef: builtin.Noop,
+		Inputs:      types.Input("{$.Tasks.first.Output + ' second'}"),
+		Requires:    types.Require("first"),
+	}
+	fixedWf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, fixedWf.GetMetadata())
+
+	// Only revisions of the workflow of the invocation can be used.
+	otherWf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		Name:       "other",
+		OutputTask: "first",
+		Tasks: types.Tasks{
+			"first": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, otherWf.GetMetadata())
+	_, err = client.Invocation.Rerun(ctx, &apiserver.RerunRequest{Id: wfi.ID(), WorkflowId: otherWf.ID()})
+	assert.Error(t, err)
+
+	md, err := client.Invocation.Rerun(ctx, &apiserver.RerunRequest{Id: wfi.ID(), WorkflowId: fixedWf.ID()})
+	assert.NoError(t, err)
+	assert.NotEqual(t, wfi.ID(), md.GetId())
+	// Give the invocation some slack to show up in the store and to complete.
+	var rerun *types.WorkflowInvocation
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		rerun, err = client.Invocation.Get(ctx, md)
+		if err == nil && rerun.GetStatus().Finished() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, rerun.GetStatus().Successful(), rerun.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "first second", typedvalues.MustUnwrap(rerun.GetStatus().GetOutput()))
+	assert.Equal(t, wfi.ID(), rerun.GetSpec().GetSeed().GetInvocationId())
+	assert.Equal(t, spec.GetLabels(), rerun.GetSpec().GetLabels())
+
+	// The succeeded task should not have been run again.
+	rerunFirst, ok := rerun.TaskInvocation("first")
+	assert.True(t, ok)
+	assert.Equal(t, first.GetStatus().GetUpdatedAt(), rerunFirst.GetStatus().GetUpdatedAt())
+}
+
+func TestTrigger(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{'scheduled ' + $.Invocation.Inputs.default}"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	// A trigger without a schedule is invalid.
+	_, err = client.Trigger.Create(ctx, &types.TriggerSpec{WorkflowId: wf.ID()})
+	assert.Error(t, err)
+
+	md, err := client.Trigger.Create(ctx, &types.TriggerSpec{
+		WorkflowId: wf.ID(),
+		Interval:   ptypes.DurationProto(time.Second),
+		Inputs: map[string]*typedvalues.TypedValue{
+			types.InputMain: typedvalues.MustWrap("hello"),
+		},
+		OverlapPolicy: types.TriggerSpec_SKIP,
+	})
+	assert.NoError(t, err)
+
+	// Give the trigger some slack to fire and the invocation to complete.
+	var wfi *types.WorkflowInvocation
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		trigger, err := client.Trigger.Get(ctx, md)
+		if err == nil && trigger.GetStatus().GetLastInvocationId() != "" {
+			wfi, err = client.Invocation.Get(ctx, &types.ObjectMetadata{Id: trigger.GetStatus().GetLastInvocationId()})
+			if err == nil && wfi.GetStatus().Finished() {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !assert.NotNil(t, wfi) {
+		return
+	}
+	assert.True(t, wfi.GetStatus().Successful(), wfi.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "scheduled hello", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+
+	l, err := client.Trigger.List(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+	assert.Contains(t, l.GetTriggers(), md.GetId())
+
+	_, err = client.Trigger.Delete(ctx, md)
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+	trigger, err := client.Trigger.Get(ctx, md)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TriggerStatus_DELETED, trigger.GetStatus().GetStatus())
+	fired := trigger.GetStatus().GetFired()
+
+	// A deleted trigger no longer fires.
+	time.Sleep(2 * time.Second)
+	trigger, err = client.Trigger.Get(ctx, md)
+	assert.NoError(t, err)
+	assert.Equal(t, fired, trigger.GetStatus().GetFired())
+}
+
+func TestCompletionTrigger(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	first, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("first"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, first.GetMetadata())
+	second, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Invocation.Inputs.default + ' second'}"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, second.GetMetadata())
+
+	md, err := client.Trigger.Create(ctx, &types.TriggerSpec{
+		WorkflowId: second.ID(),
+		Completion: &types.CompletionTrigger{
+			WorkflowId: first.ID(),
+			Labels:     map[string]string{"chain": "true"},
+		},
+		Inputs: map[string]*typedvalues.TypedValue{
+			types.InputMain: typedvalues.MustWrap("{$.Invocation.Output + ' then'}"),
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Trigger.Delete(ctx, md)
+
+	// An invocation without the label does not fire the trigger.
+	spec := types.NewWorkflowInvocationSpec(first.ID(), defaultDeadline())
+	wfi, err := client.Invocation.InvokeSync(ctx, spec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful())
+	spec.Labels = map[string]string{"chain": "true"}
+	wfi, err = client.Invocation.InvokeSync(ctx, spec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful())
+
+	// Give the trigger some slack to fire and the chained invocation to complete.
+	var chained *types.WorkflowInvocation
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		trigger, err := client.Trigger.Get(ctx, md)
+		if err == nil && trigger.GetStatus().GetLastInvocationId() != "" {
+			chained, err = client.Invocation.Get(ctx, &types.ObjectMetadata{Id: trigger.GetStatus().GetLastInvocationId()})
+			if err == nil && chained.GetStatus().Finished() {
+				break
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !assert.NotNil(t, chained) {
+		return
+	}
+	assert.True(t, chained.GetStatus().Successful(), chained.GetStatus().GetError().GetMessage())
+	assert.Equal(t, "first then second", typedvalues.MustUnwrap(chained.GetStatus().GetOutput()))
+	trigger, err := client.Trigger.Get(ctx, md)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, trigger.GetStatus().GetFired())
+}
+
+func TestInvocationGetAt(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Fail,
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Finished())
+
+	// Right after the first event the invocation has been created, but not yet finished.
+	created, err := client.Invocation.GetAt(ctx, &apiserver.GetAtRequest{
+		Id: wfi.ID(),
+		At: &apiserver.GetAtRequest_Index{Index: 0},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, wf.ID(), created.GetSpec().GetWorkflowId())
+	assert.False(t, created.GetStatus().Finished())
+
+	latest, err := client.Invocation.GetAt(ctx, &apiserver.GetAtRequest{
+		Id: wfi.ID(),
+		At: &apiserver.GetAtRequest_Timestamp{Timestamp: ptypes.TimestampNow()},
+	})
+	assert.NoError(t, err)
+	assert.True(t, latest.GetStatus().Finished())
+	assert.False(t, latest.GetStatus().Successful())
+
+	_, err = client.Invocation.GetAt(ctx, &apiserver.GetAtRequest{
+		Id: wfi.ID(),
+		At: &apiserver.GetAtRequest_Index{Index: 1000},
+	})
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
 func TestInvocationWithForcedOutputs(t *testing.T) {
 	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
 	defer cancelFn()
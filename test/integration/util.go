@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/fission/fission-workflows/pkg/cluster"
 	"github.com/fission/fission-workflows/pkg/scheduler"
+	"github.com/fission/fission-workflows/pkg/triggers"
 )
 
 // SetupBundle sets up and runs the workflows-bundle.
@@ -21,9 +23,12 @@ func SetupBundle(ctx context.Context, opts ...bundle.Options) bundle.Options {
 			InternalRuntime:      true,
 			InvocationController: true,
 			WorkflowController:   true,
+			Triggers:             &triggers.Config{},
+			Cluster:              &cluster.Config{},
 			HTTPGateway:          true,
 			InvocationAPI:        true,
 			WorkflowAPI:          true,
+			TriggerAPI:           true,
 			AdminAPI:             true,
 			Metrics:              true,
 			Debug:                true,